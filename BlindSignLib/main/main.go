@@ -10,6 +10,7 @@ import (
 )
 
 var clients map[int]*model.Client
+var connections map[int]*model.RestConnection
 var registerClient *model.Client
 var mtx sync.Mutex
 
@@ -25,6 +26,7 @@ func init() {
 	// a dummy client for register other clients is needed
 	registerClient = model.NewClient(0, chooseMnemonic(0), 3)
 	clients = make(map[int]*model.Client, nrOfClients)
+	connections = make(map[int]*model.RestConnection, nrOfClients)
 	mnemonicDefault = make([]string, mnemonicLength)
 	for i := 0; i < mnemonicLength; i++ {
 		mnemonicDefault[i] = wordList[0]
@@ -56,6 +58,18 @@ func giveDigits(clientLength int) []int {
 
 //export AddClient
 func AddClient(configFile string) int {
+	return addClient(configFile, "")
+}
+
+// Like AddClient, but the client sends and accepts bodies with the given encoding
+// (json, msgpack or cbor) instead of the configured one
+//
+//export AddClientWithEncoding
+func AddClientWithEncoding(configFile, encoding string) int {
+	return addClient(configFile, encoding)
+}
+
+func addClient(configFile, encoding string) int {
 	mtx.Lock()
 	defer mtx.Unlock()
 
@@ -70,11 +84,56 @@ func AddClient(configFile string) int {
 
 	client := model.NewClient(clientID, chooseMnemonic(clientID), 3)
 	client.ReloadConfig(configFile)
-	client.SetConnection(model.NewRestConnection())
+	connection := model.NewRestConnection()
+	if encoding != "" {
+		connection = model.NewRestConnectionWithEncoding(model.EncodingFromName(encoding))
+	}
+	client.SetConnection(connection)
+	// every client books as its own customer
+	name, password := "client"+strconv.Itoa(clientID), chooseMnemonic(clientID)
+	if _, err = client.RegisterCustomer(name, password); err != nil {
+		log.Fatal(err)
+	}
+	if _, err = client.Login(name, password); err != nil {
+		log.Fatal(err)
+	}
 	clients[clientID] = client
+	connections[clientID] = connection
 	return clientID
 }
 
+// Returns the number of requests, the sent and received body bytes and the mean latency of a client
+//
+//export GetTraffic
+func GetTraffic(clientID int) *C.char {
+	return C.CString(connections[clientID].Traffic().String())
+}
+
+// Runs the same load with every encoding: each round requests the system information,
+// books the flight and accesses the bonus system. Returns the traffic per encoding,
+// one line each.
+//
+//export CompareEncodings
+func CompareEncodings(configFile string, flightID int, fareClass string, rounds int) *C.char {
+	report := make([]string, 0, model.EncodingCBOR+1)
+	for encoding := model.Encoding(model.EncodingJSON); encoding <= model.EncodingCBOR; encoding++ {
+		clientID := addClient(configFile, encoding.String())
+		client := clients[clientID]
+		failed := 0
+		for i := 0; i < rounds; i++ {
+			for _, err := range []error{client.GetSystemInformation(), client.Booking(flightID, fareClass),
+				client.AccessBonusSystem()} {
+				if err != nil {
+					failed++
+				}
+			}
+		}
+		report = append(report, encoding.String()+": "+connections[clientID].Traffic().String()+
+			" failed="+strconv.Itoa(failed))
+	}
+	return C.CString(strings.Join(report, "\n"))
+}
+
 //export GetSystemInfo
 func GetSystemInfo(clientID int) *C.char {
 	if err := clients[clientID].GetSystemInformation(); err != nil {
@@ -84,8 +143,8 @@ func GetSystemInfo(clientID int) *C.char {
 }
 
 //export Booking
-func Booking(clientID, flightID int, bLevelID string) (error *C.char) {
-	if err := clients[clientID].Booking(flightID, bLevelID); err != nil {
+func Booking(clientID, flightID int, fareClass string) (error *C.char) {
+	if err := clients[clientID].Booking(flightID, fareClass); err != nil {
 		return C.CString(err.Error())
 	}
 	return C.CString("")
@@ -3,14 +3,147 @@ package config
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/tkanos/gonfig"
+	"runtime"
+	"time"
 )
 
+const defaultStatisticInterval = time.Second
+const defaultSignQueueSize = 64
+const defaultSignRetryAfter = time.Second
+const defaultMaxBodySize = 64 << 10
+const defaultSessionDuration = time.Hour
+const defaultRevocationInterval = time.Hour
+
+// default timeouts of the http server
+const (
+	defaultReadTimeout       = 15 * time.Second
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 2 * time.Minute
+)
+
+// A token bucket limit: rate requests per second with bursts of burst requests
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Reports whether requests are limited, a rate of zero disables the limit
+func (limit RateLimit) Enabled() bool {
+	return limit.Rate > 0
+}
+
+// A booking which matches all set attributes of the rule earns Codes codes of
+// the bonus level, each worth Points points. Empty attributes match all bookings.
+type EarningRule struct {
+	FareClass    string
+	CustomerTier string
+	// route distance in km, a maximum of zero has no upper limit
+	MinDistance int
+	MaxDistance int
+	BonusLevel  string
+	Codes       int
+	// one point if it is zero
+	Points int
+}
+
+// A reward of the catalogue of a bonus level, e.g. a lounge voucher, extra baggage or a seat upgrade.
+// A voucher of the reward is valid for ValidDays days.
+type Reward struct {
+	ID          string
+	Description string
+	// the value in the smallest unit of the currency, e.g. cents
+	Value     int
+	Currency  string
+	ValidDays int
+	// the price in loyalty points, rewards without a price cannot be bought with coins
+	Points int
+}
+
+// Codes of bonus level From worth Points points are exchanged for a code of bonus level To
+// worth one point, e.g. five codes of a lower level for a code of a higher level.
+type ExchangeRate struct {
+	From   string
+	To     string
+	Points int
+}
+
+// New codes of a bonus level are activated after a random delay between Min and Max seconds
+type ActivationDelay struct {
+	Min int
+	Max int
+}
+
+// A partner who redeems vouchers, e.g. a lounge or a hotel. It authenticates by its key,
+// which is independent of customer accounts.
+type Partner struct {
+	Name string
+	Key  string
+}
+
+// An action of the bonus system besides booking and participation, e.g. lounge access.
+// The bonus data of a performed action starts with BonusData.
+type ActionType struct {
+	Name      string
+	BonusData string
+}
+
 type configuration struct {
 	Name      string
 	Port      string
 	Host      string
 	GinMode   string
 	ResultDir string
+	// encoding of request and response bodies used by clients: json, msgpack or cbor
+	Encoding string
+	// seconds between two events of the statistic stream
+	StatisticInterval int
+	// number of workers of the signing pool and jobs which may wait for a worker
+	SignWorkers   int
+	SignQueueSize int
+	// seconds after which a request rejected by the signing pool should be retried
+	SignRetryAfter int
+	// limits per client and route of the token issuing endpoints and
+	// of the endpoints with expensive cryptographic operations
+	RateLimit       RateLimit
+	CryptoRateLimit RateLimit
+	// addresses or CIDR ranges of reverse proxies, only their X-Forwarded-For header is trusted
+	TrustedProxies []string
+	// maximal size of request bodies in bytes, the sizes of single routes
+	// (without version prefix) override the default
+	MaxBodySize  int64
+	MaxBodySizes map[string]int64
+	// timeouts of the http server in seconds
+	ReadTimeout       int
+	ReadHeaderTimeout int
+	WriteTimeout      int
+	IdleTimeout       int
+	// origins which may send cross-origin requests, "*" allows all origins
+	CORSOrigins []string
+	// JSON file with the flight catalogue, e.g. main/config/flights.json,
+	// the default flights are generated if it is empty
+	FlightFile string
+	// earning rules per airline code, "*" for all other airlines. The first
+	// matching rule decides, the server's default rules are used if it is empty.
+	EarningRules map[string][]EarningRule
+	// seconds for which a session of a logged in customer is valid
+	SessionDuration int
+	// additional actions of the bonus system, every bonus level offers all of them
+	ActionTypes []ActionType
+	// reward catalogues per bonus level, the server's default catalogues are used if it is empty
+	Rewards map[string][]Reward
+	// partners who may redeem vouchers
+	Partners []Partner
+	// key of the operators for the admin routes, the admin routes reject all requests if it is empty
+	AdminKey string
+	// seconds of an epoch of the published revocation filters
+	RevocationInterval int
+	// values of the denominations of loyalty coins, the server's default values are used if it is empty
+	CoinValues []int
+	// exchange rates of codes between bonus levels, the server's default rates are used if it is empty
+	ExchangeRates []ExchangeRate
+	// activation delays of new codes per bonus level, codes of other levels are activated at once
+	ActivationDelays map[string]ActivationDelay
 }
 
 var config configuration
@@ -48,3 +181,144 @@ func GetConfigGinMode() string {
 func GetConfigResultDir() string {
 	return config.ResultDir
 }
+
+func GetConfigEncoding() string {
+	return config.Encoding
+}
+
+// Returns the interval of the statistic stream, one second by default
+func GetConfigStatisticInterval() time.Duration {
+	if config.StatisticInterval <= 0 {
+		return defaultStatisticInterval
+	}
+	return time.Duration(config.StatisticInterval) * time.Second
+}
+
+// Returns the number of workers of the signing pool, one per CPU by default
+func GetConfigSignWorkers() int {
+	if config.SignWorkers <= 0 {
+		return runtime.NumCPU()
+	}
+	return config.SignWorkers
+}
+
+func GetConfigSignQueueSize() int {
+	if config.SignQueueSize <= 0 {
+		return defaultSignQueueSize
+	}
+	return config.SignQueueSize
+}
+
+func GetConfigSignRetryAfter() time.Duration {
+	if config.SignRetryAfter <= 0 {
+		return defaultSignRetryAfter
+	}
+	return time.Duration(config.SignRetryAfter) * time.Second
+}
+
+func GetConfigRateLimit() RateLimit {
+	return config.RateLimit
+}
+
+func GetConfigCryptoRateLimit() RateLimit {
+	return config.CryptoRateLimit
+}
+
+func SetConfigTrustedProxies(proxies []string) {
+	config.TrustedProxies = proxies
+}
+
+func GetConfigTrustedProxies() []string {
+	return config.TrustedProxies
+}
+
+// Returns the maximal size of request bodies of the route, 64 KiB by default
+func GetConfigMaxBodySize(route string) int64 {
+	if size, ok := config.MaxBodySizes[route]; ok && size > 0 {
+		return size
+	}
+	if config.MaxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return config.MaxBodySize
+}
+
+func GetConfigReadTimeout() time.Duration {
+	return configTimeout(config.ReadTimeout, defaultReadTimeout)
+}
+
+func GetConfigReadHeaderTimeout() time.Duration {
+	return configTimeout(config.ReadHeaderTimeout, defaultReadHeaderTimeout)
+}
+
+func GetConfigWriteTimeout() time.Duration {
+	return configTimeout(config.WriteTimeout, defaultWriteTimeout)
+}
+
+func GetConfigIdleTimeout() time.Duration {
+	return configTimeout(config.IdleTimeout, defaultIdleTimeout)
+}
+
+func configTimeout(seconds int, defaultTimeout time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func GetConfigCORSOrigins() []string {
+	return config.CORSOrigins
+}
+
+func GetConfigFlightFile() string {
+	return config.FlightFile
+}
+
+func GetConfigEarningRules() map[string][]EarningRule {
+	return config.EarningRules
+}
+
+func GetConfigSessionDuration() time.Duration {
+	return configTimeout(config.SessionDuration, defaultSessionDuration)
+}
+
+// Returns the duration of a revocation epoch, one hour by default
+func GetConfigRevocationInterval() time.Duration {
+	return configTimeout(config.RevocationInterval, defaultRevocationInterval)
+}
+
+func GetConfigActionTypes() []ActionType {
+	return config.ActionTypes
+}
+
+func GetConfigRewards() map[string][]Reward {
+	return config.Rewards
+}
+
+func SetConfigPartners(partners []Partner) {
+	config.Partners = partners
+}
+
+func GetConfigPartners() []Partner {
+	return config.Partners
+}
+
+func SetConfigAdminKey(key string) {
+	config.AdminKey = key
+}
+
+func GetConfigAdminKey() string {
+	return config.AdminKey
+}
+
+func GetConfigExchangeRates() []ExchangeRate {
+	return config.ExchangeRates
+}
+
+func GetConfigActivationDelays() map[string]ActivationDelay {
+	return config.ActivationDelays
+}
+
+func GetConfigCoinValues() []int {
+	return config.CoinValues
+}
@@ -0,0 +1,118 @@
+package crypt
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// Default number of entries of the derivation caches. The keys of an account need
+// about 1.5 KiB and an address about 100 bytes, so both caches stay below 3 MiB.
+const (
+	DefaultKeyCacheSize     = 1024
+	DefaultAddressCacheSize = 8192
+)
+
+// Caches the keys from master to external of seeds and accounts
+var KeyCache = NewLRU(DefaultKeyCacheSize)
+
+// Caches the addresses derived from external keys
+var AddressCache = NewLRU(DefaultAddressCacheSize)
+
+// A cache which is safe for concurrent use and removes the least recently
+// used entry if it is full. A cache with capacity zero stores nothing.
+// Keys have to be comparable.
+type LRU struct {
+	mux      sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[interface{}]*list.Element
+	hits     uint64
+	misses   uint64
+}
+
+type lruEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+func NewLRU(capacity int) *LRU {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &LRU{capacity: capacity, order: list.New(), entries: map[interface{}]*list.Element{}}
+}
+
+func (c *LRU) Get(key interface{}) (interface{}, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+func (c *LRU) Add(key interface{}, value interface{}) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	if c.capacity == 0 {
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	c.evict()
+}
+
+// Changes the capacity, surplus entries are removed
+func (c *LRU) Resize(capacity int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if capacity < 0 {
+		capacity = 0
+	}
+	c.capacity = capacity
+	c.evict()
+}
+
+// Removes all entries and resets the hit and miss counters
+func (c *LRU) Purge() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.order.Init()
+	c.entries = map[interface{}]*list.Element{}
+	atomic.StoreUint64(&c.hits, 0)
+	atomic.StoreUint64(&c.misses, 0)
+}
+
+func (c *LRU) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) Capacity() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.capacity
+}
+
+// Returns the number of successful and failed lookups
+func (c *LRU) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+func (c *LRU) evict() {
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
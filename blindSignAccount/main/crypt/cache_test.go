@@ -0,0 +1,36 @@
+package crypt
+
+import "testing"
+
+func TestLRU(t *testing.T) {
+	cache := NewLRU(2)
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	// a is used recently, so b is removed
+	if value, ok := cache.Get("a"); !ok || value.(int) != 1 {
+		t.Error("a not found")
+		t.Fail()
+	}
+	cache.Add("c", 3)
+	if _, ok := cache.Get("b"); ok || cache.Len() != 2 {
+		t.Errorf("b not removed, %d entries", cache.Len())
+		t.Fail()
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("wrong statistic: %d hits, %d misses", hits, misses)
+		t.Fail()
+	}
+
+	// shrinking removes the oldest entries
+	cache.Resize(1)
+	if _, ok := cache.Get("a"); ok || cache.Len() != 1 {
+		t.Errorf("a not removed, %d entries", cache.Len())
+		t.Fail()
+	}
+	cache.Resize(0)
+	cache.Add("d", 4)
+	if cache.Len() != 0 {
+		t.Errorf("disabled cache stores %d entries", cache.Len())
+		t.Fail()
+	}
+}
@@ -0,0 +1,199 @@
+package crypt
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sort"
+)
+
+// Golomb-coded set filters in the encoding of btcutil/gcs (BIP 158): the items are hashed by
+// SipHash-2-4 into [0, N*M), sorted and their differences are Golomb-Rice coded with P bits.
+// The vendored btcutil/gcs needs siphash and bstream packages which are not part of the tree,
+// so filters are built here. Filters of both implementations are interchangeable.
+const (
+	GCSKeySize = 16
+	// a false positive rate of about 1/M
+	GCSDefaultP = 19
+	GCSDefaultM = 784931
+)
+
+// A Golomb-coded set of N items. Membership tests have false positives, but no false negatives.
+type GCSFilter struct {
+	N    uint32
+	P    uint8
+	M    uint64
+	Data []byte
+}
+
+// Builds the filter of the items with the SipHash key
+func BuildGCSFilter(p uint8, m uint64, key [GCSKeySize]byte, items [][]byte) (*GCSFilter, error) {
+	if p > 32 {
+		return nil, errors.New("P of the filter is too big")
+	}
+	if uint64(len(items)) >= 1<<32 {
+		return nil, errors.New("too many items for a filter")
+	}
+	filter := &GCSFilter{N: uint32(len(items)), P: p, M: m}
+	values := make([]uint64, 0, len(items))
+	for _, item := range items {
+		values = append(values, filter.hashToRange(key, item))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	var writer bitWriter
+	var last uint64
+	for _, value := range values {
+		delta := value - last
+		last = value
+		for quotient := delta >> p; quotient > 0; quotient-- {
+			writer.writeBit(true)
+		}
+		writer.writeBit(false)
+		writer.writeBits(delta, int(p))
+	}
+	filter.Data = writer.data
+	return filter, nil
+}
+
+// Reports whether the item may be a member of the filter
+func (f *GCSFilter) Match(key [GCSKeySize]byte, item []byte) bool {
+	if f.N == 0 {
+		return false
+	}
+	target := f.hashToRange(key, item)
+	reader := bitReader{data: f.Data}
+	var value uint64
+	for i := uint32(0); i < f.N; i++ {
+		delta, ok := reader.readGolombRice(f.P)
+		if !ok {
+			return false
+		}
+		value += delta
+		if value == target {
+			return true
+		}
+		if value > target {
+			return false
+		}
+	}
+	return false
+}
+
+// Maps the hash of the item into [0, N*M) by a multiplication instead of a modulo
+func (f *GCSFilter) hashToRange(key [GCSKeySize]byte, item []byte) uint64 {
+	hi, _ := bits.Mul64(sipHash24(key, item), uint64(f.N)*f.M)
+	return hi
+}
+
+type bitWriter struct {
+	data []byte
+	// the number of bits used of the last byte
+	used uint8
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.used == 0 || w.used == 8 {
+		w.data = append(w.data, 0)
+		w.used = 0
+	}
+	if bit {
+		w.data[len(w.data)-1] |= 0x80 >> w.used
+	}
+	w.used++
+}
+
+// Writes the n lowest bits of the value, the most significant first
+func (w *bitWriter) writeBits(value uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		w.writeBit(value>>uint(i)&1 == 1)
+	}
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) readBit() (bit, ok bool) {
+	if r.pos >= len(r.data)*8 {
+		return false, false
+	}
+	bit = r.data[r.pos/8]&(0x80>>uint(r.pos%8)) != 0
+	r.pos++
+	return bit, true
+}
+
+func (r *bitReader) readGolombRice(p uint8) (uint64, bool) {
+	var quotient uint64
+	for {
+		bit, ok := r.readBit()
+		if !ok {
+			return 0, false
+		}
+		if !bit {
+			break
+		}
+		quotient++
+	}
+	remainder := uint64(0)
+	for i := uint8(0); i < p; i++ {
+		bit, ok := r.readBit()
+		if !ok {
+			return 0, false
+		}
+		remainder <<= 1
+		if bit {
+			remainder |= 1
+		}
+	}
+	return quotient<<p | remainder, true
+}
+
+// SipHash-2-4 with a 128 bit key and a 64 bit result
+func sipHash24(key [GCSKeySize]byte, data []byte) uint64 {
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13) ^ v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16) ^ v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21) ^ v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17) ^ v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	length := len(data)
+	for ; len(data) >= 8; data = data[8:] {
+		m := binary.LittleEndian.Uint64(data)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+	// the last block holds the remaining bytes and the length
+	var last [8]byte
+	copy(last[:], data)
+	last[7] = byte(length)
+	m := binary.LittleEndian.Uint64(last[:])
+	v3 ^= m
+	round()
+	round()
+	v0 ^= m
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
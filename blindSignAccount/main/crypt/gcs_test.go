@@ -0,0 +1,66 @@
+package crypt
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSipHash24(t *testing.T) {
+	// the test vectors of the SipHash paper: key 00..0f and the messages 00..(n-1)
+	var key [GCSKeySize]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	message := make([]byte, 15)
+	for i := range message {
+		message[i] = byte(i)
+	}
+	for length, expected := range map[int]uint64{0: 0x726fdb47dd0e0e31, 8: 0x93f5f5799a932462, 15: 0xa129ca6149be45e5} {
+		if hash := sipHash24(key, message[:length]); hash != expected {
+			t.Errorf("wrong hash of %d bytes: %x", length, hash)
+		}
+	}
+}
+
+func TestBuildGCSFilter(t *testing.T) {
+	key := [GCSKeySize]byte{1, 2, 3}
+	var members, others [][]byte
+	for i := 0; i < 500; i++ {
+		members = append(members, []byte("member"+strconv.Itoa(i)))
+		others = append(others, []byte("other"+strconv.Itoa(i)))
+	}
+	filter, err := BuildGCSFilter(GCSDefaultP, GCSDefaultM, key, members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// about P+2 bits per item
+	if filter.N != 500 || len(filter.Data) > 500*(GCSDefaultP+3)/8 {
+		t.Errorf("wrong filter of %d items with %d bytes", filter.N, len(filter.Data))
+	}
+	for _, member := range members {
+		if !filter.Match(key, member) {
+			t.Fatalf("member %s not matched", member)
+		}
+	}
+	falsePositives := 0
+	for _, other := range others {
+		if filter.Match(key, other) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 1 {
+		t.Errorf("%d false positives", falsePositives)
+	}
+	// the key is needed for matching
+	if filter.Match([GCSKeySize]byte{}, members[0]) && filter.Match([GCSKeySize]byte{}, members[1]) {
+		t.Error("members matched by another key")
+	}
+
+	empty, err := BuildGCSFilter(GCSDefaultP, GCSDefaultM, key, nil)
+	if err != nil || empty.N != 0 || empty.Match(key, members[0]) {
+		t.Error("wrong empty filter")
+	}
+	if _, err = BuildGCSFilter(33, GCSDefaultM, key, members); err == nil {
+		t.Error("filter with a too big P built")
+	}
+}
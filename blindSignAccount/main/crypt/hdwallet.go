@@ -1,6 +1,7 @@
 package crypt
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -33,31 +34,39 @@ func (r Reader) Read(p []byte) (n int, err error) {
 func GetWalletKeys(mnemonic string, accountID uint32, protocol bool) (seed []byte, keys []*hdkeychain.ExtendedKey, err error) {
 	// Generate a Bip32 HD wallet for the mnemonic and a user supplied password
 	seed = bip39.NewSeed(mnemonic, "")
-	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
-	if err != nil {
+	if keys, err = getKeysFromSeed(seed, accountID, protocol); err != nil {
 		return nil, nil, err
 	}
+	return seed, keys, nil
+}
 
-	purpose, _ := master.Child(hdkeychain.HardenedKeyStart + 44)
-	coin, _ := purpose.Child(hdkeychain.HardenedKeyStart + coinType)
-	account, _ := coin.Child(hdkeychain.HardenedKeyStart + 0)
-	external, _ := account.Child(accountID)
+// Derives the keys of another account of a wallet whose seed is known
+func GetWalletKeysFromSeed(seed []byte, accountID uint32) ([]*hdkeychain.ExtendedKey, error) {
+	return getKeysFromSeed(seed, accountID, false)
+}
 
-	if protocol {
-		fmt.Println("bip32 root key", master.String())
-		fmt.Println("purpose", purpose.String())
-		fmt.Println("coin", coin.String())
-		fmt.Println("account", account)
-		fmt.Println("external", external)
-	}
+// identifies the keys of an account, the seed is only stored as a hash
+type accountKey struct {
+	seedHash  [sha256.Size]byte
+	accountID uint32
+}
 
-	keys = []*hdkeychain.ExtendedKey{master, purpose, coin, account, external}
-	return seed, keys, nil
+// identifies an address by its (immutable) external key and index
+type addressKey struct {
+	key *hdkeychain.ExtendedKey
+	i   uint32
 }
 
+// Returns the i-th address of the key. Addresses are cached.
 func GetAddress(key *hdkeychain.ExtendedKey, i uint32) *btcutil.AddressPubKeyHash {
+	if cached, ok := AddressCache.Get(addressKey{key, i}); ok {
+		return cached.(*btcutil.AddressPubKeyHash)
+	}
 	add0, _ := key.Child(i)
 	address, _ := add0.Address(&chaincfg.MainNetParams)
+	if address != nil {
+		AddressCache.Add(addressKey{key, i}, address)
+	}
 	return address
 }
 
@@ -73,7 +82,16 @@ func GetPublicKey(key *hdkeychain.ExtendedKey, i uint32) *btcec.PublicKey {
 	return publicKey
 }
 
+// Derives the keys master, purpose, coin, account and external of the seed.
+// The keys are cached unless the derivation is logged.
 func getKeysFromSeed(seed []byte, accountID uint32, protocol bool) ([]*hdkeychain.ExtendedKey, error) {
+	cacheKey := accountKey{seedHash: sha256.Sum256(seed), accountID: accountID}
+	if !protocol {
+		if cached, ok := KeyCache.Get(cacheKey); ok {
+			return append([]*hdkeychain.ExtendedKey{}, cached.([]*hdkeychain.ExtendedKey)...), nil
+		}
+	}
+
 	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
 	if err != nil {
 		return nil, err
@@ -93,7 +111,8 @@ func getKeysFromSeed(seed []byte, accountID uint32, protocol bool) ([]*hdkeychai
 	}
 
 	keys := []*hdkeychain.ExtendedKey{master, purpose, coin, account, external}
-	return keys, nil
+	KeyCache.Add(cacheKey, keys)
+	return append([]*hdkeychain.ExtendedKey{}, keys...), nil
 }
 
 // Calculates the address from the seed of the given address bundle.
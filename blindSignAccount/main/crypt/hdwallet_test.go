@@ -83,3 +83,60 @@ func TestGetPKey(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGetAddressFromSeed_Cached(t *testing.T) {
+	KeyCache.Purge()
+	AddressCache.Purge()
+	seed, keys, _ := GetWalletKeys(utMnemonic, 1, false)
+	adrBundle := &AddressBundle{Seed: seed, AccountID: 1, AddressID: 3}
+
+	// the keys of the wallet are cached, so the address is derived from the same key
+	address, err := GetAddressFromSeed(adrBundle, false)
+	if err != nil || address != GetAddress(keys[4], 3) {
+		t.Error("address not taken from the cache")
+		t.Fail()
+	}
+	if hits, _ := KeyCache.Stats(); hits != 1 {
+		t.Errorf("wrong number of cache hits %d", hits)
+		t.Fail()
+	}
+
+	// the cached address equals the derived one
+	KeyCache.Purge()
+	AddressCache.Purge()
+	derived, _ := GetAddressFromSeed(adrBundle, false)
+	if derived.String() != address.String() {
+		t.Error("cached address differs from derived address")
+		t.Fail()
+	}
+}
+
+const utMnemonic = "coil early bronze maze battle any core sweet burger busy cotton impact evoke oven jeans glance clock final eight crowd tool okay mushroom shrimp"
+
+// Disables the caches until restore is called
+func disableCaches() (restore func()) {
+	keyCapacity, adrCapacity := KeyCache.Capacity(), AddressCache.Capacity()
+	KeyCache.Resize(0)
+	AddressCache.Resize(0)
+	return func() {
+		KeyCache.Resize(keyCapacity)
+		AddressCache.Resize(adrCapacity)
+	}
+}
+
+func BenchmarkGetAddressFromSeed(b *testing.B) {
+	seed, _, _ := GetWalletKeys(utMnemonic, 0, false)
+	adrBundle := &AddressBundle{Seed: seed, AccountID: 0, AddressID: 2}
+
+	b.Run("uncached", func(b *testing.B) {
+		defer disableCaches()()
+		for i := 0; i < b.N; i++ {
+			GetAddressFromSeed(adrBundle, false)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GetAddressFromSeed(adrBundle, false)
+		}
+	})
+}
@@ -17,9 +17,14 @@ type BlindBundle struct {
 	BlindSig   []byte
 }
 
+// Returns the full domain hash of a Token, which is blindly signed
+func TokenHash(token string) []byte {
+	return fdh.Sum(crypto.SHA256, 768, []byte(token))
+}
+
 func CreateBlindBundle(key rsa.PublicKey) (*BlindBundle, error) {
 	token := GenerateToken()
-	hashValue := fdh.Sum(crypto.SHA256, 768, []byte(token))
+	hashValue := TokenHash(token)
 	blindToken, unBlinder, err := rsablind.Blind(&key, hashValue)
 	if err != nil {
 		return nil, err
@@ -30,7 +35,7 @@ func CreateBlindBundle(key rsa.PublicKey) (*BlindBundle, error) {
 func GetBlindSignatureTestData(token string, key *rsa.PrivateKey) (blindToken, blindSig, hashValue, sig []byte, err error) {
 	var unBlind []byte
 	// hash it
-	hashValue = fdh.Sum(crypto.SHA256, 768, []byte(token))
+	hashValue = TokenHash(token)
 	// blind and unBlind
 	blindToken, unBlind, err = rsablind.Blind(&key.PublicKey, hashValue)
 	if err != nil {
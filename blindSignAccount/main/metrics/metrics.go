@@ -0,0 +1,342 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// content type of the prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	KindCounter   = "counter"
+	KindGauge     = "gauge"
+	KindHistogram = "histogram"
+)
+
+// default buckets of request latencies in seconds
+var DefaultBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// A counter which can be incremented concurrently
+type Counter int64
+
+func (c *Counter) Inc() {
+	atomic.AddInt64((*int64)(c), 1)
+}
+
+func (c *Counter) Add(n int64) {
+	atomic.AddInt64((*int64)(c), n)
+}
+
+func (c *Counter) Value() int64 {
+	return atomic.LoadInt64((*int64)(c))
+}
+
+func (c *Counter) Reset() {
+	atomic.StoreInt64((*int64)(c), 0)
+}
+
+type Label struct {
+	Name, Value string
+}
+
+// A single value of a metric family
+type Sample struct {
+	// appended to the family name, e.g. _bucket
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+type family struct {
+	name, help, kind string
+	collect          func() []Sample
+}
+
+// A set of metric families which is written in the prometheus text format
+type Registry struct {
+	mux      sync.Mutex
+	families map[string]*family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]*family{}}
+}
+
+// Registers a family whose samples are collected on every scrape.
+// A family registered twice replaces the former one.
+func (reg *Registry) Register(name, help, kind string, collect func() []Sample) {
+	reg.mux.Lock()
+	defer reg.mux.Unlock()
+	reg.families[name] = &family{name: name, help: help, kind: kind, collect: collect}
+}
+
+// Registers a single counter without labels
+func (reg *Registry) RegisterCounter(name, help string, counter *Counter) {
+	reg.Register(name, help, KindCounter, func() []Sample {
+		return []Sample{{Value: float64(counter.Value())}}
+	})
+}
+
+// Registers and returns a new counter vector
+func (reg *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	vec := &CounterVec{labelNames: labelNames, counters: map[string]*labeledCounter{}}
+	reg.Register(name, help, KindCounter, vec.collect)
+	return vec
+}
+
+// Registers and returns a new histogram vector with the given upper bounds
+func (reg *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	vec := &HistogramVec{buckets: buckets, labelNames: labelNames, histograms: map[string]*histogram{}}
+	reg.Register(name, help, KindHistogram, vec.collect)
+	return vec
+}
+
+// Writes all families sorted by name
+func (reg *Registry) WriteText(w io.Writer) error {
+	reg.mux.Lock()
+	families := make([]*family, 0, len(reg.families))
+	for _, f := range reg.families {
+		families = append(families, f)
+	}
+	reg.mux.Unlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	buf := bufio.NewWriter(w)
+	for _, f := range families {
+		buf.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		buf.WriteString("# TYPE " + f.name + " " + f.kind + "\n")
+		for _, sample := range f.collect() {
+			buf.WriteString(f.name + sample.Suffix)
+			writeLabels(buf, sample.Labels)
+			buf.WriteString(" " + formatValue(sample.Value) + "\n")
+		}
+	}
+	return buf.Flush()
+}
+
+// Collects the samples of a family
+func (reg *Registry) Gather(name string) []Sample {
+	reg.mux.Lock()
+	f := reg.families[name]
+	reg.mux.Unlock()
+	if f == nil {
+		return nil
+	}
+	return f.collect()
+}
+
+// Returns the current value of a sample, the labels have to match in order
+func (reg *Registry) Value(name string, labels ...Label) (float64, bool) {
+	for _, sample := range reg.Gather(name) {
+		if sample.Suffix == "" && labelsEqual(sample.Labels, labels) {
+			return sample.Value, true
+		}
+	}
+	return 0, false
+}
+
+// Counters partitioned by label values
+type CounterVec struct {
+	labelNames []string
+	mux        sync.RWMutex
+	counters   map[string]*labeledCounter
+}
+
+type labeledCounter struct {
+	labels  []Label
+	counter Counter
+}
+
+// Returns the counter of the label values, which are given in the order of the label names
+func (vec *CounterVec) With(labelValues ...string) *Counter {
+	key := strings.Join(labelValues, "\xff")
+	vec.mux.RLock()
+	lc := vec.counters[key]
+	vec.mux.RUnlock()
+	if lc != nil {
+		return &lc.counter
+	}
+
+	vec.mux.Lock()
+	defer vec.mux.Unlock()
+	if lc = vec.counters[key]; lc == nil {
+		lc = &labeledCounter{labels: makeLabels(vec.labelNames, labelValues)}
+		vec.counters[key] = lc
+	}
+	return &lc.counter
+}
+
+func (vec *CounterVec) collect() []Sample {
+	vec.mux.RLock()
+	defer vec.mux.RUnlock()
+	samples := make([]Sample, 0, len(vec.counters))
+	for _, lc := range vec.counters {
+		samples = append(samples, Sample{Labels: lc.labels, Value: float64(lc.counter.Value())})
+	}
+	sortSamples(samples)
+	return samples
+}
+
+// Histograms partitioned by label values
+type HistogramVec struct {
+	buckets    []float64
+	labelNames []string
+	mux        sync.RWMutex
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	labels []Label
+	// non-cumulative counts of the buckets, the last one counts values above all bounds
+	counts []uint64
+	count  uint64
+	// bits of the float64 sum
+	sumBits uint64
+}
+
+// Adds an observation to the histogram of the label values
+func (vec *HistogramVec) Observe(value float64, labelValues ...string) {
+	h := vec.with(labelValues)
+	idx := sort.SearchFloat64s(vec.buckets, value)
+	atomic.AddUint64(&h.counts[idx], 1)
+	atomic.AddUint64(&h.count, 1)
+	for {
+		oldBits := atomic.LoadUint64(&h.sumBits)
+		newBits := math.Float64bits(math.Float64frombits(oldBits) + value)
+		if atomic.CompareAndSwapUint64(&h.sumBits, oldBits, newBits) {
+			return
+		}
+	}
+}
+
+// Returns the number of observations of the label values
+func (vec *HistogramVec) Count(labelValues ...string) uint64 {
+	return atomic.LoadUint64(&vec.with(labelValues).count)
+}
+
+func (vec *HistogramVec) with(labelValues []string) *histogram {
+	key := strings.Join(labelValues, "\xff")
+	vec.mux.RLock()
+	h := vec.histograms[key]
+	vec.mux.RUnlock()
+	if h != nil {
+		return h
+	}
+
+	vec.mux.Lock()
+	defer vec.mux.Unlock()
+	if h = vec.histograms[key]; h == nil {
+		h = &histogram{labels: makeLabels(vec.labelNames, labelValues), counts: make([]uint64, len(vec.buckets)+1)}
+		vec.histograms[key] = h
+	}
+	return h
+}
+
+func (vec *HistogramVec) collect() []Sample {
+	vec.mux.RLock()
+	histograms := make([]*histogram, 0, len(vec.histograms))
+	for _, h := range vec.histograms {
+		histograms = append(histograms, h)
+	}
+	vec.mux.RUnlock()
+	sort.Slice(histograms, func(i, j int) bool {
+		return labelsLess(histograms[i].labels, histograms[j].labels)
+	})
+
+	var samples []Sample
+	for _, h := range histograms {
+		var cumulative uint64
+		for idx, bound := range vec.buckets {
+			cumulative += atomic.LoadUint64(&h.counts[idx])
+			samples = append(samples, Sample{Suffix: "_bucket", Value: float64(cumulative),
+				Labels: append(append([]Label{}, h.labels...), Label{"le", formatValue(bound)})})
+		}
+		count := atomic.LoadUint64(&h.count)
+		samples = append(samples,
+			Sample{Suffix: "_bucket", Value: float64(count),
+				Labels: append(append([]Label{}, h.labels...), Label{"le", "+Inf"})},
+			Sample{Suffix: "_sum", Labels: h.labels, Value: math.Float64frombits(atomic.LoadUint64(&h.sumBits))},
+			Sample{Suffix: "_count", Labels: h.labels, Value: float64(count)})
+	}
+	return samples
+}
+
+func makeLabels(names, values []string) []Label {
+	labels := make([]Label, len(names))
+	for idx, name := range names {
+		labels[idx].Name = name
+		if idx < len(values) {
+			labels[idx].Value = values[idx]
+		}
+	}
+	return labels
+}
+
+func writeLabels(buf *bufio.Writer, labels []Label) {
+	if len(labels) == 0 {
+		return
+	}
+	buf.WriteByte('{')
+	for idx, label := range labels {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(label.Name + `="` + escapeLabelValue(label.Value) + `"`)
+	}
+	buf.WriteByte('}')
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func labelsEqual(a, b []Label) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+func labelsLess(a, b []Label) bool {
+	for idx := 0; idx < len(a) && idx < len(b); idx++ {
+		if a[idx].Value != b[idx].Value {
+			return a[idx].Value < b[idx].Value
+		}
+	}
+	return len(a) < len(b)
+}
+
+func sortSamples(samples []Sample) {
+	sort.Slice(samples, func(i, j int) bool { return labelsLess(samples[i].Labels, samples[j].Labels) })
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCounter_Concurrent(t *testing.T) {
+	var counter Counter
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				counter.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+	if counter.Value() != 10000 {
+		t.Errorf("wrong counter value %d", counter.Value())
+		t.Fail()
+	}
+	counter.Reset()
+	if counter.Value() != 0 {
+		t.Errorf("counter not reset: %d", counter.Value())
+		t.Fail()
+	}
+}
+
+func TestRegistry_WriteText(t *testing.T) {
+	var requests Counter
+	var out bytes.Buffer
+
+	reg := NewRegistry()
+	reg.RegisterCounter("requests_total", "All requests.", &requests)
+	vec := reg.NewCounterVec("responses_total", "Responses by status.", "status")
+	histogram := reg.NewHistogramVec("latency_seconds", "Latencies.", []float64{0.1, 1}, "route")
+	reg.Register("size", "A \"gauge\".", KindGauge, func() []Sample {
+		return []Sample{{Labels: []Label{{"map", "a\"b"}}, Value: 3}}
+	})
+
+	requests.Add(2)
+	vec.With("200").Inc()
+	vec.With("404").Add(3)
+	histogram.Observe(0.05, "/x")
+	histogram.Observe(0.1, "/x")
+	histogram.Observe(2, "/x")
+
+	if err := reg.WriteText(&out); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	expected := `# HELP latency_seconds Latencies.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/x",le="0.1"} 2
+latency_seconds_bucket{route="/x",le="1"} 2
+latency_seconds_bucket{route="/x",le="+Inf"} 3
+latency_seconds_sum{route="/x"} 2.15
+latency_seconds_count{route="/x"} 3
+# HELP requests_total All requests.
+# TYPE requests_total counter
+requests_total 2
+# HELP responses_total Responses by status.
+# TYPE responses_total counter
+responses_total{status="200"} 1
+responses_total{status="404"} 3
+# HELP size A "gauge".
+# TYPE size gauge
+size{map="a\"b"} 3
+`
+	if out.String() != expected {
+		t.Errorf("wrong text format:\n%s", out.String())
+		t.Fail()
+	}
+
+	if value, found := reg.Value("responses_total", Label{"status", "404"}); !found || value != 3 {
+		t.Errorf("wrong value %f", value)
+		t.Fail()
+	}
+	if histogram.Count("/x") != 3 || strings.Contains(out.String(), "NaN") {
+		t.Error("wrong histogram count")
+		t.Fail()
+	}
+}
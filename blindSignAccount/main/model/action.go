@@ -0,0 +1,119 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"errors"
+	"sync"
+)
+
+// Returns the bonus data of an action performed at a bonus level for the chosen reward
+type ActionHandler func(bLevel *BonusLevel, rewardID string) (string, error)
+
+// A type of action of the bonus system. Every bonus level has an action variant with its own
+// key pair, token maps and statistic per registered action type.
+type ActionType struct {
+	ID   int
+	Name string
+	// the action whose valid tokens are used for blind signatures of this action,
+	// the new token of a performed action is valid for it also
+	Entry int
+	// nil for actions which cannot be performed, e.g. the booking
+	Handler ActionHandler
+}
+
+// the registered action types, indexed by their ids
+var actionTypes = struct {
+	sync.RWMutex
+	types []*ActionType
+}{types: defaultActionTypes()}
+
+// The bonus data of a participation is a voucher for a reward of the catalogue
+func participationHandler(bLevel *BonusLevel, rewardID string) (string, error) {
+	voucher, err := bLevel.issueVoucher(rewardID)
+	if err != nil {
+		return "", err
+	}
+	return voucher.String(), nil
+}
+
+func defaultActionTypes() []*ActionType {
+	return []*ActionType{
+		{ID: ActionBooking, Name: "ActionBooking", Entry: ActionBooking},
+		{ID: ActionParticipate, Name: "ActionParticipate", Entry: ActionParticipate,
+			Handler: participationHandler},
+	}
+}
+
+// Registers a new action type, e.g. lounge access or a seat upgrade. The action is entered with
+// a participation token and the handler returns its bonus data. Bonus levels created afterwards
+// have a variant for the action. Returns the id of the action.
+func RegisterActionType(name string, handler ActionHandler) (int, error) {
+	actionTypes.Lock()
+	defer actionTypes.Unlock()
+
+	if name == "" || handler == nil {
+		return 0, errors.New("action type needs a name and a handler")
+	}
+	for _, actionType := range actionTypes.types {
+		if actionType.Name == name {
+			return 0, errors.New("action type '" + name + "' already exists")
+		}
+	}
+	id := len(actionTypes.types)
+	actionTypes.types = append(actionTypes.types, &ActionType{ID: id, Name: name, Entry: ActionParticipate, Handler: handler})
+	return id, nil
+}
+
+// Returns the registered action types ordered by id
+func ActionTypes() []ActionType {
+	actionTypes.RLock()
+	defer actionTypes.RUnlock()
+
+	types := make([]ActionType, 0, len(actionTypes.types))
+	for _, actionType := range actionTypes.types {
+		types = append(types, *actionType)
+	}
+	return types
+}
+
+// Returns the action type with the id, nil if it is not registered
+func getActionType(action int) *ActionType {
+	actionTypes.RLock()
+	defer actionTypes.RUnlock()
+
+	if action < 0 || action >= len(actionTypes.types) {
+		return nil
+	}
+	return actionTypes.types[action]
+}
+
+// Reports whether an action type with the id is registered
+func IsKnownAction(action int) bool {
+	return getActionType(action) != nil
+}
+
+// Registers the action types of the configuration which are not registered yet.
+// Their handlers prefix the bonus data by the configured text.
+func registerConfiguredActions() {
+	for _, configured := range config.GetConfigActionTypes() {
+		if actionTypeByName(configured.Name) != nil {
+			continue
+		}
+		bonusData := configured.BonusData
+		_, _ = RegisterActionType(configured.Name, func(bLevel *BonusLevel, rewardID string) (string, error) {
+			return bonusData + bLevel.GetBonusData(), nil
+		})
+	}
+}
+
+func actionTypeByName(name string) *ActionType {
+	actionTypes.RLock()
+	defer actionTypes.RUnlock()
+
+	for _, actionType := range actionTypes.types {
+		if actionType.Name == name {
+			return actionType
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Registers an action type for the test, the registered types are restored by the cleanup
+func setupActionType(t *testing.T, name string) int {
+	actionTypes.Lock()
+	saved := append([]*ActionType{}, actionTypes.types...)
+	actionTypes.Unlock()
+	t.Cleanup(func() {
+		actionTypes.Lock()
+		actionTypes.types = saved
+		actionTypes.Unlock()
+	})
+
+	action, err := RegisterActionType(name, func(bLevel *BonusLevel, rewardID string) (string, error) {
+		return name + ":" + bLevel.BonusID, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return action
+}
+
+func TestRegisterActionType(t *testing.T) {
+	action := setupActionType(t, "LoungeAccess")
+	if action != ActionParticipate+1 || !IsKnownAction(action) || IsKnownAction(action+1) {
+		t.Errorf("wrong id %d of the action", action)
+	}
+	if _, err := RegisterActionType("LoungeAccess", participationHandler); err == nil {
+		t.Error("action type registered twice")
+	}
+	if _, err := RegisterActionType("SeatUpgrade", nil); err == nil {
+		t.Error("action type without handler registered")
+	}
+
+	// the bonus levels offer the action
+	bLevel := NewBonusLevel(utLowLevelID, 10, 1)
+	if len(bLevel.ActionVariants) != 3 || bLevel.ActionVariants[action].GetName() != "LoungeAccess" {
+		t.Fatal("no variant for the action")
+	}
+	public := bLevel.CopyPublic()
+	if variant := public.ActionVariantByName("LoungeAccess"); variant == nil || variant.VariantID != action ||
+		variant.PublicKey.N.Cmp(bLevel.ActionVariants[action].PublicKey.N) != 0 || variant.SkKey != nil {
+		t.Error("wrong public copy of the action variant")
+	}
+}
+
+func TestClient_PerformAction(t *testing.T) {
+	action := setupActionType(t, "LoungeAccess")
+	client := setupClient(t)
+	server := client.con.(*utConnection).server
+
+	// setup a valid participation Token
+	initialToken := "INITIAL_TOKEN"
+	client.BLevelToRecovery[utMiddleLevelID] = "INITIAL RECOVERY TOKEN"
+	client.BLevelToTokens[utMiddleLevelID] = initialToken
+	serverParticipate := server.BonusList[utMiddleLevelID].ActionVariants[ActionParticipate]
+	serverParticipate.ValidTokens[initialToken] = false
+	serverParticipate.SeedToAddress[hex.EncodeToString(client.Seed)] = "INITIAL_ADDRESS"
+	serverParticipate.SeedToAccountID[hex.EncodeToString(client.Seed)] = 2
+
+	if _, err := client.PerformAction(utMiddleLevelID, "unknown"); err == nil {
+		t.Error("unknown action performed")
+	}
+	bonusData, err := client.PerformAction(utMiddleLevelID, "LoungeAccess")
+	if err != nil || bonusData != "LoungeAccess:"+utMiddleLevelID {
+		t.Fatalf("action not performed: %s %v", bonusData, err)
+	}
+
+	// the bonus data is mapped by the variant of the action
+	serverAction := server.BonusList[utMiddleLevelID].ActionVariants[action]
+	if len(serverAction.PkrToBonusData) != 1 || len(serverParticipate.PkrToBonusData) != 0 {
+		t.Error("bonus data not mapped by the action")
+	}
+	// and the new Token can be used for participation
+	if _, err = client.Participate(utMiddleLevelID); err != nil {
+		t.Error(err)
+	}
+
+	// a booking cannot be performed
+	if _, _, _, err = server.PerformAction(utMiddleLevelID, ActionBooking, "", []byte{1}, []byte{1}, ""); err == nil {
+		t.Error("booking performed as action")
+	}
+
+	found := false
+	for _, tuple := range server.GetStatisticSummary().BLevelToSummary[utMiddleLevelID] {
+		if tuple.BonusActionVariant == "LoungeAccess" {
+			found = tuple.Statistic[StatPkrToBonusData].NrWrites == 1
+		}
+	}
+	if !found {
+		t.Error("no statistic of the action")
+	}
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// Sets the activation delays of new codes per bonus level, the delays of other levels are removed.
+// All bonus levels have to exist and the codes have to be activated before they expire.
+// The caller has to hold a write lock of the server.
+func (s *Server) setActivationDelays(delays map[string]config.ActivationDelay) error {
+	for bLevelID, delay := range delays {
+		bLevel := s.BonusList[bLevelID]
+		if bLevel == nil {
+			return errors.New("activation delay of unknown bonus level '" + bLevelID + "'")
+		}
+		if delay.Min < 0 || delay.Max < delay.Min {
+			return errors.New("activation delay of bonus level '" + bLevelID + "' is not valid")
+		}
+		if time.Duration(delay.Max)*time.Second >= time.Duration(bLevel.ValidDuration)*24*time.Hour {
+			return errors.New("codes of bonus level '" + bLevelID + "' expire before they are activated")
+		}
+	}
+	for bLevelID, bLevel := range s.BonusList {
+		delay := delays[bLevelID]
+		bLevel.MinActivationDelay = time.Duration(delay.Min) * time.Second
+		bLevel.MaxActivationDelay = time.Duration(delay.Max) * time.Second
+	}
+	return nil
+}
+
+// Returns a random activation delay of a new code between the minimal and the maximal delay
+func (b *BonusLevel) activationDelay() time.Duration {
+	if b.MaxActivationDelay <= b.MinActivationDelay {
+		return b.MinActivationDelay
+	}
+	random, err := rand.Int(rand.Reader, big.NewInt(int64(b.MaxActivationDelay-b.MinActivationDelay)+1))
+	if err != nil {
+		// the maximal delay hides the booking also
+		return b.MaxActivationDelay
+	}
+	return b.MinActivationDelay + time.Duration(random.Int64())
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"testing"
+	"time"
+)
+
+func TestServer_setActivationDelays(t *testing.T) {
+	server := NewServer()
+
+	if err := server.setActivationDelays(map[string]config.ActivationDelay{"unknown": {Min: 1, Max: 2}}); err == nil {
+		t.Error("activation delay of an unknown bonus level set")
+	}
+	if err := server.setActivationDelays(map[string]config.ActivationDelay{utLowLevelID: {Min: 3, Max: 2}}); err == nil {
+		t.Error("activation delay with a maximum below the minimum set")
+	}
+	if err := server.setActivationDelays(map[string]config.ActivationDelay{utHighLevelID: {Max: 10 * 24 * 3600}}); err == nil {
+		t.Error("activation delay beyond the expiry set")
+	}
+	if err := server.setActivationDelays(map[string]config.ActivationDelay{utLowLevelID: {Min: 60, Max: 3600}}); err != nil {
+		t.Fatal(err)
+	}
+	low := server.BonusList[utLowLevelID]
+	if low.MinActivationDelay != time.Minute || low.MaxActivationDelay != time.Hour || server.BonusList[utHighLevelID].MaxActivationDelay != 0 {
+		t.Fatal("wrong activation delays")
+	}
+
+	// the delays are random between the minimum and the maximum and public
+	for i := 0; i < 10; i++ {
+		bCode := server.GenerateNewBonusCode(utLowLevelID)
+		if delay := bCode.NotBefore.Sub(bCode.CreatedAt); delay < time.Minute || delay > time.Hour {
+			t.Errorf("wrong activation delay %v", delay)
+		}
+	}
+	public := BonusLevelFromProto(BonusLevelToProto(low.CopyPublic()))
+	if public.MinActivationDelay != time.Minute || public.MaxActivationDelay != time.Hour {
+		t.Error("activation delays are not public")
+	}
+}
+
+func TestBonusCode_isActive(t *testing.T) {
+	bLevel := setupBonusLevel()
+	bCode := BonusCode{ValidFor: bLevel, CreatedAt: time.Now(), NotBefore: time.Now().Add(time.Hour)}
+
+	if bCode.isValidForBonusLevel(bLevel) || len(bCode.GetValidBonusLevels()) != 0 {
+		t.Error("code is valid before its activation")
+	}
+	bCode.NotBefore = time.Now().Add(-time.Second)
+	if !bCode.isValidForBonusLevel(bLevel) {
+		t.Error("activated code is not valid")
+	}
+	// the expiry is checked also
+	bCode.CreatedAt = time.Now().AddDate(0, 0, -bLevel.ValidDuration-1)
+	if bCode.isValidForBonusLevel(bLevel) {
+		t.Error("expired code is valid")
+	}
+}
+
+func TestServer_verifyCodes_activation(t *testing.T) {
+	server := NewServer()
+	active := server.GenerateNewBonusCode(utHighLevelID)
+	if err := server.setActivationDelays(map[string]config.ActivationDelay{utHighLevelID: {Min: 3600, Max: 3600}}); err != nil {
+		t.Fatal(err)
+	}
+	delayed := server.GenerateNewBonusCode(utHighLevelID)
+
+	// the code which is not activated yet does not count and is kept
+	accessible := server.verifyCodes([]string{active.CodeID, delayed.CodeID})
+	if len(accessible) != 3 || server.BonusCodes[active.CodeID] != nil || server.BonusCodes[delayed.CodeID] == nil {
+		t.Fatalf("wrong access with a code which is not activated yet: %d levels", len(accessible))
+	}
+	if len(server.verifyCodes([]string{delayed.CodeID})) != 0 {
+		t.Error("access with a code which is not activated yet")
+	}
+	if _, err := server.TransferCode(delayed.CodeID, utHighLevelID, 1, []byte{1}); err == nil {
+		t.Error("code transferred before its activation")
+	}
+	delayed.NotBefore = time.Now()
+	if accessible = server.verifyCodes([]string{delayed.CodeID}); len(accessible) == 0 || server.BonusCodes[delayed.CodeID] != nil {
+		t.Error("activated code not used")
+	}
+}
+
+func TestClient_selectCodes_activation(t *testing.T) {
+	client := setupClient(t)
+	server := client.con.(*utConnection).server
+	if err := server.setActivationDelays(map[string]config.ActivationDelay{utLowLevelID: {Max: 3600}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(client.con); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := client.Booking(1, utLowFareClass); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the client waits for the maximal delay, the random delay is not known
+	if client.BonusCodes[0].NotBefore.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("code is used before the maximal activation delay: %v", client.BonusCodes[0].NotBefore)
+	}
+	if selected := client.selectCodes(); selected != nil {
+		t.Error("codes selected before their activation")
+	}
+	if err := client.AccessBonusSystem(); err == nil || len(client.BonusCodes) != 5 {
+		t.Error("codes used before their activation")
+	}
+}
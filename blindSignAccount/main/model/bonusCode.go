@@ -9,16 +9,45 @@ import (
 type BonusCode struct {
 	CodeID    string
 	CreatedAt time.Time
+	// the code cannot be used before, see the activation delay of the bonus level
+	NotBefore time.Time
 	ValidFor  *BonusLevel
+	// the points the code is worth, codes without points are worth one point
+	Points int `json:",omitempty"`
 }
 
+// Creates a received code. The random activation delay is not known by the receiver,
+// so the code is used after the maximal delay of the bonus level.
 func NewBonusCodeWithID(codeID string, validFor *BonusLevel) *BonusCode {
-	return &BonusCode{CodeID: codeID, ValidFor: validFor}
+	bCode := &BonusCode{CodeID: codeID, ValidFor: validFor}
+	if validFor != nil && validFor.MaxActivationDelay > 0 {
+		bCode.NotBefore = time.Now().Add(validFor.MaxActivationDelay)
+	}
+	return bCode
 }
 
+// Creates a new code which is activated after the activation delay of the bonus level
 func NewBonusCode(validFor *BonusLevel) *BonusCode {
 	codeID, _ := randomString(lengthBonusCode)
-	return &BonusCode{CodeID: codeID, ValidFor: validFor, CreatedAt: time.Now()}
+	now := time.Now()
+	bCode := &BonusCode{CodeID: codeID, ValidFor: validFor, CreatedAt: now, NotBefore: now}
+	if validFor != nil {
+		bCode.NotBefore = now.Add(validFor.activationDelay())
+	}
+	return bCode
+}
+
+// Returns the points the code is worth
+func (bc *BonusCode) Value() int {
+	if bc.Points < 1 {
+		return 1
+	}
+	return bc.Points
+}
+
+// Reports whether the code is activated at the given time
+func (bc *BonusCode) isActive(t time.Time) bool {
+	return !t.Before(bc.NotBefore)
 }
 
 // Reports whether bc.createdAt is after t
@@ -37,13 +66,17 @@ func randomString(length int) (string, error) {
 	return base64.URLEncoding.EncodeToString(b), err
 }
 
-// Checks if the bonus code is expired for a given bonus level.
+// Checks if the bonus code is activated and not expired for a given bonus level.
 // A code is expired if the creation time was too long ago due to the
 // bonus levels' validDuration.
 func (bc *BonusCode) isValidForBonusLevel(bLevel *BonusLevel) bool {
+	now := time.Now()
+	if !bc.isActive(now) {
+		return false
+	}
 	// get the expiration date of the bonus level
 	expireAt := bc.CreatedAt.AddDate(0, 0, bLevel.ValidDuration)
-	if time.Now().After(expireAt) {
+	if now.After(expireAt) {
 		return false
 	}
 	return true
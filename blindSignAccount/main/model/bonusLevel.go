@@ -1,13 +1,16 @@
 package model
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/crypt"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/hex"
 	"errors"
+	"golang.org/x/crypto/ed25519"
 	"sort"
 	"sync"
+	"time"
 )
 
 const ActionBooking = 0
@@ -18,17 +21,41 @@ type BonusLevel struct {
 	BonusID string
 	// duration in days for which generated codes are valid
 	ValidDuration int
-	// the minimal number of codes needed to access this level
-	MinNrCodes int
+	// the minimal number of points needed to access this level,
+	// every code is worth the points of its denomination
+	MinPoints int
 	// access manager store valid tokens, addresses and codes for
 	// specific actions
 	ActionVariants []*BonusActionVariant
+	// denominations of codes worth more than one point. Codes worth one point
+	// are signed with the key of the booking action variant.
+	Denominations []*Denomination
+	// the catalogue of rewards which can be chosen at a participation
+	Rewards []config.Reward
+	// signs the vouchers of the rewards, the public key is published for partners
+	VoucherPublicKey ed25519.PublicKey
+	VoucherSkKey     ed25519.PrivateKey
+	// the points of the codes of other bonus levels by their ids which are exchanged
+	// for a code of this level
+	ExchangeRates map[string]int `json:",omitempty"`
+	// new codes are activated after a random delay between the minimal and the maximal delay,
+	// so the time of an access does not reveal the booking of its codes
+	MinActivationDelay time.Duration `json:",omitempty"`
+	MaxActivationDelay time.Duration `json:",omitempty"`
 
 	// list of lower bonus levels
 	// all valid codes for this level have to be valid for lower levels also
 	LowerLevels []*BonusLevel
 }
 
+// Codes of a denomination are worth its points. Every denomination has its own key,
+// so the weight of a code is proven by its signature without linking it to a booking.
+type Denomination struct {
+	Points    int
+	PublicKey rsa.PublicKey
+	SkKey     *rsa.PrivateKey // private key
+}
+
 type bonusDataPair struct {
 	Token         string
 	RecoveryToken string
@@ -37,6 +64,8 @@ type bonusDataPair struct {
 
 type BonusActionVariant struct {
 	VariantID int
+	// the name of the action type
+	Name string
 	// The servers stores a public key for every bonus level
 	// This key is used for blind signatures
 	PublicKey rsa.PublicKey
@@ -55,6 +84,9 @@ type BonusActionVariant struct {
 	PkrToBonusData map[string]*bonusDataPair
 	// marks which tokens are valid or where used in past
 	ValidTokens map[string]bool
+	// the points of the codes booking tokens are signed for,
+	// tokens for codes worth one point are not contained
+	TokenPoints map[string]int
 	SkKey       *rsa.PrivateKey // private key
 	// an additional map is needed which maps seeds to the address which
 	// was used for accessing
@@ -79,6 +111,7 @@ func NewBonusActionVariant(variantID int) *BonusActionVariant {
 		AddressToToken:    map[string]string{},
 		TokenToSeed:       map[string]string{},
 		ValidTokens:       map[string]bool{},
+		TokenPoints:       map[string]int{},
 		AddressToRecovery: map[string]string{},
 		SeedToAccessAdr:   map[string]string{},
 		PenultimateAdr:    map[string]string{},
@@ -86,25 +119,48 @@ func NewBonusActionVariant(variantID int) *BonusActionVariant {
 		PkrToBonusData:    map[string]*bonusDataPair{},
 		Statistic:         NewStatisticArray(),
 	}
+	if actionType := getActionType(variantID); actionType != nil {
+		bAV.Name = actionType.Name
+	}
 	bAV.SkKey, _ = rsa.GenerateKey(rand.Reader, crypt.KeyLength)
 	bAV.PublicKey = bAV.SkKey.PublicKey
 	return bAV
 }
 
-func NewBonusLevel(id string, duration, minNrCodes int) *BonusLevel {
+func NewBonusLevel(id string, duration, minPoints int) *BonusLevel {
 	b := &BonusLevel{BonusID: id,
-		ValidDuration:  duration,
-		MinNrCodes:     minNrCodes,
-		LowerLevels:    []*BonusLevel{},
-		ActionVariants: make([]*BonusActionVariant, 2),
+		ValidDuration: duration,
+		MinPoints:     minPoints,
+		LowerLevels:   []*BonusLevel{},
+	}
+	b.VoucherPublicKey, b.VoucherSkKey, _ = ed25519.GenerateKey(rand.Reader)
+	// a variant per registered action type
+	for _, actionType := range ActionTypes() {
+		b.ActionVariants = append(b.ActionVariants, NewBonusActionVariant(actionType.ID))
 	}
-	b.ActionVariants[ActionBooking] = NewBonusActionVariant(ActionBooking)
-	b.ActionVariants[ActionParticipate] = NewBonusActionVariant(ActionParticipate)
 	return b
 }
 
+// Returns the variant of an action, nil if the bonus level does not offer the action
+func (b *BonusLevel) actionVariant(action int) *BonusActionVariant {
+	if action < 0 || action >= len(b.ActionVariants) {
+		return nil
+	}
+	return b.ActionVariants[action]
+}
+
+// Like actionVariant, but looks the action up by its name
+func (b *BonusLevel) ActionVariantByName(name string) *BonusActionVariant {
+	for _, variant := range b.ActionVariants {
+		if variant.GetName() == name {
+			return variant
+		}
+	}
+	return nil
+}
+
 func (b BonusLevel) Equals(other BonusLevel) bool {
-	if b.BonusID != other.BonusID || b.MinNrCodes != other.MinNrCodes || b.ValidDuration != other.ValidDuration {
+	if b.BonusID != other.BonusID || b.MinPoints != other.MinPoints || b.ValidDuration != other.ValidDuration {
 		return false
 	}
 	if len(b.LowerLevels) != len(other.LowerLevels) {
@@ -132,22 +188,103 @@ func (b BonusLevel) Equals(other BonusLevel) bool {
 
 // Creates a copy for public use
 func (b *BonusLevel) CopyPublic() *BonusLevel {
-	// sync
-	b.ActionVariants[ActionBooking].Mux.Lock()
-	b.ActionVariants[ActionParticipate].Mux.Lock()
-	defer b.ActionVariants[ActionBooking].Mux.Unlock()
-	defer b.ActionVariants[ActionParticipate].Mux.Unlock()
-
 	copyBLevel := &BonusLevel{BonusID: b.BonusID, ValidDuration: b.ValidDuration,
-		MinNrCodes: b.MinNrCodes, ActionVariants: make([]*BonusActionVariant, 2)}
-	copyBLevel.ActionVariants[ActionBooking] = &BonusActionVariant{PublicKey: b.ActionVariants[ActionBooking].PublicKey}
-	copyBLevel.ActionVariants[ActionParticipate] = &BonusActionVariant{PublicKey: b.ActionVariants[ActionParticipate].PublicKey}
+		MinPoints: b.MinPoints, ActionVariants: make([]*BonusActionVariant, 0, len(b.ActionVariants)),
+		Rewards: append([]config.Reward{}, b.Rewards...), VoucherPublicKey: b.VoucherPublicKey,
+		MinActivationDelay: b.MinActivationDelay, MaxActivationDelay: b.MaxActivationDelay}
+	for _, variant := range b.ActionVariants {
+		// sync
+		variant.Mux.Lock()
+		copyBLevel.ActionVariants = append(copyBLevel.ActionVariants,
+			&BonusActionVariant{VariantID: variant.VariantID, Name: variant.Name, PublicKey: variant.PublicKey})
+		variant.Mux.Unlock()
+	}
+	for _, denomination := range b.Denominations {
+		copyBLevel.Denominations = append(copyBLevel.Denominations,
+			&Denomination{Points: denomination.Points, PublicKey: denomination.PublicKey})
+	}
+	for fromID, points := range b.ExchangeRates {
+		if copyBLevel.ExchangeRates == nil {
+			copyBLevel.ExchangeRates = map[string]int{}
+		}
+		copyBLevel.ExchangeRates[fromID] = points
+	}
 	for _, lLevel := range b.LowerLevels {
 		copyBLevel.LowerLevels = append(copyBLevel.LowerLevels, lLevel.CopyPublic())
 	}
 	return copyBLevel
 }
 
+// Adds a denomination with a new key, a known denomination keeps its key.
+// Codes worth one point need no denomination.
+func (b *BonusLevel) AddDenomination(points int) {
+	if points <= 1 || b.getDenomination(points) != nil {
+		return
+	}
+	denomination := &Denomination{Points: points}
+	denomination.SkKey, _ = rsa.GenerateKey(rand.Reader, crypt.KeyLength)
+	denomination.PublicKey = denomination.SkKey.PublicKey
+	b.Denominations = append(b.Denominations, denomination)
+	sort.Slice(b.Denominations, func(i, j int) bool {
+		return b.Denominations[i].Points < b.Denominations[j].Points
+	})
+}
+
+func (b *BonusLevel) getDenomination(points int) *Denomination {
+	for _, denomination := range b.Denominations {
+		if denomination.Points == points {
+			return denomination
+		}
+	}
+	return nil
+}
+
+// Returns the public key which signs codes worth the given points, nil if the
+// denomination does not exist
+func (b *BonusLevel) BookingPublicKey(points int) *rsa.PublicKey {
+	if points <= 1 {
+		return &b.ActionVariants[ActionBooking].PublicKey
+	}
+	if denomination := b.getDenomination(points); denomination != nil {
+		return &denomination.PublicKey
+	}
+	return nil
+}
+
+// Like BookingPublicKey, but returns the private key
+func (b *BonusLevel) bookingKey(points int) *rsa.PrivateKey {
+	if points <= 1 {
+		return b.ActionVariants[ActionBooking].SkKey
+	}
+	if denomination := b.getDenomination(points); denomination != nil {
+		return denomination.SkKey
+	}
+	return nil
+}
+
+// Marks a new booking Token as valid. The Token is signed for a code worth the given points.
+func (b *BonusLevel) addValidBookingToken(token string, points int) error {
+	if err := b.addValidToken(token, ActionBooking); err != nil {
+		return err
+	}
+	if points > 1 {
+		b.ActionVariants[ActionBooking].MuxValidTokens.Lock()
+		b.ActionVariants[ActionBooking].TokenPoints[token] = points
+		b.ActionVariants[ActionBooking].MuxValidTokens.Unlock()
+	}
+	return nil
+}
+
+// Returns the points of the code a booking Token is signed for
+func (b *BonusLevel) tokenPoints(token string) int {
+	b.ActionVariants[ActionBooking].MuxValidTokens.Lock()
+	defer b.ActionVariants[ActionBooking].MuxValidTokens.Unlock()
+	if points, found := b.ActionVariants[ActionBooking].TokenPoints[token]; found {
+		return points
+	}
+	return 1
+}
+
 // Marks a new Token as valid
 func (b *BonusLevel) addValidToken(token string, action int) error {
 	// sync
@@ -184,6 +321,41 @@ func (b *BonusLevel) isTokenValid(token string, action int) (valid bool) {
 	return true
 }
 
+// Checks that a Token is valid and marks it as used at once.
+// Reports whether the Token was valid.
+func (b *BonusLevel) useToken(token string, action int) bool {
+	// sync
+	b.ActionVariants[action].MuxValidTokens.Lock()
+	defer b.ActionVariants[action].MuxValidTokens.Unlock()
+
+	SaveRead(StatValidTokens, b.ActionVariants[action])
+	used, contained := b.ActionVariants[action].ValidTokens[token]
+	if contained == false || used == true {
+		return false
+	}
+	b.ActionVariants[action].ValidTokens[token] = true
+	SaveWrite(StatValidTokens, b.ActionVariants[action])
+	return true
+}
+
+// Removes a Token which was not used yet from the list of valid tokens.
+// Reports whether the Token was revoked; used tokens are kept.
+func (b *BonusLevel) revokeToken(token string, action int) bool {
+	// sync
+	b.ActionVariants[action].MuxValidTokens.Lock()
+	defer b.ActionVariants[action].MuxValidTokens.Unlock()
+
+	SaveRead(StatValidTokens, b.ActionVariants[action])
+	used, contained := b.ActionVariants[action].ValidTokens[token]
+	if !contained || used {
+		return false
+	}
+	delete(b.ActionVariants[action].ValidTokens, token)
+	delete(b.ActionVariants[action].TokenPoints, token)
+	SaveWrite(StatValidTokens, b.ActionVariants[action])
+	return true
+}
+
 // Deletes a given Token from the list of valid tokens
 func (b *BonusLevel) markTokenAsUsed(token string, action int) {
 	// sync
@@ -200,7 +372,11 @@ func (b *BonusLevel) refreshMaps(recoveryToken, token, address string, seed []by
 	// sync
 	b.ActionVariants[action].Mux.Lock()
 	defer b.ActionVariants[action].Mux.Unlock()
+	b.refreshMapsLocked(recoveryToken, token, address, seed, action, acntID)
+}
 
+// Like refreshMaps. The caller has to hold the lock of the action variant.
+func (b *BonusLevel) refreshMapsLocked(recoveryToken, token, address string, seed []byte, action int, acntID uint32) {
 	// get the old values
 	oldAddress := b.ActionVariants[action].SeedToAddress[hex.EncodeToString(seed)]
 	SaveRead(StatSeedToAddress, b.ActionVariants[action])
@@ -263,12 +439,12 @@ func (b *BonusLevel) GetBonusData() (bonusData string) {
 }
 
 func (v *BonusActionVariant) GetName() string {
-	switch v.VariantID {
-	case ActionBooking:
-		return "ActionBooking"
-	case ActionParticipate:
-		return "ActionParticipate"
-	default:
-		return "unknown action"
+	if v.Name != "" {
+		return v.Name
+	}
+	// variants of former versions have no name
+	if actionType := getActionType(v.VariantID); actionType != nil {
+		return actionType.Name
 	}
+	return "unknown action"
 }
@@ -7,6 +7,11 @@ import (
 
 var utLowLevelID = "low"
 var utMiddleLevelID = "middle"
+
+// fare classes which earn codes of the bonus levels by the default earning rules
+var utLowFareClass = FareEconomy
+var utMiddleFareClass = FarePremium
+var utHighFareClass = FareBusiness
 var utHighLevelID = "high"
 
 func setupBonusLevel() *BonusLevel {
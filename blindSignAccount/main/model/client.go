@@ -12,6 +12,7 @@ import (
 	"github.com/cryptoballot/rsablind"
 	"io/ioutil"
 	"math/rand"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -28,10 +29,15 @@ type Client struct {
 	BLevelToTokens   map[string]string
 	BLevelToRecovery map[string]string // maps bonus level name to recovery Token
 	BonusCodes       []*BonusCode
+	Coins            []*Coin // anonymous points, see WithdrawCoins
 	BonusLevels      map[string]*BonusLevel
 	flightList       map[int]*Flight
 	RecoveryID       int               // used for generation of recoveryPK
 	RecoveryPK       *ecdsa.PrivateKey // used for deterministic blinding of recovery tokens
+	// the coin denominations of the server, loaded by the first coin request
+	coinDenominations []*CoinDenomination
+	// the blindings of the transfer requests by hex encoded blind tokens
+	pendingTransfers map[string]*crypt.BlindBundle
 }
 
 const maxAdrID uint32 = 12
@@ -48,8 +54,10 @@ func NewClientWithAccountID(clientID int, mnemonic string, recoveryID int, accou
 		BLevelToTokens:   map[string]string{},
 		BLevelToRecovery: map[string]string{},
 		BonusCodes:       []*BonusCode{},
+		Coins:            []*Coin{},
 		BonusLevels:      map[string]*BonusLevel{},
 		flightList:       map[int]*Flight{},
+		pendingTransfers: map[string]*crypt.BlindBundle{},
 	}
 
 	client.AccountID = accountID
@@ -150,55 +158,119 @@ func (c *Client) GetSystemInformation() error {
 	return nil
 }
 
-// Create a new booking for given flight and for selected bonus level
-func (c *Client) Booking(flightID int, bLevelID string) error {
-	bLevel := c.BonusLevels[bLevelID]
-	if bLevel == nil {
-		return errors.New("unknown bonus level")
-	}
+// Creates a customer account for the client's bookings
+func (c *Client) RegisterCustomer(name, password string) (customerID int, err error) {
+	return c.con.RegisterCustomer(name, password)
+}
 
-	// send booking and receive a new Token
-	token, err := c.con.SendBooking(c.ClientID, flightID, bLevelID)
+// Logs the customer in whose bookings earn the client's codes
+func (c *Client) Login(name, password string) (customerID int, err error) {
+	return c.con.Login(name, password)
+}
+
+// Create a new booking of the logged in customer for given flight and fare class.
+// The client receives a code for every Token earned by the booking.
+func (c *Client) Booking(flightID int, fareClass string) error {
+	// send booking and receive a Token per earned code
+	tokens, err := c.con.SendBooking(flightID, fareClass)
 	if err != nil {
 		return err
 	}
 
-	if token == "" {
+	for _, token := range tokens {
+		if err = c.redeemBookingToken(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exchanges the pending tokens of the customer's bookings for codes, e.g. after an
+// exchange was interrupted. Returns the number of received codes.
+func (c *Client) ResumeBookings() (int, error) {
+	bookings, err := c.con.GetBookings()
+	if err != nil {
+		return 0, err
+	}
+
+	nrCodes := 0
+	for _, booking := range bookings {
+		for _, token := range booking.PendingTokens {
+			if err = c.redeemBookingToken(token); err != nil {
+				return nrCodes, err
+			}
+			nrCodes++
+		}
+	}
+	return nrCodes, nil
+}
+
+// Exchanges a booking Token for a code of its bonus level
+func (c *Client) redeemBookingToken(token *EarnedToken) error {
+	bLevel := c.BonusLevels[token.BLevelID]
+	if bLevel == nil {
+		return errors.New("unknown bonus level")
+	}
+	if token.Token == "" {
 		return errors.New("no code received for booking")
 	}
 
+	// the code is signed by the key of its denomination
+	points := token.Points
+	if points < 1 {
+		points = 1
+	}
+	publicKey := bLevel.BookingPublicKey(points)
+	if publicKey == nil {
+		return errors.New("unknown denomination of " + strconv.Itoa(points) + " points")
+	}
+
 	// create blind Token
-	blindBundle, err := crypt.CreateBlindBundle(bLevel.ActionVariants[ActionBooking].PublicKey)
+	blindBundle, err := crypt.CreateBlindBundle(*publicKey)
 	if err != nil {
 		return err
 	}
 
 	var blindSigHex string
-	if blindSigHex, err = c.con.GetBlindSignature(bLevelID, token, blindBundle.BlindToken, ActionBooking); err != nil {
+	if blindSigHex, err = c.con.GetBlindSignature(token.BLevelID, token.Token, blindBundle.BlindToken, ActionBooking); err != nil {
 		return err
 	}
 	if blindBundle.BlindSig, err = base64.URLEncoding.DecodeString(blindSigHex); err != nil {
 		return err
 	}
-	signature := rsablind.Unblind(&bLevel.ActionVariants[ActionBooking].PublicKey, []byte(blindBundle.BlindSig), blindBundle.UnBlinder)
+	signature := rsablind.Unblind(publicKey, []byte(blindBundle.BlindSig), blindBundle.UnBlinder)
 
-	code, err := c.con.GetBookingCode(bLevelID, blindBundle.HashValue, signature)
+	code, err := c.con.GetBookingCode(token.BLevelID, points, blindBundle.HashValue, signature)
 	if err != nil {
 		return err
 	}
 
-	c.BonusCodes = append(c.BonusCodes, NewBonusCodeWithID(code, bLevel))
+	bCode := NewBonusCodeWithID(code, bLevel)
+	bCode.Points = points
+	c.BonusCodes = append(c.BonusCodes, bCode)
 	return nil
 }
 
-// Access the server's bonus system with the client's codes
+// Access the server's bonus system with the client's codes. The codes which reach the
+// highest accessible level with the least points are used, the other codes are kept.
 func (c *Client) AccessBonusSystem() error {
 	var codes []string
 	var adrBundle *crypt.AddressBundle
 
-	// create codes
-	for _, code := range c.BonusCodes {
+	// create codes, all activated codes are sent if no level is reachable
+	selected := c.selectCodes()
+	if len(selected) == 0 {
+		now := time.Now()
+		for _, code := range c.BonusCodes {
+			if code.isActive(now) {
+				selected = append(selected, code)
+			}
+		}
+	}
+	used := make(map[*BonusCode]bool, len(selected))
+	for _, code := range selected {
 		codes = append(codes, code.CodeID)
+		used[code] = true
 	}
 
 	// create a new address
@@ -211,6 +283,14 @@ func (c *Client) AccessBonusSystem() error {
 	if err != nil {
 		return err
 	}
+	// the server marks the sent codes as used
+	remaining := []*BonusCode{}
+	for _, code := range c.BonusCodes {
+		if !used[code] {
+			remaining = append(remaining, code)
+		}
+	}
+	c.BonusCodes = remaining
 	if len(tokenMap) == 0 {
 		return errors.New("no tokens received")
 	}
@@ -231,6 +311,97 @@ func (c *Client) AccessBonusSystem() error {
 	return nil
 }
 
+// Selects the activated codes for accessing the highest reachable bonus level. The selected codes
+// reach the minimal points of the level with the least overshoot. Returns nil if no level
+// is reachable.
+func (c *Client) selectCodes() []*BonusCode {
+	// levels with more lower levels have a higher priority
+	levels := make([]*BonusLevel, 0, len(c.BonusLevels))
+	for _, bLevel := range c.BonusLevels {
+		levels = append(levels, bLevel)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if len(levels[i].LowerLevels) == len(levels[j].LowerLevels) {
+			return levels[i].BonusID < levels[j].BonusID
+		}
+		return len(levels[i].LowerLevels) > len(levels[j].LowerLevels)
+	})
+
+	now := time.Now()
+	for _, bLevel := range levels {
+		var candidates []*BonusCode
+		for _, code := range c.BonusCodes {
+			if code.ValidFor != nil && code.isActive(now) && isLevelOrLower(code.ValidFor, bLevel.BonusID) {
+				candidates = append(candidates, code)
+			}
+		}
+		if selected := selectMinimalOvershoot(candidates, bLevel.MinPoints); selected != nil {
+			return selected
+		}
+	}
+	return nil
+}
+
+// Reports whether the level with given id is the bonus level or one of its lower levels
+func isLevelOrLower(bLevel *BonusLevel, bLevelID string) bool {
+	if bLevel.BonusID == bLevelID {
+		return true
+	}
+	for _, lower := range bLevel.LowerLevels {
+		if isLevelOrLower(lower, bLevelID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the codes whose points reach the target with the least overshoot and with as few
+// codes as possible, nil if all codes together do not reach the target
+func selectMinimalOvershoot(codes []*BonusCode, target int) []*BonusCode {
+	const unreachable = -1
+	total := 0
+	for _, code := range codes {
+		total += code.Value()
+	}
+	if len(codes) == 0 || total < target {
+		return nil
+	}
+
+	// counts[i][p] is the smallest number of the first i codes which are worth p points
+	counts := make([][]int, len(codes)+1)
+	counts[0] = make([]int, total+1)
+	for p := 1; p <= total; p++ {
+		counts[0][p] = unreachable
+	}
+	for i, code := range codes {
+		counts[i+1] = append([]int{}, counts[i]...)
+		for p := code.Value(); p <= total; p++ {
+			prev := counts[i][p-code.Value()]
+			if prev != unreachable && (counts[i+1][p] == unreachable || prev+1 < counts[i+1][p]) {
+				counts[i+1][p] = prev + 1
+			}
+		}
+	}
+
+	// the smallest reachable sum of points which is not below the target
+	best := target
+	if best < 1 {
+		best = 1
+	}
+	for best <= total && counts[len(codes)][best] == unreachable {
+		best++
+	}
+	// a code is selected if the sum needs it
+	var selected []*BonusCode
+	for i := len(codes); i > 0 && best > 0; i-- {
+		if counts[i][best] != counts[i-1][best] {
+			selected = append(selected, codes[i-1])
+			best -= codes[i-1].Value()
+		}
+	}
+	return selected
+}
+
 func (c *Client) AdrUpdate(bLevel *BonusLevel) (token, recoveryToken string, blindBundle *crypt.BlindBundle, signature []byte, err error) {
 	////////// step 1: Get blind Token and signature for address update ////////////
 	blindBundle, signature, err = c.getSignatureForToken(bLevel, c.BLevelToTokens[bLevel.BonusID], ActionParticipate)
@@ -255,12 +426,51 @@ func (c *Client) AdrUpdate(bLevel *BonusLevel) (token, recoveryToken string, bli
 }
 
 func (c *Client) Participate(bLevelID string) (bonusData string, err error) {
-	var participateToken string
+	bLevel := c.BonusLevels[bLevelID]
+	if bLevel == nil {
+		return "", errors.New("bonus level does not exist")
+	}
+	return c.performAction(bLevel, ActionParticipate, "")
+}
+
+// Participates at a bonus level and chooses a reward of its catalogue. Returns the voucher of the
+// reward after checking its signature by the voucher key of the bonus level.
+func (c *Client) ClaimReward(bLevelID, rewardID string) (*Voucher, error) {
+	bLevel := c.BonusLevels[bLevelID]
+	if bLevel == nil {
+		return nil, errors.New("bonus level does not exist")
+	}
+	bonusData, err := c.performAction(bLevel, ActionParticipate, rewardID)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := ParseVoucher(bonusData)
+	if err != nil {
+		return nil, err
+	}
+	if err = voucher.Verify(bLevel.VoucherPublicKey); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
 
+// Performs an action of a bonus level by its name, e.g. a lounge access. Actions are performed
+// like the participation, but the participation data is signed by the key of the action.
+func (c *Client) PerformAction(bLevelID, actionName string) (bonusData string, err error) {
 	bLevel := c.BonusLevels[bLevelID]
 	if bLevel == nil {
 		return "", errors.New("bonus level does not exist")
 	}
+	variant := bLevel.ActionVariantByName(actionName)
+	if variant == nil {
+		return "", errors.New("bonus level does not offer action '" + actionName + "'")
+	}
+	return c.performAction(bLevel, variant.VariantID, "")
+}
+
+func (c *Client) performAction(bLevel *BonusLevel, action int, rewardID string) (bonusData string, err error) {
+	var participateToken string
+	bLevelID := bLevel.BonusID
 
 	//////////// step 1 & step 2: Get blind Token and signature for address update and update address ////////////
 	var blindBundle *crypt.BlindBundle
@@ -273,7 +483,7 @@ func (c *Client) Participate(bLevelID string) (bonusData string, err error) {
 	}
 
 	////////// step 3: Get blind Token and signature for participation data ////////////
-	blindBundle, signature, err = c.getSignatureForToken(bLevel, participateToken, ActionParticipate)
+	blindBundle, signature, err = c.getSignatureForToken(bLevel, participateToken, action)
 	if err != nil {
 		return "", err
 	}
@@ -282,7 +492,7 @@ func (c *Client) Participate(bLevelID string) (bonusData string, err error) {
 	if pkr, err = c.blindRecoveryToken(c.BLevelToRecovery[bLevelID]); err != nil {
 		return "", err
 	}
-	c.BLevelToTokens[bLevelID], c.BLevelToRecovery[bLevelID], bonusData, err = c.con.Participate(bLevelID, blindBundle.HashValue, signature, pkr)
+	c.BLevelToTokens[bLevelID], c.BLevelToRecovery[bLevelID], bonusData, err = c.con.PerformAction(bLevelID, action, rewardID, blindBundle.HashValue, signature, pkr)
 	if err != nil {
 		return "", err
 	}
@@ -294,7 +504,7 @@ func (c *Client) calcNextAddressID(currAddressID uint32) {
 	if c.AddressID >= maxAdrID {
 		c.AccountID = uint32(rand.Intn(int(maxAccountID)))
 		c.AddressID = 0
-		_, c.Keys, _ = crypt.GetWalletKeys(c.Mnemonic, c.AccountID, false)
+		c.Keys, _ = crypt.GetWalletKeysFromSeed(c.Seed, c.AccountID)
 		c.generateRecoveryPK()
 	}
 }
@@ -356,7 +566,7 @@ func (c *Client) Restore(bLevelID string) (string, error) {
 	}
 	// update the keys under the new account node
 	c.AccountID = curAccountID
-	_, c.Keys, _ = crypt.GetWalletKeys(c.Mnemonic, c.AccountID, false)
+	c.Keys, _ = crypt.GetWalletKeysFromSeed(c.Seed, c.AccountID)
 	if err = c.generateRecoveryPK(); err != nil {
 		return "", err
 	}
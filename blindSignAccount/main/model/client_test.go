@@ -1,6 +1,7 @@
 package model
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/crypt"
 	"encoding/hex"
 	"os"
@@ -23,13 +24,24 @@ func setupClient(t *testing.T) *Client {
 	return client
 }
 
+// Registers the test customer if it does not exist and logs it in
+func testLogin(con Connection) error {
+	_, _ = con.RegisterCustomer(utCustomerName, utCustomerPassword)
+	_, err := con.Login(utCustomerName, utCustomerPassword)
+	return err
+}
+
 func TestNewClient(t *testing.T) {
 	setupClient(t)
 }
 
 func TestClient_Booking(t *testing.T) {
 	client := setupClient(t)
-	if err := client.Booking(1, utLowLevelID); err != nil {
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if err := client.Booking(1, utLowFareClass); err != nil {
 		t.Error(err)
 		t.Fail()
 	}
@@ -44,10 +56,33 @@ func TestClient_Booking(t *testing.T) {
 	}
 }
 
+func TestClient_ResumeBookings(t *testing.T) {
+	client := setupClient(t)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	// an exchange interrupted after the booking
+	if _, err := client.con.SendBooking(1, utLowFareClass); err != nil {
+		t.Fatal(err)
+	}
+	if nrCodes, err := client.ResumeBookings(); err != nil || nrCodes != 1 || len(client.BonusCodes) != 1 {
+		t.Errorf("booking not resumed: %d codes, %v", nrCodes, err)
+	}
+	// the exchange is not repeated
+	if nrCodes, err := client.ResumeBookings(); err != nil || nrCodes != 0 {
+		t.Errorf("booking resumed twice: %d codes, %v", nrCodes, err)
+	}
+}
+
 func TestClient_Booking_Fail(t *testing.T) {
 	client := setupClient(t)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 	// try to book a non-existing flight
-	if err := client.Booking(-1, utLowLevelID); err == nil {
+	if err := client.Booking(-1, utLowFareClass); err == nil {
 		t.Error("success for booking with invalid flight id")
 		t.Fail()
 	}
@@ -55,9 +90,9 @@ func TestClient_Booking_Fail(t *testing.T) {
 		t.Error("there exists a bonus code")
 		t.Fail()
 	}
-	// try to book an existing flight, but with non-existing bonus level
-	if err := client.Booking(0, utLowLevelID+"_unknown"); err == nil {
-		t.Error("success for booking with invalid bonus level")
+	// try to book an existing flight, but with non-existing fare class
+	if err := client.Booking(0, utLowFareClass+"_unknown"); err == nil {
+		t.Error("success for booking with invalid fare class")
 		t.Fail()
 	}
 	if len(client.BonusCodes) != 0 {
@@ -70,8 +105,12 @@ func TestClient_AccessBonusSystem(t *testing.T) {
 	client := setupClient(t)
 
 	// create 5 bookings for bonus level 'middle'
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 	for i := 0; i < 5; i++ {
-		if err := client.Booking(i, utMiddleLevelID); err != nil {
+		if err := client.Booking(i, utMiddleFareClass); err != nil {
 			t.Error(err)
 			t.Fail()
 		}
@@ -103,6 +142,68 @@ func TestClient_AccessBonusSystem(t *testing.T) {
 	}
 }
 
+func TestClient_AccessBonusSystem_WeightedPoints(t *testing.T) {
+	client := NewClient(1, utMnemonic, 2)
+	con := newUtConnection()
+	client.con = con
+	// premium bookings earn codes of 2 points for level 'middle' which needs 3 points
+	rules := map[string][]config.EarningRule{AnyAirline: {
+		{FareClass: FarePremium, BonusLevel: "middle", Codes: 1, Points: 2},
+		{BonusLevel: "low", Codes: 1},
+	}}
+	if err := con.server.SetEarningRules(rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	for _, fareClass := range []string{FarePremium, FarePremium, FarePremium, FareEconomy} {
+		if err := client.Booking(1, fareClass); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.BonusCodes[0].Points != 2 || client.BonusCodes[3].Value() != 1 {
+		t.Fatal("wrong points of the codes")
+	}
+
+	// two codes of 2 points reach level 'middle', the other codes are kept
+	if err := client.AccessBonusSystem(); err != nil {
+		t.Fatal(err)
+	}
+	if client.BLevelToTokens[utMiddleLevelID] == "" {
+		t.Error("level 'middle' not accessed")
+	}
+	if len(client.BonusCodes) != 2 {
+		t.Errorf("wrong number of kept codes: %d", len(client.BonusCodes))
+	}
+}
+
+func TestSelectMinimalOvershoot(t *testing.T) {
+	var codes []*BonusCode
+	for _, points := range []int{5, 3, 3, 1, 0} {
+		codes = append(codes, &BonusCode{Points: points})
+	}
+	for target, expPoints := range map[int]int{1: 1, 4: 4, 6: 6, 7: 7, 12: 12, 13: 13} {
+		points := 0
+		for _, code := range selectMinimalOvershoot(codes, target) {
+			points += code.Value()
+		}
+		if points != expPoints {
+			t.Errorf("target %d: selected %d points, exp: %d", target, points, expPoints)
+		}
+	}
+	if selected := selectMinimalOvershoot(codes, 5); len(selected) != 1 {
+		t.Errorf("%d codes selected for 5 points", len(selected))
+	}
+	if selectMinimalOvershoot(codes, 14) != nil {
+		t.Error("codes selected for an unreachable target")
+	}
+}
+
 func TestClient_Participate(t *testing.T) {
 	client := setupClient(t)
 	// the client needs an initial recovery Token
@@ -394,9 +495,12 @@ func TestClient_RestoreDifferentBLevels(t *testing.T) {
 }
 
 func clientAccessesBonusSystem(client *Client) error {
+	if err := testLogin(client.con); err != nil {
+		return err
+	}
 	// create 5 bookings for bonus level 'middle'
 	for i := 0; i < 5; i++ {
-		if err := client.Booking(i, utMiddleLevelID); err != nil {
+		if err := client.Booking(i, utMiddleFareClass); err != nil {
 			return err
 		}
 	}
@@ -523,7 +627,11 @@ func TestClient_SaveDebugInfos(t *testing.T) {
 		t.Fail()
 		return
 	}
-	if err := client.Booking(1, "high"); err != nil {
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if err := client.Booking(1, utHighFareClass); err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
@@ -585,7 +693,11 @@ func TestClient_Restore_debug_2(t *testing.T) {
 		t.FailNow()
 	}
 
-	if err := client.Booking(1, "high"); err != nil {
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if err := client.Booking(1, utHighFareClass); err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
@@ -642,3 +754,33 @@ func TestClient_SetAdrFromString(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func benchmarkClientRestore(b *testing.B) {
+	client := NewClient(1, utMnemonic, 2)
+	client.con = newUtConnection()
+	if err := client.GetSystemInformation(); err != nil {
+		b.Fatal(err)
+	}
+	if err := clientAccessesBonusSystem(client); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Restore(utMiddleLevelID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClient_Restore(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		keyCapacity, adrCapacity := crypt.KeyCache.Capacity(), crypt.AddressCache.Capacity()
+		crypt.KeyCache.Resize(0)
+		crypt.AddressCache.Resize(0)
+		defer crypt.KeyCache.Resize(keyCapacity)
+		defer crypt.AddressCache.Resize(adrCapacity)
+		benchmarkClientRestore(b)
+	})
+	b.Run("cached", benchmarkClientRestore)
+}
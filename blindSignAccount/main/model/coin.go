@@ -0,0 +1,250 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"github.com/cryptoballot/rsablind"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Coins are anonymous loyalty points. The server blindly signs the serial of a coin by the key of
+// its denomination, so a spent coin cannot be linked to the booking which earned it.
+
+// the values of the coin denominations if none are configured
+var defaultCoinValues = []int{1, 2, 5, 10, 20, 50, 100}
+
+// Coins of a denomination are worth its value. Every denomination has its own key,
+// like the action variants of a bonus level.
+type CoinDenomination struct {
+	Value     int
+	PublicKey rsa.PublicKey
+	SkKey     *rsa.PrivateKey `json:",omitempty"` // private key
+}
+
+// A coin worth Value points. The signature of the serial is made by the key of the denomination.
+type Coin struct {
+	Value     int      `json:"value"`
+	Serial    string   `json:"serial"`
+	Signature HexBytes `json:"signature"`
+}
+
+// A coin whose serial is blinded for the signature by the server
+type BlindCoin struct {
+	Value      int      `json:"value"`
+	BlindToken HexBytes `json:"blindToken"`
+}
+
+// Creates a denomination with a new key per value, the denominations are ordered by their values
+func newCoinDenominations(values []int) []*CoinDenomination {
+	denominations := make([]*CoinDenomination, 0, len(values))
+	known := map[int]bool{}
+	for _, value := range values {
+		if value < 1 || known[value] {
+			continue
+		}
+		known[value] = true
+		denomination := &CoinDenomination{Value: value}
+		denomination.SkKey, _ = rsa.GenerateKey(rand.Reader, crypt.KeyLength)
+		denomination.PublicKey = denomination.SkKey.PublicKey
+		denominations = append(denominations, denomination)
+	}
+	sort.Slice(denominations, func(i, j int) bool { return denominations[i].Value < denominations[j].Value })
+	return denominations
+}
+
+// Returns the configured coin values, the default values if none are configured
+func configuredCoinValues() []int {
+	if values := config.GetConfigCoinValues(); len(values) > 0 {
+		return values
+	}
+	return defaultCoinValues
+}
+
+// Returns the public coin denominations
+func (s *Server) CoinDenominations() []*CoinDenomination {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	denominations := make([]*CoinDenomination, 0, len(s.coinDenominations))
+	for _, denomination := range s.coinDenominations {
+		denominations = append(denominations, &CoinDenomination{Value: denomination.Value, PublicKey: denomination.PublicKey})
+	}
+	return denominations
+}
+
+func (s *Server) coinDenomination(value int) *CoinDenomination {
+	for _, denomination := range s.coinDenominations {
+		if denomination.Value == value {
+			return denomination
+		}
+	}
+	return nil
+}
+
+// Checks the denominations of the blind coins and returns their value
+func (s *Server) blindCoinsValue(blindCoins []*BlindCoin) (int, error) {
+	value := 0
+	for _, blindCoin := range blindCoins {
+		if s.coinDenomination(blindCoin.Value) == nil {
+			return 0, errors.New("no coin denomination of " + strconv.Itoa(blindCoin.Value) + " points")
+		}
+		if len(blindCoin.BlindToken) == 0 {
+			return 0, errors.New("blind token of a coin is empty")
+		}
+		value += blindCoin.Value
+	}
+	return value, nil
+}
+
+// Blindly signs the coins by the keys of their denominations. The caller has to hold a
+// read lock of the server and run it in the signing pool.
+func (s *Server) signBlindCoins(blindCoins []*BlindCoin) ([]string, error) {
+	blindSigs := make([]string, 0, len(blindCoins))
+	for _, blindCoin := range blindCoins {
+		blindSig, err := rsablind.BlindSign(s.coinDenomination(blindCoin.Value).SkKey, blindCoin.BlindToken)
+		if err != nil {
+			return nil, err
+		}
+		blindSigs = append(blindSigs, base64.URLEncoding.EncodeToString(blindSig))
+	}
+	return blindSigs, nil
+}
+
+// Withdraws coins for a booking Token instead of a code. The coins have to be worth the points
+// of the Token. Returns the blind signatures of the coins.
+func (s *Server) WithdrawCoins(bLevelID, token string, blindCoins []*BlindCoin) ([]string, error) {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	bLevel := s.getBonusLevel(bLevelID)
+	if bLevel == nil {
+		return nil, errors.New("no level known with given id")
+	}
+	value, err := s.blindCoinsValue(blindCoins)
+	if err != nil {
+		return nil, err
+	}
+	if points := bLevel.tokenPoints(token); value != points {
+		return nil, errors.New("coins have to be worth the " + strconv.Itoa(points) + " points of the Token")
+	}
+
+	// a rejected request does not use the Token
+	var blindSigs []string
+	err = s.SignPool.Do(func() (err error) {
+		if !bLevel.useToken(token, ActionBooking) {
+			return errors.New("Token is not valid")
+		}
+		blindSigs, err = s.signBlindCoins(blindCoins)
+		return
+	})
+	return blindSigs, err
+}
+
+// Checks the signatures of the coins and returns their value
+func (s *Server) coinsValue(coins []*Coin) (int, error) {
+	value := 0
+	serials := map[string]bool{}
+	for _, coin := range coins {
+		denomination := s.coinDenomination(coin.Value)
+		if denomination == nil {
+			return 0, errors.New("no coin denomination of " + strconv.Itoa(coin.Value) + " points")
+		}
+		if serials[coin.Serial] {
+			return 0, errors.New("coin is spent twice")
+		}
+		serials[coin.Serial] = true
+		if len(coin.Signature) == 0 ||
+			rsablind.VerifyBlindSignature(&denomination.PublicKey, crypt.TokenHash(coin.Serial), coin.Signature) != nil {
+			return 0, errors.New("coin has an invalid signature")
+		}
+		value += coin.Value
+	}
+	return value, nil
+}
+
+// Spends coins for a reward of the catalogue of a bonus level. Coins worth more than the price
+// of the reward are changed into the blind coins, which have to be worth the difference.
+// Returns the voucher of the reward and the blind signatures of the change.
+func (s *Server) SpendCoins(coins []*Coin, bLevelID, rewardID string, blindChange []*BlindCoin) (bonusData string, changeSigs []string, err error) {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	bLevel := s.getBonusLevel(bLevelID)
+	if bLevel == nil {
+		return "", nil, errors.New("no level known with given id")
+	}
+	reward, err := bLevel.getReward(rewardID)
+	if err != nil {
+		return "", nil, err
+	}
+	if reward.Points < 1 {
+		return "", nil, errors.New("reward '" + reward.ID + "' cannot be bought with coins")
+	}
+	value, err := s.coinsValue(coins)
+	if err != nil {
+		return "", nil, err
+	}
+	change, err := s.blindCoinsValue(blindChange)
+	if err != nil {
+		return "", nil, err
+	}
+	if value < reward.Points || value-reward.Points != change {
+		return "", nil, errors.New("coins worth " + strconv.Itoa(value) + " points do not pay " +
+			strconv.Itoa(reward.Points) + " points with a change of " + strconv.Itoa(change) + " points")
+	}
+
+	// mark the coins as spent, they are released if the change or the voucher cannot be signed
+	if err = s.spendCoins(coins); err != nil {
+		return "", nil, err
+	}
+	err = s.SignPool.Do(func() (err error) {
+		changeSigs, err = s.signBlindCoins(blindChange)
+		return
+	})
+	if err != nil {
+		s.releaseCoins(coins)
+		return "", nil, err
+	}
+	voucher, err := bLevel.issueVoucher(reward.ID)
+	if err != nil {
+		s.releaseCoins(coins)
+		return "", nil, err
+	}
+	for _, coin := range coins {
+		s.revoke(coin.Serial)
+	}
+	return voucher.String(), changeSigs, nil
+}
+
+// Registers the coins as spent, no coin may be spent before
+func (s *Server) spendCoins(coins []*Coin) error {
+	s.muxCoins.Lock()
+	defer s.muxCoins.Unlock()
+
+	for _, coin := range coins {
+		if _, spent := s.spentCoins[coin.Serial]; spent {
+			return errors.New("coin was spent already")
+		}
+	}
+	now := time.Now()
+	for _, coin := range coins {
+		s.spentCoins[coin.Serial] = now
+	}
+	return nil
+}
+
+func (s *Server) releaseCoins(coins []*Coin) {
+	s.muxCoins.Lock()
+	defer s.muxCoins.Unlock()
+	for _, coin := range coins {
+		delete(s.spentCoins, coin.Serial)
+	}
+}
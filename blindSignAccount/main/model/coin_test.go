@@ -0,0 +1,217 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"github.com/cryptoballot/rsablind"
+	"testing"
+)
+
+// Blinds a coin per value by the public keys of the server's denominations
+func blindTestCoins(t *testing.T, server *Server, values ...int) ([]*crypt.BlindBundle, []*BlindCoin) {
+	var bundles []*crypt.BlindBundle
+	var blindCoins []*BlindCoin
+	for _, value := range values {
+		bundle, err := crypt.CreateBlindBundle(server.coinDenomination(value).PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundles = append(bundles, bundle)
+		blindCoins = append(blindCoins, &BlindCoin{Value: value, BlindToken: bundle.BlindToken})
+	}
+	return bundles, blindCoins
+}
+
+// Unblinds the signatures of the blind coins
+func unblindTestCoins(t *testing.T, server *Server, bundles []*crypt.BlindBundle, blindCoins []*BlindCoin, blindSigs []string) []*Coin {
+	var coins []*Coin
+	for i, blindCoin := range blindCoins {
+		blindSig, err := base64.URLEncoding.DecodeString(blindSigs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature := rsablind.Unblind(&server.coinDenomination(blindCoin.Value).PublicKey, blindSig, bundles[i].UnBlinder)
+		coins = append(coins, &Coin{Value: blindCoin.Value, Serial: bundles[i].Token, Signature: signature})
+	}
+	return coins
+}
+
+// Creates a server whose bookings earn two tokens of 37 points at the lowest level
+func setupCoinServer(t *testing.T) (*Server, []*EarnedToken) {
+	server := NewServer()
+	rules := map[string][]config.EarningRule{AnyAirline: {{BonusLevel: utLowLevelID, Codes: 2, Points: 37}}}
+	if err := server.SetEarningRules(rules); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := server.Booking(1, 1, FareEconomy)
+	if err != nil || len(tokens) != 2 {
+		t.Fatalf("booking did not earn two tokens: %v", err)
+	}
+	return server, tokens
+}
+
+func TestServer_WithdrawCoins(t *testing.T) {
+	server, tokens := setupCoinServer(t)
+
+	if denominations := server.CoinDenominations(); len(denominations) != len(defaultCoinValues) || denominations[0].SkKey != nil {
+		t.Fatal("wrong public coin denominations")
+	}
+
+	_, blindCoins := blindTestCoins(t, server, 20, 10, 5)
+	if _, err := server.WithdrawCoins(utLowLevelID, tokens[0].Token, blindCoins); err == nil {
+		t.Error("coins worth less than the Token withdrawn")
+	}
+	if _, err := server.WithdrawCoins(utLowLevelID, tokens[0].Token, append(blindCoins, &BlindCoin{Value: 3, BlindToken: HexBytes{1}})); err == nil {
+		t.Error("coin of an unknown denomination withdrawn")
+	}
+	bundles, blindCoins := blindTestCoins(t, server, 20, 10, 5, 2)
+	if _, err := server.WithdrawCoins(utHighLevelID, tokens[0].Token, blindCoins); err == nil {
+		t.Error("coins withdrawn with the Token of another bonus level")
+	}
+	blindSigs, err := server.WithdrawCoins(utLowLevelID, tokens[0].Token, blindCoins)
+	if err != nil || len(blindSigs) != 4 {
+		t.Fatalf("coins not withdrawn: %v", err)
+	}
+	if value, err := server.coinsValue(unblindTestCoins(t, server, bundles, blindCoins, blindSigs)); err != nil || value != 37 {
+		t.Errorf("wrong coins worth %d points: %v", value, err)
+	}
+
+	// the Token is used by the withdrawal
+	if _, err = server.WithdrawCoins(utLowLevelID, tokens[0].Token, blindCoins); err == nil {
+		t.Error("Token used twice")
+	}
+}
+
+func TestServer_SpendCoins(t *testing.T) {
+	server, tokens := setupCoinServer(t)
+
+	var coins []*Coin
+	for _, token := range tokens {
+		bundles, blindCoins := blindTestCoins(t, server, 20, 10, 5, 2)
+		blindSigs, err := server.WithdrawCoins(utLowLevelID, token.Token, blindCoins)
+		if err != nil {
+			t.Fatal(err)
+		}
+		coins = append(coins, unblindTestCoins(t, server, bundles, blindCoins, blindSigs)...)
+	}
+
+	// the baggage costs 50 points, coins worth 52 points need a change of 2 points
+	payment := []*Coin{coins[0], coins[4], coins[1], coins[3]}
+	_, blindChange := blindTestCoins(t, server, 2, 2)
+	if _, _, err := server.SpendCoins(payment, utLowLevelID, "baggage", blindChange); err == nil {
+		t.Error("coins spent with a wrong change")
+	}
+	if _, _, err := server.SpendCoins(payment[:2], utLowLevelID, "baggage", nil); err == nil {
+		t.Error("coins spent worth less than the price")
+	}
+	if _, _, err := server.SpendCoins([]*Coin{coins[0], coins[0], coins[1]}, utLowLevelID, "baggage", nil); err == nil {
+		t.Error("coin spent twice in a payment")
+	}
+	forged := &Coin{Value: 50, Serial: coins[0].Serial, Signature: coins[0].Signature}
+	if _, _, err := server.SpendCoins([]*Coin{forged}, utLowLevelID, "baggage", nil); err == nil {
+		t.Error("coin with a forged value spent")
+	}
+
+	bundles, blindChange := blindTestCoins(t, server, 2)
+	bonusData, changeSigs, err := server.SpendCoins(payment, utLowLevelID, "baggage", blindChange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	voucher, err := ParseVoucher(bonusData)
+	if err != nil || voucher.RewardID != "baggage" || voucher.Verify(server.BonusList[utLowLevelID].VoucherPublicKey) != nil {
+		t.Fatalf("wrong voucher: %v %v", voucher, err)
+	}
+	if value, err := server.coinsValue(unblindTestCoins(t, server, bundles, blindChange, changeSigs)); err != nil || value != 2 {
+		t.Errorf("wrong change worth %d points: %v", value, err)
+	}
+
+	// spent coins are rejected and revoked
+	if _, _, err = server.SpendCoins(payment[:3], utLowLevelID, "baggage", nil); err == nil {
+		t.Error("coins spent twice")
+	}
+	filter, _ := server.SealRevocations()
+	list := NewRevocationList(server.RevocationPublicKey())
+	if err = list.Add(filter); err != nil || !list.IsRevoked(payment[0].Serial) {
+		t.Error("spent coin is not revoked")
+	}
+}
+
+func TestServer_SpendCoins_VoucherFails(t *testing.T) {
+	server, tokens := setupCoinServer(t)
+	var coins []*Coin
+	for _, token := range tokens {
+		bundles, blindCoins := blindTestCoins(t, server, 20, 10, 5, 2)
+		blindSigs, err := server.WithdrawCoins(utLowLevelID, token.Token, blindCoins)
+		if err != nil {
+			t.Fatal(err)
+		}
+		coins = append(coins, unblindTestCoins(t, server, bundles, blindCoins, blindSigs)...)
+	}
+	// the baggage costs 50 points
+	payment := []*Coin{coins[0], coins[4], coins[1]}
+	var err error
+
+	// the voucher of the reward cannot be issued without the voucher key
+	bLevel := server.BonusList[utLowLevelID]
+	skKey := bLevel.VoucherSkKey
+	bLevel.VoucherSkKey = nil
+	if _, _, err = server.SpendCoins(payment, utLowLevelID, "baggage", nil); err == nil {
+		t.Fatal("coins spent without a voucher")
+	}
+
+	// the coins are not lost
+	bLevel.VoucherSkKey = skKey
+	if _, _, err = server.SpendCoins(payment, utLowLevelID, "baggage", nil); err != nil {
+		t.Errorf("coins not released: %v", err)
+	}
+}
+
+func TestClient_SpendCoins(t *testing.T) {
+	client := setupClient(t)
+	server := client.con.(*utConnection).server
+	rules := map[string][]config.EarningRule{AnyAirline: {{BonusLevel: utLowLevelID, Codes: 2, Points: 37}}}
+	if err := server.SetEarningRules(rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(client.con); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.BookingWithCoins(1, utLowFareClass); err != nil {
+		t.Fatal(err)
+	}
+	if client.Balance() != 74 || len(client.BonusCodes) != 0 {
+		t.Fatalf("wrong balance of %d points", client.Balance())
+	}
+	if _, err := client.SpendCoins(utLowLevelID, "upgrade"); err == nil {
+		t.Error("reward of another bonus level bought")
+	}
+
+	voucher, err := client.SpendCoins(utLowLevelID, "baggage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voucher.RewardID != "baggage" || client.Balance() != 24 {
+		t.Errorf("wrong voucher %v or balance of %d points", voucher, client.Balance())
+	}
+	if _, err = client.SpendCoins(utLowLevelID, "baggage"); err == nil || client.Balance() != 24 {
+		t.Error("reward bought without enough coins")
+	}
+}
+
+func TestClient_selectCoins(t *testing.T) {
+	client := &Client{}
+	for _, value := range []int{1, 5, 20, 20, 50} {
+		client.Coins = append(client.Coins, &Coin{Value: value})
+	}
+	if coins, value, err := client.selectCoins(44); err != nil || len(coins) != 4 || value != 46 {
+		t.Errorf("wrong coins worth %d points: %v", value, err)
+	}
+	if coins, value, err := client.selectCoins(45); err != nil || len(coins) != 3 || value != 45 {
+		t.Errorf("wrong coins worth %d points: %v", value, err)
+	}
+	if _, _, err := client.selectCoins(97); err == nil {
+		t.Error("coins selected for more than the balance")
+	}
+}
@@ -29,20 +29,39 @@ func (status RecoveryStatus) String() string {
 
 type Connection interface {
 
-	// Sends a booking of given customer, flight and bonus id to the server.
-	SendBooking(customerID, flightID int, bLevelID string) (string, error)
+	// Creates a new customer account
+	RegisterCustomer(name, password string) (customerID int, err error)
+	// Logs a customer in. The session of the customer is sent with bookings only.
+	Login(name, password string) (customerID int, err error)
+	// Sends a booking of given flight and fare class of the logged in customer to the server.
+	// Returns a Token for every code earned by the booking.
+	SendBooking(flightID int, fareClass string) ([]*EarnedToken, error)
+	// Receives the bookings of the logged in customer and the status of their codes
+	GetBookings() ([]*BookingStatus, error)
 	// Receives information about flights and the bonus system of the server
 	GetSystemInformation() ([]*Flight, []*BonusLevel, error)
 	// Sends a blind signature request to the server
 	GetBlindSignature(bLevelID, token string, blindToken []byte, action int) (string, error)
-	// Gets a code from the server
-	GetBookingCode(bLevelID string, hashValue, signature []byte) (string, error)
+	// Gets a code worth the given points from the server
+	GetBookingCode(bLevelID string, points int, hashValue, signature []byte) (string, error)
+	// Receives the public keys of the coin denominations
+	GetCoinDenominations() ([]*CoinDenomination, error)
+	// Exchanges a booking Token for blind signatures of coins worth its points
+	WithdrawCoins(bLevelID, token string, blindCoins []*BlindCoin) (blindSigs []string, err error)
+	// Spends coins for a reward, returns its voucher and the blind signatures of the change
+	SpendCoins(coins []*Coin, bLevelID, rewardID string, blindChange []*BlindCoin) (bonusData string, blindSigs []string, err error)
+	// Spends a code for the blind signature of the Token of its receiver
+	TransferCode(code, bLevelID string, points int, blindToken []byte) (string, error)
+	// Spends codes of a bonus level for the blind signature of a code of a higher level
+	ExchangeCodes(codes []string, fromID, toID string, blindToken []byte) (string, error)
 	// Sends a request to the server for accessing the server's bonus system
 	AccessBonusSystem(codes []string, adrBundle *crypt.AddressBundle) (tokens, recoveries map[string]string, err error)
 	// Sends an address update to the server
 	SetAddress(bLevelID string, hashValue, signature []byte, adrBundle *crypt.AddressBundle, action int, pkr string) (token, recovery string, err error)
 	// Requests participation data from the server
 	Participate(bLevelID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error)
+	// Like Participate, but performs the given action and chooses the reward of a participation
+	PerformAction(bLevelID string, action int, rewardID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error)
 	// Checks if a given address was set for the last address update.
 	CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.AddressBundle) (status RecoveryStatus, token string, err error)
 	// A recovery test for receiving 'normal' Token or recovery Token from server
@@ -59,6 +78,8 @@ type Connection interface {
 
 type utConnection struct {
 	server *Server
+	// the session of the logged in customer
+	session string
 }
 
 func newUtConnection() *utConnection {
@@ -69,16 +90,56 @@ func (con *utConnection) GetSystemInformation() ([]*Flight, []*BonusLevel, error
 	return con.server.GetSystemInformation()
 }
 
-func (con *utConnection) SendBooking(customerID, flightID int, bLevelID string) (string, error) {
-	return con.server.Booking(flightID, customerID, bLevelID)
+func (con *utConnection) RegisterCustomer(name, password string) (customerID int, err error) {
+	return con.server.RegisterCustomer(name, password)
 }
 
+func (con *utConnection) Login(name, password string) (customerID int, err error) {
+	con.session, customerID, err = con.server.Login(name, password)
+	return
+}
+
+func (con *utConnection) SendBooking(flightID int, fareClass string) ([]*EarnedToken, error) {
+	customerID, err := con.server.Authenticate(con.session)
+	if err != nil {
+		return nil, err
+	}
+	return con.server.Booking(flightID, customerID, fareClass)
+}
+
+func (con *utConnection) GetBookings() ([]*BookingStatus, error) {
+	customerID, err := con.server.Authenticate(con.session)
+	if err != nil {
+		return nil, err
+	}
+	return con.server.GetBookings(customerID), nil
+}
 func (con *utConnection) GetBlindSignature(bLevelID, token string, blindToken []byte, action int) (string, error) {
 	return con.server.GetBlindSignature(bLevelID, token, blindToken, action)
 }
 
-func (con *utConnection) GetBookingCode(bLevelID string, hashValue, signature []byte) (string, error) {
-	return con.server.GetBookingCode(bLevelID, hashValue, signature)
+func (con *utConnection) GetBookingCode(bLevelID string, points int, hashValue, signature []byte) (string, error) {
+	return con.server.GetBookingCode(bLevelID, points, hashValue, signature)
+}
+
+func (con *utConnection) GetCoinDenominations() ([]*CoinDenomination, error) {
+	return con.server.CoinDenominations(), nil
+}
+
+func (con *utConnection) WithdrawCoins(bLevelID, token string, blindCoins []*BlindCoin) (blindSigs []string, err error) {
+	return con.server.WithdrawCoins(bLevelID, token, blindCoins)
+}
+
+func (con *utConnection) SpendCoins(coins []*Coin, bLevelID, rewardID string, blindChange []*BlindCoin) (bonusData string, blindSigs []string, err error) {
+	return con.server.SpendCoins(coins, bLevelID, rewardID, blindChange)
+}
+
+func (con *utConnection) TransferCode(code, bLevelID string, points int, blindToken []byte) (string, error) {
+	return con.server.TransferCode(code, bLevelID, points, blindToken)
+}
+
+func (con *utConnection) ExchangeCodes(codes []string, fromID, toID string, blindToken []byte) (string, error) {
+	return con.server.ExchangeCodes(codes, fromID, toID, blindToken)
 }
 
 func (con *utConnection) AccessBonusSystem(codes []string, adrBundle *crypt.AddressBundle) (tokens, recoveries map[string]string, err error) {
@@ -93,6 +154,10 @@ func (con *utConnection) Participate(bLevelID string, hashValue, signature []byt
 	return con.server.Participate(bLevelID, hashValue, signature, pkr)
 }
 
+func (con *utConnection) PerformAction(bLevelID string, action int, rewardID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
+	return con.server.PerformAction(bLevelID, action, rewardID, hashValue, signature, pkr)
+}
+
 func (con *utConnection) CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.AddressBundle) (status RecoveryStatus, token string, err error) {
 	return con.server.CanBeUsedForRecovery(bLevelID, adrBdl)
 }
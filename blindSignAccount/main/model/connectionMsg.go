@@ -1,130 +1,381 @@
 package model
 
 type MsgDataRecStatus struct {
-	Token          string
-	RecoveryStatus RecoveryStatus
+	Token          string         `json:"token"`
+	RecoveryStatus RecoveryStatus `json:"recoveryStatus"`
 }
 
 type MsgResponseRecStatus struct {
-	Data MsgDataRecStatus
-	Err  string
+	Data MsgDataRecStatus `json:"data"`
+	Err  string           `json:"err"`
 }
 
 type MsgDataRecoveryTest struct {
-	Token              string
-	FoundRecoveryToken string
-	BonusData          string
+	Token              string `json:"token"`
+	FoundRecoveryToken string `json:"foundRecoveryToken"`
+	BonusData          string `json:"bonusData"`
 }
 
 type MsgResponseRecoveryTest struct {
-	Data MsgDataRecoveryTest
-	Err  string
+	Data MsgDataRecoveryTest `json:"data"`
+	Err  string              `json:"err"`
 }
 
 type MsgDataAccessBS struct {
-	Tokens         map[string]string
-	RecoveryTokens map[string]string
+	Tokens         map[string]string `json:"tokens"`
+	RecoveryTokens map[string]string `json:"recoveryTokens"`
 }
 
 type MsgResponseAccessBS struct {
-	Data MsgDataAccessBS
-	Err  string
+	Data MsgDataAccessBS `json:"data"`
+	Err  string          `json:"err"`
 }
 
 type MsgDataParticipate struct {
-	Token         string
-	RecoveryToken string
-	BonusData     string
+	Token         string `json:"token"`
+	RecoveryToken string `json:"recoveryToken"`
+	BonusData     string `json:"bonusData"`
 }
 
 type MsgResponseParticipate struct {
-	Data MsgDataParticipate
-	Err  string
+	Data MsgDataParticipate `json:"data"`
+	Err  string             `json:"err"`
 }
 
 type MsgDataSystemInfo struct {
-	Flights []*Flight
-	BLevels []*BonusLevel
+	Flights []*Flight     `json:"flights"`
+	BLevels []*BonusLevel `json:"bLevels"`
+}
+
+type MsgResponseFlightSearch struct {
+	Data FlightPage `json:"data"`
+	Err  string     `json:"err"`
 }
 
 type MsgResponseStatistic struct {
-	Data *StatisticSummary
-	Err  string
+	Data *StatisticSummary `json:"data"`
+	Err  string            `json:"err"`
 }
 
 type MsgResponseSystemInfo struct {
-	Data MsgDataSystemInfo
-	Err  string
+	Data MsgDataSystemInfo `json:"data"`
+	Err  string            `json:"err"`
 }
 
 type MsgDataBlindSignature struct {
-	BlindSignature string
+	// base64 (url) encoded by JSON, raw by binary encodings
+	BlindSignature string `json:"blindSignature"`
 }
 
 type MsgResponseBlindSignature struct {
-	Data MsgDataBlindSignature
-	Err  string
+	Data MsgDataBlindSignature `json:"data"`
+	Err  string                `json:"err"`
+}
+
+type MsgResponseCoinDenominations struct {
+	Data []*CoinDenomination `json:"data"`
+	Err  string              `json:"err"`
+}
+
+type MsgDataBlindCoins struct {
+	// base64 (url) encoded in the order of the blind coins
+	BlindSignatures []string `json:"blindSignatures"`
+}
+
+type MsgResponseWithdrawCoins struct {
+	Data MsgDataBlindCoins `json:"data"`
+	Err  string            `json:"err"`
+}
+
+type MsgDataTransferCode struct {
+	// base64 (url) encoded
+	BlindSignature string `json:"blindSignature"`
+}
+
+type MsgResponseTransferCode struct {
+	Data MsgDataTransferCode `json:"data"`
+	Err  string              `json:"err"`
+}
+
+type MsgDataExchangeCodes struct {
+	// base64 (url) encoded
+	BlindSignature string `json:"blindSignature"`
+}
+
+type MsgResponseExchangeCodes struct {
+	Data MsgDataExchangeCodes `json:"data"`
+	Err  string               `json:"err"`
+}
+
+type MsgDataSpendCoins struct {
+	BonusData string `json:"bonusData"`
+	// the blind signatures of the change
+	BlindSignatures []string `json:"blindSignatures"`
+}
+
+type MsgResponseSpendCoins struct {
+	Data MsgDataSpendCoins `json:"data"`
+	Err  string            `json:"err"`
 }
 
 type MsgDataSetAdr struct {
-	Token         string
-	RecoveryToken string
+	Token         string `json:"token"`
+	RecoveryToken string `json:"recoveryToken"`
 }
 
 type MsgResponseSetAdr struct {
-	Data MsgDataSetAdr
-	Err  string
+	Data MsgDataSetAdr `json:"data"`
+	Err  string        `json:"err"`
 }
 
 type MsgDataSendBooking struct {
-	Token string
+	// the first earned Token, for clients which expect one Token per booking
+	Token     string         `json:"token"`
+	BookingID int            `json:"bookingID"`
+	Tokens    []*EarnedToken `json:"tokens"`
+}
+
+type MsgDataCancelBooking struct {
+	// the booking token was used for a blind signature before the cancellation
+	CodeMayExist bool `json:"codeMayExist"`
+}
+
+type MsgResponseCancelBooking struct {
+	Data MsgDataCancelBooking `json:"data"`
+	Err  string               `json:"err"`
 }
 
 type MsgResponseSendBooking struct {
-	Data MsgDataSendBooking
-	Err  string
+	Data MsgDataSendBooking `json:"data"`
+	Err  string             `json:"err"`
 }
 
 type MsgDataGetBookingCode struct {
-	Code string
+	Code string `json:"code"`
 }
 
 type MsgResponseGetBookingCode struct {
-	Data MsgDataGetBookingCode
-	Err  string
+	Data MsgDataGetBookingCode `json:"data"`
+	Err  string                `json:"err"`
 }
 
 type MsgRequestAdrBundle struct {
-	Seed      string
+	Seed      HexBytes
 	AccountID uint32
 	AddressID uint32
 	Address   string
 }
 
 type MsgDataRegister struct {
-	ClientID int
+	ClientID int `json:"clientID"`
 }
 
 type MsgResponseRegister struct {
-	Data MsgDataRegister
-	Err  string
+	Data MsgDataRegister `json:"data"`
+	Err  string          `json:"err"`
+}
+
+type MsgDataCustomer struct {
+	CustomerID int `json:"customerID"`
+	// the session of a login, empty for a registration
+	Session string `json:"session,omitempty"`
+}
+
+type MsgResponseCustomer struct {
+	Data MsgDataCustomer `json:"data"`
+	Err  string          `json:"err"`
+}
+
+type MsgResponseBookings struct {
+	Data []*BookingStatus `json:"data"`
+	Err  string           `json:"err"`
+}
+
+type MsgResponsePartnerKeys struct {
+	Data map[string]string `json:"data"`
+	Err  string            `json:"err"`
+}
+
+type MsgResponseRedeemVoucher struct {
+	Data *Redemption `json:"data"`
+	Err  string      `json:"err"`
+}
+
+type MsgDataRevocations struct {
+	PublicKey HexBytes            `json:"publicKey"`
+	Filters   []*RevocationFilter `json:"filters"`
+}
+
+type MsgResponseRevocations struct {
+	Data MsgDataRevocations `json:"data"`
+	Err  string             `json:"err"`
 }
 
 type MsgDataDebugInfo struct {
-	Server *Server
+	Server *Server `json:"server"`
 }
 
 type MsgResponseDebugInfo struct {
-	Data MsgDataDebugInfo
-	Err  string
+	Data MsgDataDebugInfo `json:"data"`
+	Err  string           `json:"err"`
 }
 
 type MsgDataLastAdrBdl struct {
-	Address   string
-	AccountID uint32
+	Address   string `json:"address"`
+	AccountID uint32 `json:"accountID"`
 }
 
 type MsgResponseLastAdrBdl struct {
-	Data MsgDataLastAdrBdl
-	Err  string
+	Data MsgDataLastAdrBdl `json:"data"`
+	Err  string            `json:"err"`
+}
+
+type MsgDataAPIVersions struct {
+	Versions []string `json:"versions"`
+}
+
+type MsgResponseAPIVersions struct {
+	Data MsgDataAPIVersions `json:"data"`
+	Err  string             `json:"err"`
+}
+
+type MsgResponseReset struct {
+	Data struct{} `json:"data"`
+	Err  string   `json:"err"`
+}
+
+type MsgDataHealth struct {
+	Status string `json:"status"`
+}
+
+type MsgResponseHealth struct {
+	Data MsgDataHealth `json:"data"`
+	Err  string        `json:"err"`
+}
+
+type MsgDataReadiness struct {
+	Ready  bool             `json:"ready"`
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+type MsgResponseReadiness struct {
+	Data MsgDataReadiness `json:"data"`
+	Err  string           `json:"err"`
+}
+
+type MsgResponseExit struct {
+	Data string `json:"data"`
+	Err  string `json:"err"`
+}
+
+// Request bodies. Byte values are sent hex encoded by JSON and raw by binary encodings.
+
+// The customer of a booking is authenticated by the session header
+type MsgRequestSendBooking struct {
+	FlightID int `json:"flightID"`
+	// any fare class with free seats if it is empty
+	FareClass string `json:"fareClass,omitempty"`
+}
+
+type MsgRequestCustomer struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type MsgRequestCancelBooking struct {
+	BookingID int `json:"bookingID"`
+}
+
+// The partner of a redemption is authenticated by the partner key header
+type MsgRequestRedeemVoucher struct {
+	Voucher string `json:"voucher"`
+}
+
+type MsgRequestBlindSignature struct {
+	BLevelID   string   `json:"bLevelID"`
+	Token      string   `json:"token"`
+	BlindToken HexBytes `json:"blindToken"`
+	Action     int      `json:"action"`
+}
+
+type MsgRequestGetBookingCode struct {
+	BLevelID  string   `json:"bLevelID"`
+	HashValue HexBytes `json:"hashValue"`
+	Signature HexBytes `json:"signature"`
+	// one point if it is missing
+	Points int `json:"points,omitempty"`
+}
+
+type MsgRequestAccessBS struct {
+	Codes     []string             `json:"codes"`
+	AdrBundle *MsgRequestAdrBundle `json:"adrBundle"`
+}
+
+type MsgRequestSetAdr struct {
+	BLevelID  string               `json:"bLevelID"`
+	HashValue HexBytes             `json:"hashValue"`
+	Signature HexBytes             `json:"signature"`
+	AdrBundle *MsgRequestAdrBundle `json:"adrBundle"`
+	Action    int                  `json:"action"`
+	Pkr       string               `json:"pkr"`
+}
+
+type MsgRequestParticipate struct {
+	BLevelID  string   `json:"bLevelID"`
+	HashValue HexBytes `json:"hashValue"`
+	Signature HexBytes `json:"signature"`
+	Pkr       string   `json:"pkr"`
+	// the performed action, the participation if it is not set
+	Action int `json:"action,omitempty"`
+	// the reward of a participation, the first reward of the catalogue if it is not set
+	RewardID string `json:"rewardID,omitempty"`
+}
+
+type MsgRequestWithdrawCoins struct {
+	BLevelID   string       `json:"bLevelID"`
+	Token      string       `json:"token"`
+	BlindCoins []*BlindCoin `json:"blindCoins"`
+}
+
+type MsgRequestTransferCode struct {
+	Code       string   `json:"code"`
+	BLevelID   string   `json:"bLevelID"`
+	Points     int      `json:"points"`
+	BlindToken HexBytes `json:"blindToken"`
+}
+
+type MsgRequestExchangeCodes struct {
+	Codes      []string `json:"codes"`
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	BlindToken HexBytes `json:"blindToken"`
+}
+
+type MsgRequestSpendCoins struct {
+	Coins    []*Coin `json:"coins"`
+	BLevelID string  `json:"bLevelID"`
+	RewardID string  `json:"rewardID"`
+	// the change, no blind coins if the coins are worth the price
+	BlindChange []*BlindCoin `json:"blindChange,omitempty"`
+}
+
+type MsgRequestRecStatus struct {
+	BLevelID  string               `json:"bLevelID"`
+	AdrBundle *MsgRequestAdrBundle `json:"adrBundle"`
+}
+
+type MsgRequestRecoveryTest struct {
+	BLevelID      string               `json:"bLevelID"`
+	RecoveryToken string               `json:"recoveryToken"`
+	Pkr           string               `json:"pkr"`
+	AdrBundle     *MsgRequestAdrBundle `json:"adrBundle"`
+}
+
+type MsgRequestLastAdrBdl struct {
+	BLevelID string   `json:"bLevelID"`
+	Seed     HexBytes `json:"seed"`
+}
+
+type MsgRequestExit struct {
+	ExitStatus int `json:"exitStatus"`
 }
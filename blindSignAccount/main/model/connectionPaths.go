@@ -18,6 +18,20 @@ const (
 	PathStatistic
 	PathDebugInfos
 	PathReset
+	PathCoinDenominations
+	PathWithdrawCoins
+	PathSpendCoins
+	PathTransferCode
+	PathExchangeCodes
+	PathFlightSearch
+	PathCancelBooking
+	PathCustomerRegister
+	PathCustomerLogin
+	PathCustomerLogout
+	PathCustomerBookings
+	PathPartnerKeys
+	PathPartnerRedeem
+	PathPartnerRevocations
 )
 
 var ServerAddress string
@@ -28,9 +42,99 @@ func (path RoutePath) String() string {
 		"/setAddress", "/accessBonusSystem", "/participate",
 		"/recovery/canBeUsedForRecovery", "/recovery/test", "/system/register", "/system/exit",
 		"/system/statistic", "/system/debug", "/system/reset",
+		"/coins/denominations", "/coins/withdraw", "/coins/spend",
+		"/codes/transfer", "/codes/exchange",
+		"/flights/search", "/booking/cancel",
+		"/customer/register", "/customer/login", "/customer/logout", "/customer/bookings",
+		"/partner/keys", "/partner/vouchers/redeem", "/partner/revocations",
 	}
-	if path < PathSendBooking || path > PathReset {
+	if path < PathSendBooking || int(path) >= len(names) {
 		return "unknown path"
 	}
 	return names[path]
 }
+
+// Reports whether requests of the path identify the client: the routes of logged in customers.
+// The client id is sent on these routes only, so the anonymous protocol steps are not linked to it.
+func (path RoutePath) IdentifiesClient() bool {
+	switch path {
+	case PathSendBooking, PathCancelBooking, PathCustomerLogout, PathCustomerBookings:
+		return true
+	}
+	return false
+}
+
+// Returns the path of the route under the given api version
+func (path RoutePath) Versioned(version APIVersion) string {
+	return version.Prefix() + path.String()
+}
+
+type APIVersion int
+
+const (
+	APIVersionLegacy = iota
+	APIVersion1
+)
+
+// Routes which are not part of a versioned route group
+const (
+	// lists the api versions supported by the server
+	RouteAPIVersions = "/versions"
+	// the OpenAPI document of a route group
+	RouteOpenAPI = "/openapi.json"
+	// server-sent events of the live statistic for operators
+	RouteStatisticStream = "/admin/statistic/stream"
+	// metrics in the prometheus text format
+	RouteMetrics = "/metrics"
+	// liveness and readiness probes
+	RouteHealth = "/healthz"
+	RouteReady  = "/readyz"
+	// management of the flight catalogue for operators
+	RouteAdminFlights = "/admin/flights"
+	// tiers of customers used by the earning rules, for operators
+	RouteAdminCustomerTier = "/admin/customers/tier"
+)
+
+// header which identifies a registered client on the routes of logged in customers
+const HeaderClientID = "X-Client-ID"
+
+// header which carries the session of a logged in customer as bearer token.
+// It is accepted by the booking routes only.
+const HeaderAuthorization = "Authorization"
+const BearerPrefix = "Bearer "
+
+// header which carries the key of a partner. It is accepted by the partner routes only.
+const HeaderPartnerKey = "X-Partner-Key"
+
+// header which carries the key of an operator. It is accepted by the admin routes only.
+const HeaderAdminKey = "X-Admin-Key"
+
+// the newest api version known by this code base
+const LatestAPIVersion = APIVersion1
+
+func (version APIVersion) String() string {
+	names := [...]string{"legacy", "v1"}
+	if version < APIVersionLegacy || version > LatestAPIVersion {
+		return "unknown version"
+	}
+	return names[version]
+}
+
+// Returns the route prefix of the api version.
+// Legacy routes have no prefix.
+func (version APIVersion) Prefix() string {
+	if version <= APIVersionLegacy || version > LatestAPIVersion {
+		return ""
+	}
+	return "/" + version.String()
+}
+
+// Parses the name of an api version
+func ParseAPIVersion(name string) (APIVersion, bool) {
+	for version := APIVersion(APIVersionLegacy); version <= LatestAPIVersion; version++ {
+		if version.String() == name {
+			return version, true
+		}
+	}
+	return APIVersionLegacy, false
+}
@@ -77,12 +77,113 @@ func TestRoutePath_String(t *testing.T) {
 		t.Errorf("wrong string representation: %s", strRep)
 	}
 
+	strRep = RoutePath(PathSpendCoins).String()
+	if strRep != "/coins/spend" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathTransferCode).String()
+	if strRep != "/codes/transfer" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathExchangeCodes).String()
+	if strRep != "/codes/exchange" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathFlightSearch).String()
+	if strRep != "/flights/search" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathCancelBooking).String()
+	if strRep != "/booking/cancel" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathCustomerRegister).String()
+	if strRep != "/customer/register" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathCustomerLogin).String()
+	if strRep != "/customer/login" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathCustomerLogout).String()
+	if strRep != "/customer/logout" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathCustomerBookings).String()
+	if strRep != "/customer/bookings" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathPartnerKeys).String()
+	if strRep != "/partner/keys" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathPartnerRedeem).String()
+	if strRep != "/partner/vouchers/redeem" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
+	strRep = RoutePath(PathPartnerRevocations).String()
+	if strRep != "/partner/revocations" {
+		t.Errorf("wrong string representation: %s", strRep)
+	}
+
 	strRep = RoutePath(-1).String()
 	if strRep != "unknown path" {
 		t.Errorf("wrong string representation: %s", strRep)
 	}
-	strRep = RoutePath(15).String()
+	strRep = RoutePath(PathPartnerRevocations + 1).String()
 	if strRep != "unknown path" {
 		t.Errorf("wrong string representation: %s", strRep)
 	}
 }
+
+func TestRoutePath_Versioned(t *testing.T) {
+	if path := RoutePath(PathSendBooking).Versioned(APIVersionLegacy); path != "/booking/send" {
+		t.Errorf("wrong legacy path: %s", path)
+	}
+	if path := RoutePath(PathSendBooking).Versioned(APIVersion1); path != "/v1/booking/send" {
+		t.Errorf("wrong v1 path: %s", path)
+	}
+	if path := RoutePath(PathReset).Versioned(APIVersion(7)); path != "/system/reset" {
+		t.Errorf("wrong path for unknown version: %s", path)
+	}
+}
+
+func TestRoutePath_IdentifiesClient(t *testing.T) {
+	for _, path := range []RoutePath{PathSendBooking, PathCancelBooking, PathCustomerLogout, PathCustomerBookings} {
+		if !path.IdentifiesClient() {
+			t.Errorf("customer route %s does not identify the client", path)
+		}
+	}
+	// the anonymous protocol steps must not be linked to the client
+	for _, path := range []RoutePath{PathRegister, PathAccessBonusSystem, PathBlindSignature, PathParticipate, PathSetAddress, PathGetBookingCode} {
+		if path.IdentifiesClient() {
+			t.Errorf("anonymous route %s identifies the client", path)
+		}
+	}
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	if version, known := ParseAPIVersion("v1"); !known || version != APIVersion1 {
+		t.Error("v1 not parsed")
+	}
+	if version, known := ParseAPIVersion("legacy"); !known || version != APIVersionLegacy {
+		t.Error("legacy not parsed")
+	}
+	if _, known := ParseAPIVersion("v99"); known {
+		t.Error("unknown version parsed")
+	}
+	if name := APIVersion(-1).String(); name != "unknown version" {
+		t.Errorf("wrong string representation: %s", name)
+	}
+}
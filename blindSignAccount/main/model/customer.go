@@ -0,0 +1,129 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// the minimal length of a customer's password
+const minPasswordLength = 8
+
+// A customer account. Customers are identified for bookings only: the
+// access and participation of the bonus system stay anonymous.
+type Customer struct {
+	ID   int
+	Name string
+	// the bcrypt hash of the password
+	PasswordHash []byte `json:"-"`
+}
+
+// A session of a logged in customer
+type customerSession struct {
+	customerID int
+	expiresAt  time.Time
+}
+
+// the hash which is compared with the passwords of unknown customers, so a login takes the
+// same time for known and unknown names
+var dummyPasswordHash struct {
+	once sync.Once
+	hash []byte
+}
+
+func unknownCustomerHash() []byte {
+	dummyPasswordHash.once.Do(func() {
+		dummyPasswordHash.hash, _ = bcrypt.GenerateFromPassword([]byte(crypt.GenerateToken()), bcrypt.DefaultCost)
+	})
+	return dummyPasswordHash.hash
+}
+
+// Creates a new customer account with a unique name and returns its id
+func (s *Server) RegisterCustomer(name, password string) (int, error) {
+	if name == "" {
+		return 0, errors.New("customer name is empty")
+	}
+	if len(password) < minPasswordLength {
+		return 0, errors.New("password must have at least " + strconv.Itoa(minPasswordLength) + " characters")
+	}
+	// hashing is expensive and done without holding a lock
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+
+	s.muxCustomers.Lock()
+	defer s.muxCustomers.Unlock()
+	if s.customers[name] != nil {
+		return 0, errors.New("customer '" + name + "' already exists")
+	}
+	customer := &Customer{ID: len(s.customers) + 1, Name: name, PasswordHash: hash}
+	s.customers[name] = customer
+	return customer.ID, nil
+}
+
+// Checks the password of a customer and starts a new session.
+// Returns the session token, which authenticates the bookings of the customer.
+func (s *Server) Login(name, password string) (session string, customerID int, err error) {
+	s.muxCustomers.Lock()
+	customer := s.customers[name]
+	s.muxCustomers.Unlock()
+
+	// unknown names and wrong passwords are not distinguished, not even by the time
+	passwordHash := unknownCustomerHash()
+	if customer != nil {
+		passwordHash = customer.PasswordHash
+	}
+	if bcrypt.CompareHashAndPassword(passwordHash, []byte(password)) != nil || customer == nil {
+		return "", 0, errors.New("wrong customer name or password")
+	}
+
+	session = crypt.GenerateToken()
+	now := time.Now()
+	duration := config.GetConfigSessionDuration()
+	s.muxCustomers.Lock()
+	defer s.muxCustomers.Unlock()
+	// expired sessions are removed once per session duration, so they are kept for two durations at most
+	if now.Sub(s.sessionsSwept) >= duration {
+		s.sweepSessions(now)
+	}
+	s.sessions[session] = &customerSession{customerID: customer.ID, expiresAt: now.Add(duration)}
+	return session, customer.ID, nil
+}
+
+// Removes the expired sessions. The caller has to hold the customer lock.
+func (s *Server) sweepSessions(now time.Time) {
+	for session, customerSession := range s.sessions {
+		if now.After(customerSession.expiresAt) {
+			delete(s.sessions, session)
+		}
+	}
+	s.sessionsSwept = now
+}
+
+// Ends the session of a customer
+func (s *Server) Logout(session string) {
+	s.muxCustomers.Lock()
+	defer s.muxCustomers.Unlock()
+	delete(s.sessions, session)
+}
+
+// Returns the id of the customer who is logged in with the session
+func (s *Server) Authenticate(session string) (int, error) {
+	s.muxCustomers.Lock()
+	defer s.muxCustomers.Unlock()
+
+	customerSession := s.sessions[session]
+	if customerSession == nil {
+		return 0, errors.New("session is not valid")
+	}
+	if time.Now().After(customerSession.expiresAt) {
+		delete(s.sessions, session)
+		return 0, errors.New("session is expired")
+	}
+	return customerSession.customerID, nil
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"testing"
+	"time"
+)
+
+func TestServer_RegisterCustomer(t *testing.T) {
+	server := NewServer()
+
+	if id, err := server.RegisterCustomer(utCustomerName, utCustomerPassword); err != nil || id != 1 {
+		t.Errorf("customer not registered: %d %v", id, err)
+	}
+	if _, err := server.RegisterCustomer(utCustomerName, utCustomerPassword); err == nil {
+		t.Error("customer name registered twice")
+	}
+	if _, err := server.RegisterCustomer("", utCustomerPassword); err == nil {
+		t.Error("customer with empty name registered")
+	}
+	if _, err := server.RegisterCustomer("other", "short"); err == nil {
+		t.Error("customer with short password registered")
+	}
+
+	// the password is stored as hash only
+	if string(server.customers[utCustomerName].PasswordHash) == utCustomerPassword {
+		t.Error("password stored in plain text")
+	}
+
+	server.Reset()
+	if _, _, err := server.Login(utCustomerName, utCustomerPassword); err == nil {
+		t.Error("customer not removed by reset")
+	}
+}
+
+func TestServer_Login(t *testing.T) {
+	server := NewServer()
+	customerID, _ := server.RegisterCustomer(utCustomerName, utCustomerPassword)
+
+	if _, _, err := server.Login(utCustomerName, "wrong password"); err == nil {
+		t.Error("login with wrong password")
+	}
+	if _, _, err := server.Login("unknown", utCustomerPassword); err == nil {
+		t.Error("login of unknown customer")
+	}
+
+	session, id, err := server.Login(utCustomerName, utCustomerPassword)
+	if err != nil || id != customerID {
+		t.Fatalf("login failed: %d %v", id, err)
+	}
+	if id, err = server.Authenticate(session); err != nil || id != customerID {
+		t.Errorf("session not authenticated: %d %v", id, err)
+	}
+
+	server.Logout(session)
+	if _, err = server.Authenticate(session); err == nil {
+		t.Error("session valid after logout")
+	}
+
+	// expired sessions are removed
+	session, _, _ = server.Login(utCustomerName, utCustomerPassword)
+	server.sessions[session].expiresAt = time.Now().Add(-time.Second)
+	if _, err = server.Authenticate(session); err == nil || server.sessions[session] != nil {
+		t.Error("expired session is valid")
+	}
+}
+
+func TestServer_Login_SweepsSessions(t *testing.T) {
+	server := NewServer()
+	_, _ = server.RegisterCustomer(utCustomerName, utCustomerPassword)
+
+	expired, _, _ := server.Login(utCustomerName, utCustomerPassword)
+	valid, _, _ := server.Login(utCustomerName, utCustomerPassword)
+	server.sessions[expired].expiresAt = time.Now().Add(-time.Second)
+
+	// sessions which are never used again are removed by a later login
+	server.sessionsSwept = time.Now().Add(-config.GetConfigSessionDuration())
+	if _, _, err := server.Login(utCustomerName, utCustomerPassword); err != nil {
+		t.Fatal(err)
+	}
+	if server.sessions[expired] != nil || server.sessions[valid] == nil || len(server.sessions) != 2 {
+		t.Errorf("wrong sessions after a login: %d", len(server.sessions))
+	}
+}
+
+func TestServer_Login_UnknownName(t *testing.T) {
+	server := NewServer()
+	_, _ = server.RegisterCustomer(utCustomerName, utCustomerPassword)
+	_, _, _ = server.Login("unknown", utCustomerPassword)
+
+	// an unknown name is checked against a hash as expensive as the hash of a password
+	login := func(name string) time.Duration {
+		start := time.Now()
+		_, _, _ = server.Login(name, "wrong password")
+		return time.Since(start)
+	}
+	known, unknown := login(utCustomerName), login("unknown")
+	if unknown < known/4 {
+		t.Errorf("login of an unknown name took %s, of a known name %s", unknown, known)
+	}
+}
@@ -25,8 +25,12 @@ func TestDemoThreeBookings(t *testing.T) {
 	}
 
 	time.Sleep(time.Second)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 	for i := 0; i < 3; i++ {
-		if err := client.Booking(1, utLowLevelID); err != nil {
+		if err := client.Booking(1, utLowFareClass); err != nil {
 			t.Error(err)
 			t.Fail()
 		}
@@ -62,8 +66,12 @@ func TestDemoAccess(t *testing.T) {
 	log.Println()
 	log.Println("The client books three times and receives bonus codes.")
 	time.Sleep(time.Second)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 	for i := 0; i < 3; i++ {
-		if err := client.Booking(1, utMiddleLevelID); err != nil {
+		if err := client.Booking(1, utMiddleFareClass); err != nil {
 			t.Error(err)
 			t.FailNow()
 		}
@@ -116,8 +124,12 @@ func TestDemoParticipate(t *testing.T) {
 	log.Println()
 	log.Println("The client books three times and receives bonus codes.")
 	time.Sleep(time.Second)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 	for i := 0; i < 3; i++ {
-		if err := client.Booking(1, utMiddleLevelID); err != nil {
+		if err := client.Booking(1, utMiddleFareClass); err != nil {
 			t.Error(err)
 			t.FailNow()
 		}
@@ -185,7 +197,7 @@ func TestDemoParticipate(t *testing.T) {
 }
 
 func TestDemoRestoration(t *testing.T) {
-	if _, err := testSetupForRestTests(); err != nil {
+	if _, err := testSetupForRestTestsWithReset(); err != nil {
 		t.Log(err)
 		t.Skip("server is down")
 	}
@@ -206,8 +218,12 @@ func TestDemoRestoration(t *testing.T) {
 	log.Println()
 	log.Println("The client books three times and receives bonus codes.")
 	time.Sleep(time.Second)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 	for i := 0; i < 3; i++ {
-		if err := client.Booking(1, utMiddleLevelID); err != nil {
+		if err := client.Booking(1, utMiddleFareClass); err != nil {
 			t.Error(err)
 			t.FailNow()
 		}
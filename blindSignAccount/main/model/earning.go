@@ -0,0 +1,128 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// the airline code of the rules which apply to all airlines without own rules
+const AnyAirline = "*"
+
+// the tier of customers without a tier
+const DefaultCustomerTier = "basic"
+
+// A token for one earned code, the bonus level the code is valid for and the points it is worth
+type EarnedToken struct {
+	Token    string `json:"token"`
+	BLevelID string `json:"bLevelID"`
+	Points   int    `json:"points,omitempty"`
+}
+
+// Returns the earning rules used if none are configured: the fare class decides the bonus level
+func DefaultEarningRules() map[string][]config.EarningRule {
+	return map[string][]config.EarningRule{AnyAirline: {
+		{FareClass: FareBusiness, BonusLevel: "high", Codes: 1},
+		{FareClass: FarePremium, BonusLevel: "middle", Codes: 1},
+		{BonusLevel: "low", Codes: 1},
+	}}
+}
+
+// Replaces the earning rules. All bonus levels of the rules have to exist.
+// Missing denominations of the earned codes are added to the bonus levels.
+func (s *Server) SetEarningRules(rules map[string][]config.EarningRule) error {
+	// sync
+	s.Mux.Lock()
+	defer s.Mux.Unlock()
+
+	if err := s.checkEarningRules(rules); err != nil {
+		return err
+	}
+	s.earningRules = rules
+	s.addDenominations()
+	return nil
+}
+
+// Adds the denominations of the earning rules to the bonus levels.
+// The caller has to hold a write lock of the server.
+func (s *Server) addDenominations() {
+	for _, airlineRules := range s.earningRules {
+		for _, rule := range airlineRules {
+			s.BonusList[rule.BonusLevel].AddDenomination(rule.Points)
+		}
+	}
+}
+
+func (s *Server) checkEarningRules(rules map[string][]config.EarningRule) error {
+	for airline, airlineRules := range rules {
+		for i, rule := range airlineRules {
+			name := "earning rule " + strconv.Itoa(i) + " of airline '" + airline + "'"
+			if s.BonusList[rule.BonusLevel] == nil {
+				return errors.New(name + ": bonus level '" + rule.BonusLevel + "' does not exist")
+			}
+			if rule.Codes < 1 {
+				return errors.New(name + ": at least one code must be earned")
+			}
+			if rule.Points < 0 {
+				return errors.New(name + ": points must not be negative")
+			}
+			if rule.MaxDistance > 0 && rule.MaxDistance < rule.MinDistance {
+				return errors.New(name + ": maximal distance is smaller than the minimal distance")
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the bonus level, the number of codes and the points of every code a booking earns,
+// nil if no rule matches. The caller has to hold a read lock of the server.
+func (s *Server) earn(flight *Flight, fareClass string, customerID int) (bLevel *BonusLevel, codes, points int) {
+	tier := s.CustomerTier(customerID)
+	flight.mux.Lock()
+	airline, distance := flight.Airline, flight.Distance
+	flight.mux.Unlock()
+
+	rules, found := s.earningRules[airline]
+	if !found {
+		rules = s.earningRules[AnyAirline]
+	}
+	for _, rule := range rules {
+		if rule.FareClass != "" && !strings.EqualFold(rule.FareClass, fareClass) {
+			continue
+		}
+		if rule.CustomerTier != "" && !strings.EqualFold(rule.CustomerTier, tier) {
+			continue
+		}
+		if distance < rule.MinDistance || (rule.MaxDistance > 0 && distance > rule.MaxDistance) {
+			continue
+		}
+		points = rule.Points
+		if points < 1 {
+			points = 1
+		}
+		return s.BonusList[rule.BonusLevel], rule.Codes, points
+	}
+	return nil, 0, 0
+}
+
+// Sets the tier of a customer, which may be used by earning rules
+func (s *Server) SetCustomerTier(customerID int, tier string) {
+	s.muxCustomers.Lock()
+	defer s.muxCustomers.Unlock()
+	if tier == "" || tier == DefaultCustomerTier {
+		delete(s.customerTiers, customerID)
+		return
+	}
+	s.customerTiers[customerID] = tier
+}
+
+// Returns the tier of a customer
+func (s *Server) CustomerTier(customerID int) string {
+	s.muxCustomers.Lock()
+	defer s.muxCustomers.Unlock()
+	if tier, found := s.customerTiers[customerID]; found {
+		return tier
+	}
+	return DefaultCustomerTier
+}
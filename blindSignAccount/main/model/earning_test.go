@@ -0,0 +1,151 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"github.com/cryptoballot/rsablind"
+	"testing"
+)
+
+func TestRouteDistance(t *testing.T) {
+	if distance := RouteDistance("FRA", "MUC"); distance < 290 || distance > 310 {
+		t.Errorf("wrong distance FRA-MUC: %d", distance)
+	}
+	if RouteDistance("fra", "mad") != RouteDistance("MAD", "FRA") {
+		t.Error("distance depends on the direction or case")
+	}
+	if RouteDistance("FRA", "XXX") != 0 {
+		t.Error("distance to an unknown airport")
+	}
+}
+
+func TestServer_Booking_DefaultEarningRules(t *testing.T) {
+	s := NewServer()
+	for fareClass, bLevelID := range map[string]string{FareEconomy: "low", FarePremium: "middle", FareBusiness: "high"} {
+		tokens, err := s.Booking(1, 1, fareClass)
+		if err != nil || len(tokens) != 1 || tokens[0].BLevelID != bLevelID {
+			t.Errorf("booking in %s did not earn a code of %s: %v", fareClass, bLevelID, err)
+			continue
+		}
+		if !s.BonusList[bLevelID].isTokenValid(tokens[0].Token, ActionBooking) {
+			t.Error("earned token is not valid")
+		}
+	}
+}
+
+func TestServer_SetEarningRules(t *testing.T) {
+	s := NewServer()
+	rules := map[string][]config.EarningRule{
+		"BS": {
+			{FareClass: FareBusiness, BonusLevel: "high", Codes: 2},
+			{CustomerTier: "gold", BonusLevel: "middle", Codes: 3},
+			{MinDistance: 1000, BonusLevel: "middle", Codes: 1},
+			{MaxDistance: 999, BonusLevel: "low", Codes: 1},
+		},
+		AnyAirline: {{BonusLevel: "low", Codes: 4}},
+	}
+	for _, invalid := range []config.EarningRule{{BonusLevel: "unknown", Codes: 1}, {BonusLevel: "low"},
+		{MinDistance: 100, MaxDistance: 50, BonusLevel: "low", Codes: 1}} {
+		if err := s.SetEarningRules(map[string][]config.EarningRule{AnyAirline: {invalid}}); err == nil {
+			t.Errorf("invalid rule accepted: %+v", invalid)
+		}
+	}
+	if err := s.SetEarningRules(rules); err != nil {
+		t.Fatal(err)
+	}
+
+	fareClasses := []*FareClass{{Name: FareEconomy, Seats: 10}, {Name: FareBusiness, Seats: 10}}
+	flights := []*Flight{
+		{ID: 1000, Number: "BS1000", Origin: "FRA", Destination: "MUC", FareClasses: fareClasses},
+		{ID: 1001, Number: "BS1001", Origin: "FRA", Destination: "MAD", FareClasses: fareClasses},
+		{ID: 1002, Number: "XY1002", Origin: "FRA", Destination: "MUC", FareClasses: fareClasses},
+	}
+	for _, flight := range flights {
+		if err := s.AddFlight(flight); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.SetCustomerTier(7, "gold")
+
+	for _, test := range []struct {
+		flightID, customerID int
+		fareClass, bLevelID  string
+		codes                int
+	}{
+		{1000, 1, FareBusiness, "high", 2},
+		{1000, 7, FareEconomy, "middle", 3},
+		{1000, 1, FareEconomy, "low", 1},
+		{1001, 1, FareEconomy, "middle", 1},
+		{1002, 7, FareBusiness, "low", 4},
+	} {
+		tokens, err := s.Booking(test.flightID, test.customerID, test.fareClass)
+		if err != nil || len(tokens) != test.codes {
+			t.Errorf("%+v: wrong number of tokens %d (%v)", test, len(tokens), err)
+			continue
+		}
+		for _, token := range tokens {
+			if token.BLevelID != test.bLevelID {
+				t.Errorf("%+v: token of bonus level %s", test, token.BLevelID)
+			}
+		}
+	}
+
+	// bookings which match no rule earn nothing, the rules are kept by a reset
+	s.SetCustomerTier(7, DefaultCustomerTier)
+	if s.CustomerTier(7) != DefaultCustomerTier {
+		t.Error("customer tier not reset")
+	}
+	rules["BS"] = rules["BS"][:1]
+	if err := s.SetEarningRules(rules); err != nil {
+		t.Fatal(err)
+	}
+	s.Reset()
+	if tokens, err := s.Booking(1, 1, FareEconomy); err != nil || len(tokens) != 0 {
+		t.Errorf("booking without matching rule earned %d tokens (%v)", len(tokens), err)
+	}
+}
+
+func TestServer_Booking_WeightedCodes(t *testing.T) {
+	s := NewServer()
+	rules := map[string][]config.EarningRule{AnyAirline: {{BonusLevel: "low", Codes: 2, Points: 3}}}
+	if err := s.SetEarningRules(rules); err != nil {
+		t.Fatal(err)
+	}
+	if s.BonusList["low"].BookingPublicKey(3) == nil || s.BonusList["low"].BookingPublicKey(2) != nil {
+		t.Fatal("wrong denominations of level 'low'")
+	}
+	tokens, err := s.Booking(1, 1, FareEconomy)
+	if err != nil || len(tokens) != 2 || tokens[0].Points != 3 {
+		t.Fatalf("booking did not earn two tokens of 3 points: %v", err)
+	}
+
+	// the blind signature is made by the key of the denomination
+	bLevel := s.BonusList["low"]
+	blindBundle, _ := crypt.CreateBlindBundle(*bLevel.BookingPublicKey(3))
+	blindSig, err := s.GetBlindSignature("low", tokens[0].Token, blindBundle.BlindToken, ActionBooking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawSig, _ := base64.URLEncoding.DecodeString(blindSig)
+	signature := rsablind.Unblind(bLevel.BookingPublicKey(3), rawSig, blindBundle.UnBlinder)
+	if _, err = s.GetBookingCode("low", 1, blindBundle.HashValue, signature); err == nil {
+		t.Error("code of 1 point generated with the key of 3 points")
+	}
+	if _, err = s.GetBookingCode("low", 2, blindBundle.HashValue, signature); err == nil {
+		t.Error("code of an unknown denomination generated")
+	}
+	code, err := s.GetBookingCode("low", 3, blindBundle.HashValue, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// one code of 3 points does not reach the 5 points of level 'low', two codes do
+	if accessible := s.verifyCodes([]string{code}); len(accessible) != 0 {
+		t.Error("level accessed with 3 points")
+	}
+	codes := []string{s.generateBonusCode("low", 3).CodeID, s.generateBonusCode("low", 3).CodeID}
+	if accessible := s.verifyCodes(codes); len(accessible) != 1 || accessible[0].BonusID != "low" {
+		t.Error("level 'low' not accessed with 6 points")
+	}
+}
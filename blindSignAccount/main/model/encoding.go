@@ -0,0 +1,159 @@
+package model
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/ugorji/go/codec"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+type Encoding int
+
+const (
+	EncodingJSON = iota
+	EncodingMsgpack
+	EncodingCBOR
+)
+
+const (
+	MIMEJSON    = "application/json"
+	MIMEMsgpack = "application/msgpack"
+	MIMECBOR    = "application/cbor"
+)
+
+// big integers (rsa keys) are sent as positive bignums
+const bigIntTag = 2
+
+var msgpackHandle, cborHandle = newCodecHandles()
+
+func newCodecHandles() (*codec.MsgpackHandle, *codec.CborHandle) {
+	mapType := reflect.TypeOf(map[string]interface{}(nil))
+
+	mh := &codec.MsgpackHandle{WriteExt: true, RawToString: true}
+	mh.MapType = mapType
+	if err := mh.SetBytesExt(reflect.TypeOf(big.Int{}), bigIntTag, bigIntExt{}); err != nil {
+		panic(err)
+	}
+
+	ch := &codec.CborHandle{}
+	ch.MapType = mapType
+	if err := ch.SetInterfaceExt(reflect.TypeOf(big.Int{}), bigIntTag, bigIntExt{}); err != nil {
+		panic(err)
+	}
+	return mh, ch
+}
+
+// String returns the name of the encoding
+func (enc Encoding) String() string {
+	names := [...]string{"json", "msgpack", "cbor"}
+	if !enc.IsValid() {
+		return "unknown encoding"
+	}
+	return names[enc]
+}
+
+func (enc Encoding) IsValid() bool {
+	return enc >= EncodingJSON && enc <= EncodingCBOR
+}
+
+// Returns the mime type of the encoding
+func (enc Encoding) ContentType() string {
+	switch enc {
+	case EncodingMsgpack:
+		return MIMEMsgpack
+	case EncodingCBOR:
+		return MIMECBOR
+	}
+	return MIMEJSON
+}
+
+// Reports whether byte values are sent raw
+func (enc Encoding) IsBinary() bool {
+	return enc == EncodingMsgpack || enc == EncodingCBOR
+}
+
+// Returns the encoding of a content type or accept header value.
+// Unknown types are reported as JSON.
+func EncodingFromContentType(contentType string) (Encoding, bool) {
+	for _, mimeType := range strings.Split(contentType, ",") {
+		// strip parameters
+		mimeType = strings.TrimSpace(strings.Split(mimeType, ";")[0])
+		switch mimeType {
+		case MIMEJSON:
+			return EncodingJSON, true
+		case MIMEMsgpack, "application/x-msgpack":
+			return EncodingMsgpack, true
+		case MIMECBOR:
+			return EncodingCBOR, true
+		}
+	}
+	return EncodingJSON, false
+}
+
+// Returns the encoding with the given name. Unknown names are reported as JSON.
+func EncodingFromName(name string) Encoding {
+	for enc := Encoding(EncodingJSON); enc <= EncodingCBOR; enc++ {
+		if enc.String() == name {
+			return enc
+		}
+	}
+	return EncodingJSON
+}
+
+func (enc Encoding) Marshal(v interface{}) ([]byte, error) {
+	if !enc.IsBinary() {
+		return json.Marshal(v)
+	}
+	var out []byte
+	err := codec.NewEncoderBytes(&out, enc.handle()).Encode(v)
+	return out, err
+}
+
+func (enc Encoding) Unmarshal(data []byte, v interface{}) error {
+	if !enc.IsBinary() {
+		return json.Unmarshal(data, v)
+	}
+	return codec.NewDecoderBytes(data, enc.handle()).Decode(v)
+}
+
+func (enc Encoding) handle() codec.Handle {
+	if enc == EncodingCBOR {
+		return cborHandle
+	}
+	return msgpackHandle
+}
+
+// Byte values which are hex encoded by JSON and sent raw by binary encodings
+type HexBytes []byte
+
+func (b HexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+func (b *HexBytes) UnmarshalText(text []byte) (err error) {
+	*b, err = hex.DecodeString(string(text))
+	return
+}
+
+// Codec extension for non-negative big integers
+type bigIntExt struct{}
+
+func (bigIntExt) WriteExt(v interface{}) []byte {
+	return v.(*big.Int).Bytes()
+}
+
+func (bigIntExt) ReadExt(dst interface{}, src []byte) {
+	dst.(*big.Int).SetBytes(src)
+}
+
+func (ext bigIntExt) ConvertExt(v interface{}) interface{} {
+	return ext.WriteExt(v)
+}
+
+func (ext bigIntExt) UpdateExt(dst interface{}, src interface{}) {
+	if raw, ok := src.([]byte); ok {
+		ext.ReadExt(dst, raw)
+	}
+}
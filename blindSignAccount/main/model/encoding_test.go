@@ -0,0 +1,143 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncodingFromContentType(t *testing.T) {
+	if enc, known := EncodingFromContentType("application/msgpack; charset=utf-8"); !known || enc != EncodingMsgpack {
+		t.Error("msgpack not detected")
+	}
+	if enc, known := EncodingFromContentType("text/html, application/cbor"); !known || enc != EncodingCBOR {
+		t.Error("cbor not detected")
+	}
+	if enc, known := EncodingFromContentType("text/plain"); known || enc != EncodingJSON {
+		t.Error("unknown type not reported as json")
+	}
+	if EncodingFromName("cbor") != EncodingCBOR || EncodingFromName("") != EncodingJSON {
+		t.Error("wrong encoding for name")
+	}
+	if Encoding(7).String() != "unknown encoding" {
+		t.Error("wrong string representation")
+	}
+}
+
+func TestHexBytes(t *testing.T) {
+	raw, err := json.Marshal(MsgRequestGetBookingCode{BLevelID: "low", HashValue: HexBytes{0xab, 0x01}})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if string(raw) != `{"bLevelID":"low","hashValue":"ab01","signature":""}` {
+		t.Error("wrong json: " + string(raw))
+		t.Fail()
+	}
+	var msg MsgRequestGetBookingCode
+	if err = json.Unmarshal(raw, &msg); err != nil || len(msg.HashValue) != 2 || msg.HashValue[0] != 0xab {
+		t.Error("hex value not decoded")
+		t.Fail()
+	}
+}
+
+func TestEncoding_MarshalUnmarshal(t *testing.T) {
+	bLevel := NewBonusLevel(utLowLevelID, 1, 1)
+	in := MsgResponseSystemInfo{Data: MsgDataSystemInfo{Flights: []*Flight{{ID: 3}},
+		BLevels: []*BonusLevel{bLevel.CopyPublic()}}}
+
+	for enc := Encoding(EncodingJSON); enc.IsValid(); enc++ {
+		var out MsgResponseSystemInfo
+		raw, err := enc.Marshal(in)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if err = enc.Unmarshal(raw, &out); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if len(out.Data.Flights) != 1 || out.Data.Flights[0].ID != 3 {
+			t.Error("flights not decoded with " + enc.String())
+			t.Fail()
+		}
+		// the public keys are needed for blinding
+		pk := out.Data.BLevels[0].ActionVariants[ActionBooking].PublicKey
+		if pk.N == nil || pk.N.Cmp(bLevel.ActionVariants[ActionBooking].PublicKey.N) != 0 ||
+			pk.E != bLevel.ActionVariants[ActionBooking].PublicKey.E {
+			t.Error("public key not decoded with " + enc.String())
+			t.Fail()
+		}
+	}
+}
+
+func TestEncoding_RawBytes(t *testing.T) {
+	blindBundle, _ := crypt.CreateBlindBundle(NewBonusActionVariant(ActionBooking).PublicKey)
+	request := MsgRequestBlindSignature{BLevelID: utLowLevelID, Token: crypt.GenerateToken(),
+		BlindToken: blindBundle.BlindToken, Action: ActionBooking}
+
+	jsonBody, _ := Encoding(EncodingJSON).Marshal(request)
+	for _, enc := range []Encoding{EncodingMsgpack, EncodingCBOR} {
+		body, err := enc.Marshal(request)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		// the blind token is not hex inflated
+		if len(body) >= len(jsonBody)-len(blindBundle.BlindToken) {
+			t.Errorf("%s body too large: %d (json: %d)", enc, len(body), len(jsonBody))
+			t.Fail()
+		}
+		// a server decodes bodies without knowing the message type
+		var values map[string]interface{}
+		if err = enc.Unmarshal(body, &values); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if raw, ok := values["blindToken"].([]byte); !ok || len(raw) != len(blindBundle.BlindToken) {
+			t.Errorf("blind token not sent raw with %s", enc)
+			t.Fail()
+		}
+		if values["bLevelID"] != utLowLevelID {
+			t.Errorf("bonus level not decoded as string with %s", enc)
+			t.Fail()
+		}
+	}
+}
+
+func benchmarkEncoding(b *testing.B, enc Encoding) {
+	bLevel := NewBonusLevel(utLowLevelID, 1, 1)
+	blindBundle, _ := crypt.CreateBlindBundle(bLevel.ActionVariants[ActionBooking].PublicKey)
+	request := MsgRequestBlindSignature{BLevelID: utLowLevelID, Token: crypt.GenerateToken(),
+		BlindToken: blindBundle.BlindToken, Action: ActionBooking}
+	response := MsgResponseSystemInfo{Data: MsgDataSystemInfo{BLevels: []*BonusLevel{bLevel.CopyPublic()}}}
+	for id := range GetDefaultFlightList() {
+		response.Data.Flights = append(response.Data.Flights, &Flight{ID: id, Bookings: []*Booking{}})
+	}
+
+	body, _ := enc.Marshal(request)
+	respBody, _ := enc.Marshal(response)
+	b.Logf("%s: blind signature request %d bytes, system information %d bytes", enc, len(body), len(respBody))
+	b.SetBytes(int64(len(body) + len(respBody)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var values map[string]interface{}
+		var msg MsgResponseSystemInfo
+		body, _ = enc.Marshal(request)
+		_ = enc.Unmarshal(body, &values)
+		respBody, _ = enc.Marshal(response)
+		_ = enc.Unmarshal(respBody, &msg)
+	}
+}
+
+func BenchmarkEncoding_JSON(b *testing.B) {
+	benchmarkEncoding(b, EncodingJSON)
+}
+
+func BenchmarkEncoding_Msgpack(b *testing.B) {
+	benchmarkEncoding(b, EncodingMsgpack)
+}
+
+func BenchmarkEncoding_CBOR(b *testing.B) {
+	benchmarkEncoding(b, EncodingCBOR)
+}
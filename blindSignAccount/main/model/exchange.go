@@ -0,0 +1,185 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"errors"
+	"github.com/cryptoballot/rsablind"
+	"strconv"
+	"time"
+)
+
+// Codes are exchanged like they are transferred: the client blinds a new Token for a code of the higher
+// bonus level, the server spends the codes of the lower level and blindly signs the Token. The client
+// gets the new code for the signature like for a booking, so it cannot be linked to the spent codes.
+
+// Returns the default exchange rates: five codes of a level for a code of the next higher level
+func DefaultExchangeRates() []config.ExchangeRate {
+	return []config.ExchangeRate{
+		{From: "low", To: "middle", Points: 5},
+		{From: "middle", To: "high", Points: 5},
+	}
+}
+
+// Sets the exchange rates of the bonus levels. All bonus levels of the rates have to exist.
+// The caller has to hold a write lock of the server.
+func (s *Server) setExchangeRates(rates []config.ExchangeRate) error {
+	for _, rate := range rates {
+		if s.BonusList[rate.From] == nil || s.BonusList[rate.To] == nil {
+			return errors.New("exchange rate from '" + rate.From + "' to '" + rate.To + "' has an unknown bonus level")
+		}
+		if rate.From == rate.To || rate.Points < 1 {
+			return errors.New("exchange rate from '" + rate.From + "' to '" + rate.To + "' is not valid")
+		}
+	}
+	for _, bLevel := range s.BonusList {
+		bLevel.ExchangeRates = nil
+	}
+	for _, rate := range rates {
+		to := s.BonusList[rate.To]
+		if to.ExchangeRates == nil {
+			to.ExchangeRates = map[string]int{}
+		}
+		to.ExchangeRates[rate.From] = rate.Points
+	}
+	return nil
+}
+
+// Spends codes of a bonus level and signs the blind Token for a code of a higher level worth one point.
+// The codes have to be worth the points of the exchange rate. Returns the blind signature.
+func (s *Server) ExchangeCodes(codes []string, fromID, toID string, blindToken []byte) (string, error) {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	to := s.getBonusLevel(toID)
+	if to == nil {
+		return "", errors.New("no level known with given id")
+	}
+	points, found := to.ExchangeRates[fromID]
+	if !found {
+		return "", errors.New("no exchange from '" + fromID + "' to '" + toID + "'")
+	}
+	if len(blindToken) == 0 {
+		return "", errors.New("blind token is empty")
+	}
+	bCodes, err := s.takeCodes(codes, fromID, points)
+	if err != nil {
+		return "", err
+	}
+
+	// the codes are restored if the Token cannot be signed
+	var blindSig []byte
+	err = s.SignPool.Do(func() (err error) {
+		blindSig, err = rsablind.BlindSign(to.bookingKey(1), blindToken)
+		return
+	})
+	if err != nil {
+		for _, bCode := range bCodes {
+			s.restoreCode(bCode)
+		}
+		return "", err
+	}
+	for _, code := range codes {
+		s.revoke(code)
+	}
+	return base64.URLEncoding.EncodeToString(blindSig), nil
+}
+
+// Checks that the codes of the bonus level are worth the points together and marks them as used.
+// No code is used if one of them is not valid.
+func (s *Server) takeCodes(codes []string, bLevelID string, points int) ([]*BonusCode, error) {
+	s.muxCodes.Lock()
+	defer s.muxCodes.Unlock()
+
+	bCodes := make([]*BonusCode, 0, len(codes))
+	known := map[string]bool{}
+	value := 0
+	for _, code := range codes {
+		if known[code] {
+			return nil, errors.New("code is used twice")
+		}
+		known[code] = true
+		bCode, err := s.checkCode(code, bLevelID)
+		if err != nil {
+			return nil, err
+		}
+		bCodes = append(bCodes, bCode)
+		value += bCode.Value()
+	}
+	if value != points {
+		return nil, errors.New("codes worth " + strconv.Itoa(value) + " points do not match the exchange rate of " +
+			strconv.Itoa(points) + " points")
+	}
+	for _, code := range codes {
+		s.BonusCodes[code] = nil
+	}
+	return bCodes, nil
+}
+
+// Exchanges codes of a bonus level for a code of a higher level by the published exchange rate.
+// The codes which are worth the points of the rate are replaced by the new code.
+func (c *Client) ExchangeCodes(fromID, toID string) (*BonusCode, error) {
+	to := c.BonusLevels[toID]
+	if to == nil {
+		return nil, errors.New("bonus level does not exist")
+	}
+	points, found := to.ExchangeRates[fromID]
+	if !found {
+		return nil, errors.New("no exchange from '" + fromID + "' to '" + toID + "'")
+	}
+	var candidates []*BonusCode
+	now := time.Now()
+	for _, bCode := range c.BonusCodes {
+		if bCode.ValidFor != nil && bCode.ValidFor.BonusID == fromID && bCode.isActive(now) {
+			candidates = append(candidates, bCode)
+		}
+	}
+	selected := selectMinimalOvershoot(candidates, points)
+	codes := make([]string, 0, len(selected))
+	value := 0
+	for _, bCode := range selected {
+		codes = append(codes, bCode.CodeID)
+		value += bCode.Value()
+	}
+	if value != points {
+		return nil, errors.New("no codes of '" + fromID + "' worth " + strconv.Itoa(points) + " points")
+	}
+
+	blindBundle, err := crypt.CreateBlindBundle(*to.BookingPublicKey(1))
+	if err != nil {
+		return nil, err
+	}
+	blindSigHex, err := c.con.ExchangeCodes(codes, fromID, toID, blindBundle.BlindToken)
+	if err != nil {
+		return nil, err
+	}
+	c.removeCodes(selected)
+	if blindBundle.BlindSig, err = base64.URLEncoding.DecodeString(blindSigHex); err != nil {
+		return nil, err
+	}
+	signature := rsablind.Unblind(to.BookingPublicKey(1), blindBundle.BlindSig, blindBundle.UnBlinder)
+
+	code, err := c.con.GetBookingCode(toID, 1, blindBundle.HashValue, signature)
+	if err != nil {
+		return nil, err
+	}
+	bCode := NewBonusCodeWithID(code, to)
+	c.BonusCodes = append(c.BonusCodes, bCode)
+	return bCode, nil
+}
+
+func (c *Client) removeCodes(spent []*BonusCode) {
+	isSpent := make(map[*BonusCode]bool, len(spent))
+	for _, bCode := range spent {
+		isSpent[bCode] = true
+	}
+	remaining := []*BonusCode{}
+	for _, bCode := range c.BonusCodes {
+		if !isSpent[bCode] {
+			remaining = append(remaining, bCode)
+		}
+	}
+	c.BonusCodes = remaining
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"github.com/cryptoballot/rsablind"
+	"testing"
+)
+
+func TestServer_setExchangeRates(t *testing.T) {
+	server := NewServer()
+
+	if server.BonusList[utMiddleLevelID].ExchangeRates[utLowLevelID] != 5 || len(server.BonusList[utLowLevelID].ExchangeRates) != 0 {
+		t.Fatal("wrong default exchange rates")
+	}
+	if err := server.setExchangeRates([]config.ExchangeRate{{From: "unknown", To: utHighLevelID, Points: 3}}); err == nil {
+		t.Error("exchange rate of an unknown bonus level set")
+	}
+	if err := server.setExchangeRates([]config.ExchangeRate{{From: utLowLevelID, To: utHighLevelID}}); err == nil {
+		t.Error("exchange rate without points set")
+	}
+	if err := server.setExchangeRates([]config.ExchangeRate{{From: utLowLevelID, To: utHighLevelID, Points: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.BonusList[utMiddleLevelID].ExchangeRates) != 0 || server.BonusList[utHighLevelID].ExchangeRates[utLowLevelID] != 10 {
+		t.Error("exchange rates not replaced")
+	}
+
+	// the rates are public
+	public := BonusLevelFromProto(BonusLevelToProto(server.BonusList[utHighLevelID].CopyPublic()))
+	if public.ExchangeRates[utLowLevelID] != 10 {
+		t.Error("exchange rates are not public")
+	}
+}
+
+func TestServer_ExchangeCodes(t *testing.T) {
+	server := NewServer()
+	middle := server.BonusList[utMiddleLevelID]
+	var codes []string
+	for i := 0; i < 5; i++ {
+		codes = append(codes, server.generateBonusCode(utLowLevelID, 1).CodeID)
+	}
+	blindBundle, _ := crypt.CreateBlindBundle(*middle.BookingPublicKey(1))
+
+	if _, err := server.ExchangeCodes(codes, utLowLevelID, utHighLevelID, blindBundle.BlindToken); err == nil {
+		t.Error("codes exchanged without an exchange rate")
+	}
+	if _, err := server.ExchangeCodes(codes[:4], utLowLevelID, utMiddleLevelID, blindBundle.BlindToken); err == nil {
+		t.Error("codes worth less than the rate exchanged")
+	}
+	if _, err := server.ExchangeCodes(append(codes[:4:4], codes[0]), utLowLevelID, utMiddleLevelID, blindBundle.BlindToken); err == nil {
+		t.Error("code exchanged twice")
+	}
+	if _, err := server.ExchangeCodes(append(codes[:4:4], "unknown"), utLowLevelID, utMiddleLevelID, blindBundle.BlindToken); err == nil {
+		t.Error("unknown code exchanged")
+	}
+	// rejected exchanges use no codes
+	blindSig, err := server.ExchangeCodes(codes, utLowLevelID, utMiddleLevelID, blindBundle.BlindToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = server.ExchangeCodes(codes, utLowLevelID, utMiddleLevelID, blindBundle.BlindToken); err == nil {
+		t.Error("codes exchanged twice")
+	}
+	filter, _ := server.SealRevocations()
+	if !filter.Contains(codes[0]) || !filter.Contains(codes[4]) {
+		t.Error("exchanged codes are not revoked")
+	}
+
+	rawSig, _ := base64.URLEncoding.DecodeString(blindSig)
+	signature := rsablind.Unblind(middle.BookingPublicKey(1), rawSig, blindBundle.UnBlinder)
+	code, err := server.GetBookingCode(utMiddleLevelID, 1, blindBundle.HashValue, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bCode := server.BonusCodes[code]; bCode == nil || bCode.ValidFor != middle {
+		t.Error("no code of the higher level received")
+	}
+
+	// one exchange gives one code of the higher level
+	if _, err = server.GetBookingCode(utMiddleLevelID, 1, blindBundle.HashValue, signature); err == nil {
+		t.Error("exchange signature redeemed twice")
+	}
+}
+
+func TestClient_ExchangeCodes(t *testing.T) {
+	client := setupClient(t)
+	if err := testLogin(client.con); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		if err := client.Booking(1, utLowFareClass); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := client.ExchangeCodes(utLowLevelID, utHighLevelID); err == nil {
+		t.Error("codes exchanged without an exchange rate")
+	}
+	if _, err := client.ExchangeCodes(utMiddleLevelID, utHighLevelID); err == nil || len(client.BonusCodes) != 6 {
+		t.Error("codes exchanged without codes of the lower level")
+	}
+	bCode, err := client.ExchangeCodes(utLowLevelID, utMiddleLevelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bCode.ValidFor != client.BonusLevels[utMiddleLevelID] || len(client.BonusCodes) != 2 {
+		t.Errorf("wrong codes after the exchange: %d", len(client.BonusCodes))
+	}
+	if _, err = client.ExchangeCodes(utLowLevelID, utMiddleLevelID); err == nil {
+		t.Error("codes exchanged without enough codes")
+	}
+}
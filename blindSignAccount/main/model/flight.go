@@ -1,36 +1,524 @@
 package model
 
-import "sync"
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// default fare classes of generated flights
+const (
+	FareEconomy  = "economy"
+	FarePremium  = "premium"
+	FareBusiness = "business"
+)
+
+// page sizes of the flight search
+const (
+	defaultFlightPageSize = 20
+	maxFlightPageSize     = 100
+)
+
+// format of the date of a flight search
+const FlightDateFormat = "2006-01-02"
 
 type Booking struct {
+	// unique across all flights
 	ID         int
 	CustomerID int
+	// the bonus level earned by the booking, nil if it earned no codes
 	BonusLevel *BonusLevel
+	FareClass  string
+	// one token per earned code, revoked by a cancellation
+	Tokens []*EarnedToken `json:"-"`
+}
+
+// Status of the codes of a booking
+const (
+	// the booking earned no codes
+	BookingCodeNone = "none"
+	// a blind signature was not requested with every token of the booking yet
+	BookingCodePending = "pending"
+	// a blind signature was issued for every token of the booking
+	BookingCodeIssued = "issued"
+)
+
+// The status of a booking, as seen by the customer of the booking. The server cannot link the
+// signed tokens to the codes: an issued code may be redeemed or not, which is not reported.
+type BookingStatus struct {
+	BookingID int    `json:"bookingID"`
+	FlightID  int    `json:"flightID"`
+	FareClass string `json:"fareClass"`
+	Status    string `json:"status"`
+	// the tokens which were not exchanged for a blind signature yet
+	PendingTokens []*EarnedToken `json:"pendingTokens"`
+}
+
+// A fare class of a flight with its seat capacity
+type FareClass struct {
+	Name   string
+	Seats  int
+	Booked int
 }
 
 type Flight struct {
-	ID       int
-	Bookings []*Booking
+	ID int
+	// e.g. BS100, the airline code is the prefix of the number if it is not set
+	Number  string
+	Airline string
+	// IATA codes of the airports
+	Origin      string
+	Destination string
+	Departure   time.Time
+	// the route distance in km, calculated for known airports if it is not set
+	Distance int
+	// the fare classes in order of preference of bookings without fare class
+	FareClasses []*FareClass
+	Bookings    []*Booking
 	// sync
 	mux sync.Mutex
 }
 
-// Generate a list of 100 default flights
+// airports of the generated flights
+var defaultAirports = []string{"FRA", "MUC", "BER", "HAM", "VIE", "ZRH", "CDG", "LHR", "AMS", "MAD"}
+
+// the airline of the generated flights
+const defaultAirline = "BS"
+
+// mean radius of the earth in km
+const earthRadius = 6371.0
+
+// Position of an airport in degrees
+type Airport struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// airports with known positions, distances of routes between them are calculated
+var Airports = map[string]Airport{
+	"FRA": {50.033, 8.570}, "MUC": {48.354, 11.786}, "BER": {52.366, 13.503}, "HAM": {53.630, 9.988},
+	"VIE": {48.110, 16.570}, "ZRH": {47.458, 8.548}, "CDG": {49.010, 2.548}, "LHR": {51.470, -0.454},
+	"AMS": {52.310, 4.768}, "MAD": {40.472, -3.561},
+}
+
+// Returns the great-circle distance of two known airports in km, 0 for unknown airports
+func RouteDistance(origin, destination string) int {
+	from, okFrom := Airports[strings.ToUpper(origin)]
+	to, okTo := Airports[strings.ToUpper(destination)]
+	if !okFrom || !okTo {
+		return 0
+	}
+	rad := math.Pi / 180
+	dLat := (to.Latitude - from.Latitude) * rad
+	dLon := (to.Longitude - from.Longitude) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(from.Latitude*rad)*math.Cos(to.Latitude*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return int(math.Round(2 * earthRadius * math.Asin(math.Sqrt(a))))
+}
+
+// Generate a list of 100 default flights. The flights depart every two hours
+// starting on the next day.
 func GetDefaultFlightList() map[int]*Flight {
 	flightList := map[int]*Flight{}
+	start := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
 	for i := 0; i < 100; i++ {
-		flightList[i] = &Flight{ID: i, Bookings: []*Booking{}}
+		origin := defaultAirports[i%len(defaultAirports)]
+		destination := defaultAirports[(i/len(defaultAirports)+i+1)%len(defaultAirports)]
+		if destination == origin {
+			destination = defaultAirports[(i+2)%len(defaultAirports)]
+		}
+		flightList[i] = &Flight{ID: i, Number: defaultAirline + strconv.Itoa(100+i), Airline: defaultAirline,
+			Origin: origin, Destination: destination, Distance: RouteDistance(origin, destination),
+			Departure: start.Add(time.Duration(i) * 2 * time.Hour),
+			FareClasses: []*FareClass{{Name: FareEconomy, Seats: 300}, {Name: FarePremium, Seats: 60},
+				{Name: FareBusiness, Seats: 30}},
+			Bookings: []*Booking{}}
 	}
 	return flightList
 }
 
-// Adds a new booking to a given flight
-func (flight *Flight) AddBooking(customerID int, bLevel *BonusLevel) {
+// Loads a list of flights from a JSON file. The file contains an array of flights.
+func LoadFlightList(fileName string) (map[int]*Flight, error) {
+	var flights []*Flight
+	rawVal, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(rawVal, &flights); err != nil {
+		return nil, err
+	}
+	flightList := map[int]*Flight{}
+	for _, flight := range flights {
+		if err = flight.Validate(); err != nil {
+			return nil, err
+		}
+		if flightList[flight.ID] != nil {
+			return nil, errors.New("flight with id " + strconv.Itoa(flight.ID) + " is defined twice")
+		}
+		flightList[flight.ID] = flight.copySchedule()
+	}
+	return flightList, nil
+}
+
+// Checks that the schedule of the flight is complete
+func (flight *Flight) Validate() error {
+	if flight.ID < 0 {
+		return errors.New("flight id must not be negative")
+	}
+	if flight.Origin == "" || flight.Destination == "" || flight.Origin == flight.Destination {
+		return errors.New("flight " + strconv.Itoa(flight.ID) + " needs different origin and destination")
+	}
+	if len(flight.FareClasses) == 0 {
+		return errors.New("flight " + strconv.Itoa(flight.ID) + " has no fare classes")
+	}
+	names := map[string]bool{}
+	for _, fareClass := range flight.FareClasses {
+		if fareClass == nil || fareClass.Name == "" || fareClass.Seats <= 0 || names[fareClass.Name] {
+			return errors.New("flight " + strconv.Itoa(flight.ID) + " has an invalid fare class")
+		}
+		names[fareClass.Name] = true
+	}
+	return nil
+}
+
+// Returns a copy of the schedule without bookings
+func (flight *Flight) copySchedule() *Flight {
+	flight.mux.Lock()
+	defer flight.mux.Unlock()
+	return flight.copyScheduleLocked(false)
+}
+
+// Returns a copy of the schedule, with the numbers of booked seats if requested.
+// The caller has to hold the lock of the flight.
+func (flight *Flight) copyScheduleLocked(withBooked bool) *Flight {
+	copyFlight := &Flight{ID: flight.ID, Number: flight.Number, Airline: flight.Airline, Origin: flight.Origin,
+		Destination: flight.Destination, Departure: flight.Departure, Distance: flight.Distance, Bookings: []*Booking{}}
+	if copyFlight.Airline == "" {
+		copyFlight.Airline = strings.TrimRightFunc(flight.Number, unicode.IsDigit)
+	}
+	if copyFlight.Distance == 0 {
+		copyFlight.Distance = RouteDistance(flight.Origin, flight.Destination)
+	}
+	for _, fareClass := range flight.FareClasses {
+		copyClass := &FareClass{Name: fareClass.Name, Seats: fareClass.Seats}
+		if withBooked {
+			copyClass.Booked = fareClass.Booked
+		}
+		copyFlight.FareClasses = append(copyFlight.FareClasses, copyClass)
+	}
+	return copyFlight
+}
+
+// Returns the number of free seats of all fare classes
+func (flight *Flight) FreeSeats() int {
+	flight.mux.Lock()
+	defer flight.mux.Unlock()
+	free := 0
+	for _, fareClass := range flight.FareClasses {
+		free += fareClass.Seats - fareClass.Booked
+	}
+	return free
+}
+
+// Adds a new booking to a given flight. The booking gets a seat of the first
+// fare class with free seats.
+func (flight *Flight) AddBooking(customerID int, bLevel *BonusLevel) (*Booking, error) {
+	return flight.AddBookingInClass(customerID, bLevel, "")
+}
+
+// Adds a new booking in the given fare class, any class if it is empty
+func (flight *Flight) AddBookingInClass(customerID int, bLevel *BonusLevel, fareClassName string) (*Booking, error) {
+	booking := &Booking{CustomerID: customerID, BonusLevel: bLevel}
+	if err := flight.addBooking(booking, fareClassName, flight.nextBookingID); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// Adds the booking with an id of the given source. The caller must not hold the lock of the flight.
+func (flight *Flight) addBooking(booking *Booking, fareClassName string, nextBookingID func() int) error {
 	// sync
 	flight.mux.Lock()
 	defer flight.mux.Unlock()
 
-	id := len(flight.Bookings) + 1
-	booking := &Booking{ID: id, CustomerID: customerID, BonusLevel: bLevel}
+	var fareClass *FareClass
+	for _, class := range flight.FareClasses {
+		if (fareClassName == "" || class.Name == fareClassName) && class.Booked < class.Seats {
+			fareClass = class
+			break
+		}
+	}
+	if fareClass == nil {
+		if fareClassName == "" {
+			return errors.New("flight " + strconv.Itoa(flight.ID) + " is fully booked")
+		}
+		return errors.New("no free seat in fare class '" + fareClassName + "' of flight " + strconv.Itoa(flight.ID))
+	}
+	fareClass.Booked++
+
+	booking.ID = nextBookingID()
+	booking.FareClass = fareClass.Name
 	flight.Bookings = append(flight.Bookings, booking)
+	return nil
+}
+
+// Returns the id after the ids of the flight's bookings. The ids of bookings of a server are
+// unique per server, see Server.nextBookingID. The caller has to hold the lock of the flight.
+func (flight *Flight) nextBookingID() int {
+	lastID := 0
+	for _, booking := range flight.Bookings {
+		if booking.ID > lastID {
+			lastID = booking.ID
+		}
+	}
+	return lastID + 1
+}
+
+// Returns the id of the customer of a booking, -1 if the booking does not exist
+func (flight *Flight) bookingCustomer(bookingID int) int {
+	// sync
+	flight.mux.Lock()
+	defer flight.mux.Unlock()
+
+	for _, booking := range flight.Bookings {
+		if booking.ID == bookingID {
+			return booking.CustomerID
+		}
+	}
+	return -1
+}
+
+// Returns the booking with the id, nil if the flight has no booking with the id
+func (flight *Flight) booking(bookingID int) *Booking {
+	// sync
+	flight.mux.Lock()
+	defer flight.mux.Unlock()
+
+	for _, booking := range flight.Bookings {
+		if booking.ID == bookingID {
+			return booking
+		}
+	}
+	return nil
+}
+
+// Removes a booking and frees its seat. Returns nil if the flight has no booking with the id.
+func (flight *Flight) removeBooking(bookingID int) *Booking {
+	// sync
+	flight.mux.Lock()
+	defer flight.mux.Unlock()
+
+	for i, booking := range flight.Bookings {
+		if booking.ID != bookingID {
+			continue
+		}
+		flight.Bookings = append(flight.Bookings[:i], flight.Bookings[i+1:]...)
+		for _, fareClass := range flight.FareClasses {
+			if fareClass.Name == booking.FareClass && fareClass.Booked > 0 {
+				fareClass.Booked--
+			}
+		}
+		return booking
+	}
+	return nil
+}
+
+// Filters flights by route and departure date
+type FlightQuery struct {
+	// IATA codes, empty codes match all airports
+	Origin      string
+	Destination string
+	// the day of departure (UTC), the zero time matches all days
+	Date time.Time
+	// pages start at 1
+	Page     int
+	PageSize int
+}
+
+// A page of the flights which match a query
+type FlightPage struct {
+	Flights  []*Flight `json:"flights"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"pageSize"`
+	Total    int       `json:"total"`
+}
+
+func (query FlightQuery) matches(flight *Flight) bool {
+	if query.Origin != "" && !strings.EqualFold(query.Origin, flight.Origin) {
+		return false
+	}
+	if query.Destination != "" && !strings.EqualFold(query.Destination, flight.Destination) {
+		return false
+	}
+	if !query.Date.IsZero() {
+		day := query.Date.UTC().Truncate(24 * time.Hour)
+		departure := flight.Departure.UTC()
+		if departure.Before(day) || !departure.Before(day.Add(24*time.Hour)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the requested page of the flights which match the query, ordered by departure
+func (s *Server) SearchFlights(query FlightQuery) *FlightPage {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultFlightPageSize
+	}
+	if query.PageSize > maxFlightPageSize {
+		query.PageSize = maxFlightPageSize
+	}
+
+	matches := s.matchingFlights(query)
+	page := &FlightPage{Flights: []*Flight{}, Page: query.Page, PageSize: query.PageSize, Total: len(matches)}
+	if start := (query.Page - 1) * query.PageSize; start < len(matches) {
+		end := start + query.PageSize
+		if end > len(matches) {
+			end = len(matches)
+		}
+		page.Flights = matches[start:end]
+	}
+	return page
+}
+
+// Returns copies of all flights with the booked seats, ordered by departure
+func (s *Server) Flights() []*Flight {
+	return s.matchingFlights(FlightQuery{})
+}
+
+func (s *Server) matchingFlights(query FlightQuery) []*Flight {
+	// sync
+	s.Mux.RLock()
+	s.muxFlights.RLock()
+	matches := []*Flight{}
+	for _, flight := range s.flightMap {
+		flight.mux.Lock()
+		if query.matches(flight) {
+			matches = append(matches, flight.copyScheduleLocked(true))
+		}
+		flight.mux.Unlock()
+	}
+	s.muxFlights.RUnlock()
+	s.Mux.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Departure.Equal(matches[j].Departure) {
+			return matches[i].ID < matches[j].ID
+		}
+		return matches[i].Departure.Before(matches[j].Departure)
+	})
+	return matches
+}
+
+// Adds a flight to the catalogue or replaces the schedule of a known flight.
+// The bookings of a replaced flight are kept, so its capacity must not become too small.
+func (s *Server) AddFlight(flight *Flight) error {
+	if err := flight.Validate(); err != nil {
+		return err
+	}
+	newFlight := flight.copySchedule()
+
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	s.muxFlights.Lock()
+	defer s.muxFlights.Unlock()
+
+	oldFlight := s.flightMap[newFlight.ID]
+	if oldFlight == nil {
+		s.flightMap[newFlight.ID] = newFlight
+		return nil
+	}
+
+	// the known flight is updated in place, so concurrent bookings are not lost
+	oldFlight.mux.Lock()
+	defer oldFlight.mux.Unlock()
+	booked := map[string]int{}
+	for _, booking := range oldFlight.Bookings {
+		booked[booking.FareClass]++
+	}
+	for _, fareClass := range newFlight.FareClasses {
+		if booked[fareClass.Name] > fareClass.Seats {
+			return errors.New("fare class '" + fareClass.Name + "' has more bookings than seats")
+		}
+		fareClass.Booked = booked[fareClass.Name]
+		delete(booked, fareClass.Name)
+	}
+	if len(booked) > 0 {
+		return errors.New("booked fare classes must not be removed")
+	}
+	oldFlight.Number = newFlight.Number
+	oldFlight.Airline = newFlight.Airline
+	oldFlight.Distance = newFlight.Distance
+	oldFlight.Origin = newFlight.Origin
+	oldFlight.Destination = newFlight.Destination
+	oldFlight.Departure = newFlight.Departure
+	oldFlight.FareClasses = newFlight.FareClasses
+	return nil
+}
+
+// Returns the flight with the given id or nil. The caller has to hold a read lock of the server.
+func (s *Server) flight(flightID int) *Flight {
+	s.muxFlights.RLock()
+	defer s.muxFlights.RUnlock()
+	return s.flightMap[flightID]
+}
+
+// Removes a flight without bookings from the catalogue
+func (s *Server) RemoveFlight(flightID int) error {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	s.muxFlights.Lock()
+	defer s.muxFlights.Unlock()
+
+	flight := s.flightMap[flightID]
+	if flight == nil {
+		return errors.New("flight with id " + strconv.Itoa(flightID) + " does not exist")
+	}
+	flight.mux.Lock()
+	defer flight.mux.Unlock()
+	if len(flight.Bookings) > 0 {
+		return errors.New("flight with id " + strconv.Itoa(flightID) + " has bookings")
+	}
+	delete(s.flightMap, flightID)
+	return nil
+}
+
+// Replaces the flight catalogue by the flights of the file. The catalogue is kept by resets.
+func (s *Server) LoadFlights(fileName string) error {
+	flightList, err := LoadFlightList(fileName)
+	if err != nil {
+		return err
+	}
+	if len(flightList) == 0 {
+		return errors.New("no flights in " + fileName)
+	}
+
+	// sync
+	s.Mux.Lock()
+	defer s.Mux.Unlock()
+	s.flightFile = fileName
+	s.flightMap = flightList
+	return nil
+}
+
+// Returns the catalogue after a reset: the flights of the loaded file or the default flights
+func (s *Server) initialFlights() map[int]*Flight {
+	if s.flightFile != "" {
+		if flightList, err := LoadFlightList(s.flightFile); err == nil {
+			return flightList
+		}
+	}
+	return GetDefaultFlightList()
 }
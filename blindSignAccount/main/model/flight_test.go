@@ -1,18 +1,55 @@
 package model
 
-import "testing"
+import (
+	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/pb"
+	"github.com/golang/protobuf/proto"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
 
 func TestGetDefaultFlightList(t *testing.T) {
 	list := GetDefaultFlightList()
 	if len(list) == 0 {
 		t.Fail()
 	}
+	for _, flight := range list {
+		if err := flight.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFlightToProto(t *testing.T) {
+	flight := GetDefaultFlightList()[1]
+	flight.FareClasses[0].Booked = 3
+	expected := flight.copyScheduleLocked(true)
+
+	raw, err := proto.Marshal(FlightToProto(flight))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg pb.Flight
+	if err = proto.Unmarshal(raw, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if received := FlightFromProto(&msg); !reflect.DeepEqual(received, expected) {
+		t.Errorf("wrong flight %+v, expected %+v", received, expected)
+	}
+
+	// a flight without schedule keeps its zero departure
+	if received := FlightFromProto(FlightToProto(&Flight{ID: 7})); received.ID != 7 || !received.Departure.IsZero() {
+		t.Errorf("wrong flight without schedule %+v", received)
+	}
 }
 
 func TestFlight_AddBooking(t *testing.T) {
 	bLevelLow := NewBonusLevel(utLowLevelID, 4, 4)
 	bLevelHigh := NewBonusLevel("high", 4, 4)
-	flight := &Flight{ID: 1, Bookings: []*Booking{}}
+	flight := &Flight{ID: 1, FareClasses: []*FareClass{{Name: FareEconomy, Seats: 2}}, Bookings: []*Booking{}}
 	flight.AddBooking(2, bLevelLow)
 	flight.AddBooking(2, bLevelHigh)
 	if len(flight.Bookings) != 2 {
@@ -28,7 +65,7 @@ func TestFlight_AddBooking(t *testing.T) {
 		t.Fail()
 	}
 	s := NewServer()
-	if _, err := s.Booking(1, 2, "low"); err != nil {
+	if _, err := s.Booking(1, 2, utLowFareClass); err != nil {
 		t.Error(err)
 		t.Fail()
 	}
@@ -37,3 +74,264 @@ func TestFlight_AddBooking(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestFlight_AddBooking_Capacity(t *testing.T) {
+	bLevel := NewBonusLevel(utLowLevelID, 4, 4)
+	flight := &Flight{ID: 1, Bookings: []*Booking{},
+		FareClasses: []*FareClass{{Name: FareEconomy, Seats: 2}, {Name: FareBusiness, Seats: 1}}}
+	for i := 0; i < 3; i++ {
+		if _, err := flight.AddBooking(i, bLevel); err != nil {
+			t.Error(err)
+		}
+	}
+	if flight.Bookings[2].FareClass != FareBusiness || flight.FreeSeats() != 0 {
+		t.Error("bookings are not assigned to the fare classes in order")
+	}
+	if _, err := flight.AddBooking(3, bLevel); err == nil {
+		t.Error("fully booked flight accepted a booking")
+	}
+	if _, err := flight.AddBookingInClass(3, bLevel, FareEconomy); err == nil {
+		t.Error("fully booked fare class accepted a booking")
+	}
+	if len(flight.Bookings) != 3 {
+		t.Error("rejected bookings were stored")
+	}
+
+	// the server issues no token for a fully booked flight
+	s := NewServer()
+	if err := s.AddFlight(&Flight{ID: 1000, Origin: "FRA", Destination: "MUC",
+		FareClasses: []*FareClass{{Name: FareEconomy, Seats: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Booking(1000, 1, ""); err != nil {
+		t.Error(err)
+	}
+	if tokens, err := s.Booking(1000, 2, ""); err == nil || len(tokens) != 0 {
+		t.Error("booking of a fully booked flight returned a token")
+	}
+}
+
+func TestServer_BookFlight_IDs(t *testing.T) {
+	// bookings of a flight without server are numbered per flight
+	flight := &Flight{ID: 1, FareClasses: []*FareClass{{Name: FareEconomy, Seats: 2}}, Bookings: []*Booking{}}
+	first, _ := flight.AddBooking(1, nil)
+	second, _ := flight.AddBooking(1, nil)
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("wrong ids of the flight's bookings: %d, %d", first.ID, second.ID)
+	}
+
+	// the ids of a server start at its creation time, so a restarted server does not reuse them
+	start := time.Now().UnixNano() / int64(time.Millisecond)
+	s := NewServer()
+	_, id, err := s.BookFlight(1, 1, utLowFareClass)
+	if err != nil || int64(id) <= start {
+		t.Errorf("wrong id %d of the first booking: %v", id, err)
+	}
+
+	// the ids are not shared with other servers and not reused after a reset
+	other := NewServer()
+	other.lastBookingID = s.lastBookingID + 100
+	if _, _, err = other.BookFlight(1, 1, utLowFareClass); err != nil {
+		t.Fatal(err)
+	}
+	s.Reset()
+	if _, next, err := s.BookFlight(1, 1, utLowFareClass); err != nil || next != id+1 {
+		t.Errorf("wrong id %d of the booking after %d: %v", next, id, err)
+	}
+}
+
+func TestServer_SearchFlights(t *testing.T) {
+	s := NewServer()
+	all := s.SearchFlights(FlightQuery{PageSize: 1000})
+	if all.Total != 100 || len(all.Flights) != maxFlightPageSize || all.PageSize != maxFlightPageSize {
+		t.Errorf("wrong page of all flights: total %d, %d flights", all.Total, len(all.Flights))
+	}
+	for i := 1; i < len(all.Flights); i++ {
+		if all.Flights[i].Departure.Before(all.Flights[i-1].Departure) {
+			t.Fatal("flights are not ordered by departure")
+		}
+	}
+
+	// filter by route and date
+	first := all.Flights[0]
+	page := s.SearchFlights(FlightQuery{Origin: first.Origin, Destination: first.Destination, Date: first.Departure})
+	if page.Total == 0 || page.Flights[0].ID != first.ID {
+		t.Error("flight not found by its route and date")
+	}
+	for _, flight := range page.Flights {
+		if flight.Origin != first.Origin || flight.Destination != first.Destination {
+			t.Error("flight of another route found")
+		}
+	}
+	if page := s.SearchFlights(FlightQuery{Date: first.Departure.Add(-24 * time.Hour)}); page.Total != 0 {
+		t.Error("flights found before the first departure")
+	}
+
+	// pagination
+	page = s.SearchFlights(FlightQuery{Page: 5})
+	if page.Total != 100 || len(page.Flights) != defaultFlightPageSize || page.Flights[0].ID != all.Flights[80].ID {
+		t.Error("wrong last page")
+	}
+	if page := s.SearchFlights(FlightQuery{Page: 6}); len(page.Flights) != 0 || page.Total != 100 {
+		t.Error("page behind the last flight is not empty")
+	}
+
+	// results are copies
+	page.Flights[0].Origin = "XXX"
+	if s.SearchFlights(FlightQuery{Page: 5}).Flights[0].Origin == "XXX" {
+		t.Error("search returned a flight of the catalogue")
+	}
+}
+
+func TestServer_AddFlight_RemoveFlight(t *testing.T) {
+	s := NewServer()
+	if err := s.AddFlight(&Flight{ID: 1000, Origin: "FRA", Destination: "FRA",
+		FareClasses: []*FareClass{{Name: FareEconomy, Seats: 1}}}); err == nil {
+		t.Error("flight without route added")
+	}
+	if err := s.AddFlight(&Flight{ID: 1000, Origin: "FRA", Destination: "MUC"}); err == nil {
+		t.Error("flight without fare classes added")
+	}
+
+	// replacing a booked flight keeps its bookings
+	if _, err := s.Booking(1, 1, utLowFareClass); err != nil {
+		t.Fatal(err)
+	}
+	replacement := &Flight{ID: 1, Origin: "FRA", Destination: "VIE", FareClasses: []*FareClass{{Name: FareEconomy, Seats: 10}}}
+	if err := s.AddFlight(replacement); err != nil {
+		t.Error(err)
+	}
+	if s.flightMap[1].Destination != "VIE" || len(s.flightMap[1].Bookings) != 1 || s.flightMap[1].FreeSeats() != 9 {
+		t.Error("schedule of the booked flight was not replaced")
+	}
+	replacement.FareClasses = []*FareClass{{Name: FareBusiness, Seats: 10}}
+	if err := s.AddFlight(replacement); err == nil {
+		t.Error("booked fare class removed")
+	}
+
+	if err := s.RemoveFlight(1); err == nil {
+		t.Error("booked flight removed")
+	}
+	if err := s.RemoveFlight(2); err != nil || s.flightMap[2] != nil {
+		t.Error("flight not removed")
+	}
+	if err := s.RemoveFlight(2); err == nil {
+		t.Error("unknown flight removed")
+	}
+}
+
+func TestServer_LoadFlights(t *testing.T) {
+	file, err := ioutil.TempFile("", "flights")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	_, _ = file.WriteString(`[{"ID": 7, "Number": "BS7", "Origin": "FRA", "Destination": "MUC",
+		"Departure": "2030-01-02T10:00:00Z", "FareClasses": [{"Name": "economy", "Seats": 1, "Booked": 1}]}]`)
+	_ = file.Close()
+
+	s := NewServer()
+	if err = s.LoadFlights(file.Name()); err != nil {
+		t.Fatal(err)
+	}
+	date, _ := time.Parse(FlightDateFormat, "2030-01-02")
+	page := s.SearchFlights(FlightQuery{Origin: "fra", Date: date})
+	if page.Total != 1 || page.Flights[0].ID != 7 || page.Flights[0].FareClasses[0].Booked != 0 {
+		t.Error("flight of the file not loaded")
+	}
+	if _, err = s.Booking(7, 1, ""); err != nil {
+		t.Error(err)
+	}
+
+	// a reset restores the flights of the file
+	s.Reset()
+	if len(s.flightMap) != 1 || s.flightMap[7].FreeSeats() != 1 {
+		t.Error("reset did not restore the loaded flights")
+	}
+	if err = s.LoadFlights(file.Name() + ".missing"); err == nil {
+		t.Error("missing file loaded")
+	}
+}
+
+func TestServer_CancelBooking(t *testing.T) {
+	s := NewServer()
+	_, firstID, err := s.BookFlight(1, 1, utLowFareClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, bookingID, err := s.BookFlight(2, 1, utLowFareClass)
+	if err != nil || len(tokens) != 1 {
+		t.Fatal(err)
+	}
+	if bookingID == firstID {
+		t.Error("booking ids of different flights are equal")
+	}
+	free := s.flightMap[2].FreeSeats()
+
+	// bookings of other customers cannot be cancelled
+	if _, err = s.CancelBooking(2, bookingID); err == nil {
+		t.Error("booking of another customer cancelled")
+	}
+
+	// the unused token is revoked
+	codeMayExist, err := s.CancelBooking(1, bookingID)
+	if err != nil || codeMayExist {
+		t.Errorf("cancellation failed: %v, code may exist %v", err, codeMayExist)
+	}
+	if len(s.flightMap[2].Bookings) != 0 || s.flightMap[2].FreeSeats() != free+1 {
+		t.Error("booking not removed")
+	}
+	blindToken, _, _, _, _ := crypt.GetBlindSignatureTestData("test123456", s.BonusList[utLowLevelID].ActionVariants[ActionBooking].SkKey)
+	if _, err = s.GetBlindSignature(utLowLevelID, tokens[0].Token, blindToken, ActionBooking); err == nil {
+		t.Error("token of a cancelled booking signed")
+	}
+	if _, err = s.CancelBooking(1, bookingID); err == nil {
+		t.Error("booking cancelled twice")
+	}
+
+	// a signed token may have been exchanged for a code
+	tokens, bookingID, _ = s.BookFlight(2, 1, utLowFareClass)
+	if _, err = s.GetBlindSignature(utLowLevelID, tokens[0].Token, blindToken, ActionBooking); err != nil {
+		t.Fatal(err)
+	}
+	if codeMayExist, err = s.CancelBooking(1, bookingID); err != nil || !codeMayExist {
+		t.Error("cancellation of a signed booking does not report the code")
+	}
+	if len(s.flightMap[1].Bookings) != 1 {
+		t.Error("booking of another flight removed")
+	}
+}
+
+func TestServer_GetBookings(t *testing.T) {
+	s := NewServer()
+	signedTokens, signedID, _ := s.BookFlight(1, 1, utLowFareClass)
+	_, pendingID, _ := s.BookFlight(2, 1, utLowFareClass)
+	_, _, _ = s.BookFlight(2, 2, utLowFareClass)
+
+	blindToken, _, _, _, _ := crypt.GetBlindSignatureTestData("test123456", s.BonusList[utLowLevelID].ActionVariants[ActionBooking].SkKey)
+	if _, err := s.GetBlindSignature(utLowLevelID, signedTokens[0].Token, blindToken, ActionBooking); err != nil {
+		t.Fatal(err)
+	}
+
+	// bookings of other customers are not listed
+	bookings := s.GetBookings(1)
+	if len(bookings) != 2 || bookings[0].BookingID != signedID || bookings[1].BookingID != pendingID {
+		t.Fatalf("wrong bookings: %v", bookings)
+	}
+	if bookings[0].Status != BookingCodeIssued || len(bookings[0].PendingTokens) != 0 || bookings[0].FlightID != 1 {
+		t.Errorf("wrong status of a signed booking: %s", bookings[0].Status)
+	}
+	if bookings[1].Status != BookingCodePending || len(bookings[1].PendingTokens) != 1 || bookings[1].FareClass != utLowFareClass {
+		t.Errorf("wrong status of a pending booking: %s", bookings[1].Status)
+	}
+
+	if _, err := s.CancelBooking(1, pendingID); err != nil {
+		t.Fatal(err)
+	}
+	if bookings = s.GetBookings(1); len(bookings) != 1 {
+		t.Error("cancelled booking listed")
+	}
+	if bookings = s.GetBookings(3); len(bookings) != 0 {
+		t.Error("bookings of an unknown customer listed")
+	}
+}
@@ -0,0 +1,221 @@
+package model
+
+import (
+	"blindSignAccount/main/metrics"
+	"reflect"
+	"strings"
+)
+
+// Names of the metric families of the server
+const (
+	MetricRequests         = "bss_requests_total"
+	MetricMapReads         = "bss_map_reads_total"
+	MetricMapWrites        = "bss_map_writes_total"
+	MetricMapSize          = "bss_map_size"
+	MetricValidTokens      = "bss_valid_tokens"
+	MetricOutstandingCodes = "bss_outstanding_codes"
+	MetricHTTPRequests     = "bss_http_requests_total"
+	MetricHTTPDuration     = "bss_http_request_duration_seconds"
+	MetricSignQueueDepth   = "bss_sign_queue_depth"
+	MetricSignRejected     = "bss_sign_rejected_total"
+	MetricSignWait         = "bss_sign_wait_seconds"
+	MetricRateLimited      = "bss_rate_limited_total"
+)
+
+// prefix of the request counter fields, the rest of the name is the operation label
+const requestCounterPrefix = "CntReq"
+
+// Registers all metrics of the server. The request counters and the map statistics
+// are collected from the server on every scrape.
+func (s *Server) initMetrics() {
+	s.Metrics = metrics.NewRegistry()
+	s.Metrics.Register(MetricRequests, "Requests per protocol operation since the last reset.",
+		metrics.KindCounter, s.collectRequests)
+	s.Metrics.Register(MetricMapReads, "Reads of the maps of the bonus action variants.",
+		metrics.KindCounter, func() []metrics.Sample { return s.collectMapStatistic(MetricMapReads) })
+	s.Metrics.Register(MetricMapWrites, "Writes of the maps of the bonus action variants.",
+		metrics.KindCounter, func() []metrics.Sample { return s.collectMapStatistic(MetricMapWrites) })
+	s.Metrics.Register(MetricMapSize, "Number of entries of the maps of the bonus action variants.",
+		metrics.KindGauge, func() []metrics.Sample { return s.collectMapStatistic(MetricMapSize) })
+	s.Metrics.Register(MetricValidTokens, "Issued tokens which are not used yet.",
+		metrics.KindGauge, s.collectValidTokens)
+	s.Metrics.Register(MetricOutstandingCodes, "Bonus codes which are not redeemed yet.",
+		metrics.KindGauge, s.collectOutstandingCodes)
+	s.HTTPRequests = s.Metrics.NewCounterVec(MetricHTTPRequests, "HTTP requests by route, method and status.",
+		"route", "method", "status")
+	s.HTTPDuration = s.Metrics.NewHistogramVec(MetricHTTPDuration, "HTTP request latencies in seconds.",
+		metrics.DefaultBuckets, "route", "method")
+	s.Metrics.Register(MetricSignQueueDepth, "Jobs waiting for a worker of the signing pool.",
+		metrics.KindGauge, func() []metrics.Sample { return []metrics.Sample{{Value: float64(s.signPool().Depth())}} })
+	s.Metrics.Register(MetricSignRejected, "Jobs rejected by the signing pool because its queue was full.",
+		metrics.KindCounter, func() []metrics.Sample {
+			return []metrics.Sample{{Value: float64(s.signPool().Rejected.Value())}}
+		})
+	s.SignWait = s.Metrics.NewHistogramVec(MetricSignWait, "Time jobs wait for a worker of the signing pool in seconds.",
+		metrics.DefaultBuckets)
+	s.RateLimited = s.Metrics.NewCounterVec(MetricRateLimited, "Requests rejected by the rate limits by route and limit.",
+		"route", "limit")
+}
+
+func (s *Server) signPool() *SignPool {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	return s.SignPool
+}
+
+// Returns all request counter fields of the server
+func (s *Server) requestCounters() map[string]*metrics.Counter {
+	counters := map[string]*metrics.Counter{}
+	value := reflect.ValueOf(s).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type == reflect.TypeOf(metrics.Counter(0)) && strings.HasPrefix(field.Name, requestCounterPrefix) {
+			counters[field.Name[len(requestCounterPrefix):]] = value.Field(i).Addr().Interface().(*metrics.Counter)
+		}
+	}
+	return counters
+}
+
+func (s *Server) resetRequestCounters() {
+	for _, counter := range s.requestCounters() {
+		counter.Reset()
+	}
+}
+
+func (s *Server) collectRequests() []metrics.Sample {
+	var samples []metrics.Sample
+	for operation, counter := range s.requestCounters() {
+		samples = append(samples, metrics.Sample{Labels: []metrics.Label{{Name: "operation", Value: operation}},
+			Value: float64(counter.Value())})
+	}
+	return samples
+}
+
+// Returns a snapshot of the bonus levels which is safe against a concurrent reset
+func (s *Server) bonusLevels() []*BonusLevel {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	return append([]*BonusLevel{}, s.Hierarchy...)
+}
+
+func variantLabels(bLevel *BonusLevel, variant *BonusActionVariant) []metrics.Label {
+	return []metrics.Label{{Name: "bonus_level", Value: bLevel.BonusID}, {Name: "variant", Value: variant.GetName()}}
+}
+
+func (s *Server) collectMapStatistic(name string) []metrics.Sample {
+	var samples []metrics.Sample
+	for _, bLevel := range s.bonusLevels() {
+		for _, variant := range bLevel.ActionVariants {
+			for _, stat := range variant.CopyStatistic() {
+				sample := metrics.Sample{Labels: append(variantLabels(bLevel, variant),
+					metrics.Label{Name: "map", Value: stat.Name})}
+				switch name {
+				case MetricMapReads:
+					sample.Value = float64(stat.NrReads)
+				case MetricMapWrites:
+					sample.Value = float64(stat.NrWrites)
+				default:
+					sample.Value = float64(stat.Length)
+				}
+				samples = append(samples, sample)
+			}
+		}
+	}
+	return samples
+}
+
+func (s *Server) collectValidTokens() []metrics.Sample {
+	var samples []metrics.Sample
+	for _, bLevel := range s.bonusLevels() {
+		for _, variant := range bLevel.ActionVariants {
+			valid := 0
+			variant.MuxValidTokens.Lock()
+			for _, used := range variant.ValidTokens {
+				if !used {
+					valid++
+				}
+			}
+			variant.MuxValidTokens.Unlock()
+			samples = append(samples, metrics.Sample{Labels: variantLabels(bLevel, variant), Value: float64(valid)})
+		}
+	}
+	return samples
+}
+
+func (s *Server) collectOutstandingCodes() []metrics.Sample {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	s.muxCodes.Lock()
+	defer s.muxCodes.Unlock()
+	outstanding := 0
+	for _, bCode := range s.BonusCodes {
+		// redeemed codes are set to nil
+		if bCode != nil {
+			outstanding++
+		}
+	}
+	return []metrics.Sample{{Value: float64(outstanding)}}
+}
+
+// Returns a statistical summary for all action variants of
+// the server's bonus levels. The summary is built from the metric registry.
+func (s *Server) GetStatisticSummary() *StatisticSummary {
+	stat := &StatisticSummary{BLevelToSummary: make(map[string][]StatisticSummaryTuple)}
+
+	summary := reflect.ValueOf(stat).Elem()
+	for _, sample := range s.Metrics.Gather(MetricRequests) {
+		if field := summary.FieldByName(requestCounterPrefix + sample.Labels[0].Value); field.IsValid() {
+			field.SetInt(int64(sample.Value))
+		}
+	}
+
+	if depth, ok := s.Metrics.Value(MetricSignQueueDepth); ok {
+		stat.SignQueueDepth = int(depth)
+	}
+	if rejected, ok := s.Metrics.Value(MetricSignRejected); ok {
+		stat.SignRejected = int(rejected)
+	}
+	for _, sample := range s.Metrics.Gather(MetricRateLimited) {
+		stat.RateLimited += int(sample.Value)
+	}
+	for _, sample := range s.Metrics.Gather(MetricSignWait) {
+		switch sample.Suffix {
+		case "_count":
+			stat.SignWaits = int(sample.Value)
+		case "_sum":
+			stat.SignWaitSeconds = sample.Value
+		}
+	}
+
+	// map statistics are indexed by bonus level, variant and map name
+	mapStatistic := map[string]*Statistic{}
+	for _, name := range []string{MetricMapReads, MetricMapWrites, MetricMapSize} {
+		for _, sample := range s.Metrics.Gather(name) {
+			key := sample.Labels[0].Value + "/" + sample.Labels[1].Value + "/" + sample.Labels[2].Value
+			if mapStatistic[key] == nil {
+				mapStatistic[key] = &Statistic{Name: sample.Labels[2].Value}
+			}
+			switch name {
+			case MetricMapReads:
+				mapStatistic[key].NrReads = int(sample.Value)
+			case MetricMapWrites:
+				mapStatistic[key].NrWrites = int(sample.Value)
+			default:
+				mapStatistic[key].Length = int(sample.Value)
+			}
+		}
+	}
+	for _, bLevel := range s.bonusLevels() {
+		for _, variant := range bLevel.ActionVariants {
+			tuple := StatisticSummaryTuple{BonusActionVariant: variant.GetName(), Statistic: NewStatisticArray()}
+			for idx := range tuple.Statistic {
+				key := bLevel.BonusID + "/" + variant.GetName() + "/" + StatName(idx).String()
+				if mapStatistic[key] != nil {
+					tuple.Statistic[idx] = mapStatistic[key]
+				}
+			}
+			stat.BLevelToSummary[bLevel.BonusID] = append(stat.BLevelToSummary[bLevel.BonusID], tuple)
+		}
+	}
+	return stat
+}
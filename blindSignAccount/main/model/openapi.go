@@ -0,0 +1,263 @@
+package model
+
+import (
+	"math/big"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const openAPISpecVersion = "3.0.0"
+
+// Describes a single operation of the versioned api.
+// Request and Response hold zero values of the message types which are
+// sent and received by the RestConnection.
+type APIOperation struct {
+	Path     RoutePath
+	Method   string
+	Summary  string
+	Request  interface{}
+	Response interface{}
+}
+
+// All operations which are served by the versioned route groups. Every route of a
+// versioned group has to be listed, the tests of the routes check it.
+var APIOperations = []APIOperation{
+	{PathSendBooking, http.MethodPost, "Sends a booking of the logged in customer and receives tokens for blind signatures",
+		MsgRequestSendBooking{}, MsgResponseSendBooking{}},
+	{PathLastAdrBdl, http.MethodPost, "Returns the last address and account id of an address update",
+		MsgRequestLastAdrBdl{}, MsgResponseLastAdrBdl{}},
+	{PathGetBookingCode, http.MethodPost, "Exchanges a signed hash value for a bonus code",
+		MsgRequestGetBookingCode{}, MsgResponseGetBookingCode{}},
+	{PathGetSystemInformation, http.MethodGet, "Returns the flights and the public bonus levels",
+		nil, MsgResponseSystemInfo{}},
+	{PathBlindSignature, http.MethodPost, "Signs a blind token if the given token is valid",
+		MsgRequestBlindSignature{}, MsgResponseBlindSignature{}},
+	{PathSetAddress, http.MethodPost, "Updates the address of a bonus level access",
+		MsgRequestSetAdr{}, MsgResponseSetAdr{}},
+	{PathAccessBonusSystem, http.MethodPost, "Redeems codes for accessing the bonus system",
+		MsgRequestAccessBS{}, MsgResponseAccessBS{}},
+	{PathParticipate, http.MethodPost, "Requests participation data",
+		MsgRequestParticipate{}, MsgResponseParticipate{}},
+	{PathCanBesUsedForRecovery, http.MethodPost, "Checks if an address can be used for recovery",
+		MsgRequestRecStatus{}, MsgResponseRecStatus{}},
+	{PathRecoveryTest, http.MethodPost, "Recovers the tokens of an address",
+		MsgRequestRecoveryTest{}, MsgResponseRecoveryTest{}},
+	{PathRegister, http.MethodGet, "Registers a new client",
+		nil, MsgResponseRegister{}},
+	{PathExit, http.MethodPost, "Shuts the server down",
+		MsgRequestExit{}, MsgResponseExit{}},
+	{PathStatistic, http.MethodGet, "Returns a statistical summary of the server",
+		nil, MsgResponseStatistic{}},
+	{PathDebugInfos, http.MethodGet, "Returns the complete server state",
+		nil, MsgResponseDebugInfo{}},
+	{PathReset, http.MethodGet, "Resets the server state",
+		nil, MsgResponseReset{}},
+	{PathCoinDenominations, http.MethodGet, "Returns the public keys of the coin denominations",
+		nil, MsgResponseCoinDenominations{}},
+	{PathWithdrawCoins, http.MethodPost, "Signs blind coins worth the points of a booking Token",
+		MsgRequestWithdrawCoins{}, MsgResponseWithdrawCoins{}},
+	{PathSpendCoins, http.MethodPost, "Spends coins for a reward and signs the change",
+		MsgRequestSpendCoins{}, MsgResponseSpendCoins{}},
+	{PathTransferCode, http.MethodPost, "Spends a code and signs the blind token of its receiver",
+		MsgRequestTransferCode{}, MsgResponseTransferCode{}},
+	{PathExchangeCodes, http.MethodPost, "Spends codes of a bonus level and signs the blind token of a higher code",
+		MsgRequestExchangeCodes{}, MsgResponseExchangeCodes{}},
+	{PathFlightSearch, http.MethodGet, "Searches the flight catalogue by origin, destination and date",
+		nil, MsgResponseFlightSearch{}},
+	{PathCancelBooking, http.MethodPost, "Cancels a booking of the logged in customer and revokes its unused tokens",
+		MsgRequestCancelBooking{}, MsgResponseCancelBooking{}},
+	{PathCustomerRegister, http.MethodPost, "Creates a customer account",
+		MsgRequestCustomer{}, MsgResponseCustomer{}},
+	{PathCustomerLogin, http.MethodPost, "Logs a customer in and returns the session",
+		MsgRequestCustomer{}, MsgResponseCustomer{}},
+	{PathCustomerLogout, http.MethodPost, "Ends the session of the logged in customer",
+		nil, MsgResponseCustomer{}},
+	{PathCustomerBookings, http.MethodGet, "Lists the bookings of the logged in customer with the status of their codes",
+		nil, MsgResponseBookings{}},
+	{PathPartnerKeys, http.MethodGet, "Returns the hex encoded voucher keys by bonus level ids",
+		nil, MsgResponsePartnerKeys{}},
+	{PathPartnerRedeem, http.MethodPost, "Redeems a voucher for the partner of the partner key header",
+		MsgRequestRedeemVoucher{}, MsgResponseRedeemVoucher{}},
+	{PathPartnerRevocations, http.MethodGet, "Returns the signed revocation filters of the epochs after the query parameter 'since'",
+		nil, MsgResponseRevocations{}},
+}
+
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Servers    []OpenAPIServer                         `json:"servers"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components OpenAPIComponents                       `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+type OpenAPIOperation struct {
+	Summary     string                      `json:"summary"`
+	OperationID string                      `json:"operationId"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// Generates the OpenAPI document of the given api version from the message types
+// of all api operations
+func NewOpenAPIDocument(version APIVersion) *OpenAPIDocument {
+	doc := &OpenAPIDocument{OpenAPI: openAPISpecVersion,
+		Info:       OpenAPIInfo{Title: "blindSignServer", Version: version.String()},
+		Servers:    []OpenAPIServer{{URL: version.Prefix()}},
+		Paths:      map[string]map[string]*OpenAPIOperation{},
+		Components: OpenAPIComponents{Schemas: map[string]*OpenAPISchema{}},
+	}
+
+	for _, op := range APIOperations {
+		operation := &OpenAPIOperation{Summary: op.Summary,
+			OperationID: strings.Trim(strings.Replace(op.Path.String(), "/", "_", -1), "_"),
+			Responses: map[string]*OpenAPIResponse{
+				"default": {Description: "message envelope; err is set in case of failure",
+					Content: mediaContent(doc.schemaOf(reflect.TypeOf(op.Response)))},
+			},
+		}
+		if op.Request != nil {
+			operation.RequestBody = &OpenAPIRequestBody{Required: true,
+				Content: mediaContent(doc.schemaOf(reflect.TypeOf(op.Request)))}
+		}
+		path := op.Path.String()
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*OpenAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = operation
+	}
+	return doc
+}
+
+// All encodings share the schema of the message type
+func mediaContent(schema *OpenAPISchema) map[string]*OpenAPIMediaType {
+	content := map[string]*OpenAPIMediaType{}
+	for enc := Encoding(EncodingJSON); enc.IsValid(); enc++ {
+		content[enc.ContentType()] = &OpenAPIMediaType{Schema: schema}
+	}
+	return content
+}
+
+// Returns the schema of a go type. Named structs are added to the components
+// of the document and referenced.
+func (doc *OpenAPIDocument) schemaOf(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		if t == reflect.TypeOf(&big.Int{}) {
+			return &OpenAPISchema{Type: "integer"}
+		}
+		t = t.Elem()
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case reflect.TypeOf(big.Int{}):
+		return &OpenAPISchema{Type: "integer"}
+	case reflect.TypeOf(HexBytes{}):
+		// raw bytes for binary encodings
+		return &OpenAPISchema{Type: "string", Format: "hex"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json sends byte slices base64 encoded
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: doc.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: doc.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return doc.structSchema(t)
+		}
+		if _, found := doc.Components.Schemas[t.Name()]; !found {
+			// register the name first: types can be recursive
+			doc.Components.Schemas[t.Name()] = &OpenAPISchema{}
+			*doc.Components.Schemas[t.Name()] = *doc.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + t.Name()}
+	}
+	// interfaces and everything else can hold any value
+	return &OpenAPISchema{}
+}
+
+func (doc *OpenAPIDocument) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported fields are never sent
+			continue
+		}
+		name := field.Name
+		omitEmpty := false
+		if tag, found := field.Tag.Lookup("json"); found {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, option := range parts[1:] {
+				omitEmpty = omitEmpty || option == "omitempty"
+			}
+		}
+		schema.Properties[name] = doc.schemaOf(field.Type)
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	doc := NewOpenAPIDocument(APIVersion1)
+
+	if doc.Servers[0].URL != "/v1" || doc.Info.Version != "v1" {
+		t.Error("wrong version information")
+		t.Fail()
+	}
+	for _, op := range APIOperations {
+		operation := doc.Paths[op.Path.String()][strings.ToLower(op.Method)]
+		if operation == nil {
+			t.Error("missing operation for " + op.Path.String())
+			t.FailNow()
+		}
+		if (op.Request == nil) != (operation.RequestBody == nil) {
+			t.Error("wrong request body for " + op.Path.String())
+			t.Fail()
+		}
+	}
+
+	// every route path has to be documented
+	for path := RoutePath(PathSendBooking); path.String() != "unknown path"; path++ {
+		if doc.Paths[path.String()] == nil {
+			t.Error("path not documented: " + path.String())
+			t.Fail()
+		}
+	}
+
+	// request schemas have to be generated from the message types
+	schema := doc.Components.Schemas["MsgRequestSendBooking"]
+	if schema == nil {
+		t.Error("request schema not generated")
+		t.FailNow()
+	}
+	if strings.Join(schema.Required, ",") != "flightID" {
+		t.Errorf("wrong required properties: %v", schema.Required)
+		t.Fail()
+	}
+	if schema.Properties["flightID"].Type != "integer" || schema.Properties["fareClass"].Type != "string" {
+		t.Error("wrong property types")
+		t.Fail()
+	}
+
+	// recursive types are referenced
+	bLevel := doc.Components.Schemas["BonusLevel"]
+	if bLevel == nil || bLevel.Properties["LowerLevels"].Items.Ref != "#/components/schemas/BonusLevel" {
+		t.Error("recursive type not referenced")
+		t.Fail()
+	}
+
+	// all references have to be resolvable
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	for _, part := range strings.Split(string(raw), `"$ref":"#/components/schemas/`)[1:] {
+		name := part[:strings.Index(part, `"`)]
+		if doc.Components.Schemas[name] == nil {
+			t.Error("unresolved reference " + name)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"crypto/subtle"
+	"errors"
+	"golang.org/x/crypto/ed25519"
+	"time"
+)
+
+// The redemption of a voucher by a partner
+type Redemption struct {
+	VoucherID  string
+	Partner    string
+	RedeemedAt time.Time
+}
+
+// Returns the configured partner names by their keys, partners without name or key are ignored
+func configuredPartners() map[string]string {
+	partners := map[string]string{}
+	for _, partner := range config.GetConfigPartners() {
+		if partner.Name != "" && partner.Key != "" {
+			partners[partner.Key] = partner.Name
+		}
+	}
+	return partners
+}
+
+// Replaces the partners. Every partner needs a name and a key of its own.
+// The partners are kept by resets.
+func (s *Server) SetPartners(partners []config.Partner) error {
+	byKey := make(map[string]string, len(partners))
+	for _, partner := range partners {
+		if partner.Name == "" || partner.Key == "" {
+			return errors.New("partner needs a name and a key")
+		}
+		if _, found := byKey[partner.Key]; found {
+			return errors.New("key of partner '" + partner.Name + "' is used by another partner")
+		}
+		byKey[partner.Key] = partner.Name
+	}
+
+	// sync
+	s.Mux.Lock()
+	defer s.Mux.Unlock()
+	s.partners = byKey
+	return nil
+}
+
+// Returns the name of the partner with the key
+func (s *Server) AuthenticatePartner(key string) (string, error) {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	// compare all keys in constant time, so the time does not leak a key
+	var name string
+	for partnerKey, partnerName := range s.partners {
+		if subtle.ConstantTimeCompare([]byte(partnerKey), []byte(key)) == 1 {
+			name = partnerName
+		}
+	}
+	if name == "" {
+		return "", errors.New("partner key is not valid")
+	}
+	return name, nil
+}
+
+// Returns the published voucher keys by bonus level ids
+func (s *Server) VoucherKeys() map[string]ed25519.PublicKey {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	keys := make(map[string]ed25519.PublicKey, len(s.BonusList))
+	for bLevelID, bLevel := range s.BonusList {
+		keys[bLevelID] = bLevel.VoucherPublicKey
+	}
+	return keys
+}
+
+// Redeems a voucher for a partner. The voucher has to be signed by its bonus level and
+// must neither be expired nor redeemed before.
+func (s *Server) RedeemVoucher(partner string, voucher *Voucher) (*Redemption, error) {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	bLevel := s.getBonusLevel(voucher.BLevelID)
+	if bLevel == nil {
+		return nil, errors.New("no level known with given id")
+	}
+	if err := voucher.Verify(bLevel.VoucherPublicKey); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if voucher.IsExpired(now) {
+		return nil, errors.New("voucher is expired")
+	}
+
+	s.muxRedemptions.Lock()
+	defer s.muxRedemptions.Unlock()
+	if redemption := s.redemptions[voucher.ID]; redemption != nil {
+		return nil, errors.New("voucher was redeemed by '" + redemption.Partner + "' already")
+	}
+	redemption := &Redemption{VoucherID: voucher.ID, Partner: partner, RedeemedAt: now.UTC()}
+	s.redemptions[voucher.ID] = redemption
+	s.revoke(voucher.ID)
+	return redemption, nil
+}
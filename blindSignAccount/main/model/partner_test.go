@@ -0,0 +1,103 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"golang.org/x/crypto/ed25519"
+	"testing"
+	"time"
+)
+
+// Configures a partner for the test, the partners are removed by the cleanup
+func setupPartner(t *testing.T, name, key string) *Server {
+	config.SetConfigPartners([]config.Partner{{Name: name, Key: key}, {Name: "", Key: "no name"}})
+	t.Cleanup(func() { config.SetConfigPartners(nil) })
+	return NewServer()
+}
+
+func TestServer_AuthenticatePartner(t *testing.T) {
+	server := setupPartner(t, "lounge", "lounge-key")
+
+	if partner, err := server.AuthenticatePartner("lounge-key"); err != nil || partner != "lounge" {
+		t.Errorf("partner not authenticated: %s %v", partner, err)
+	}
+	for _, key := range []string{"", "wrong-key", "no name"} {
+		if _, err := server.AuthenticatePartner(key); err == nil {
+			t.Errorf("partner authenticated by key '%s'", key)
+		}
+	}
+	// customer sessions are no partner keys
+	if _, err := server.RegisterCustomer(utCustomerName, utCustomerPassword); err != nil {
+		t.Fatal(err)
+	}
+	session, _, err := server.Login(utCustomerName, utCustomerPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = server.AuthenticatePartner(session); err == nil {
+		t.Error("partner authenticated by a customer session")
+	}
+}
+
+func TestServer_SetPartners(t *testing.T) {
+	server := setupServer()
+
+	for _, partners := range [][]config.Partner{{{Name: "", Key: "key"}}, {{Name: "lounge", Key: ""}},
+		{{Name: "lounge", Key: "key"}, {Name: "hotel", Key: "key"}}} {
+		if err := server.SetPartners(partners); err == nil {
+			t.Errorf("invalid partners set: %v", partners)
+		}
+	}
+	if err := server.SetPartners([]config.Partner{{Name: "lounge", Key: "lounge-key"}}); err != nil {
+		t.Fatal(err)
+	}
+	// the partners are kept by a reset
+	server.Reset()
+	if partner, err := server.AuthenticatePartner("lounge-key"); err != nil || partner != "lounge" {
+		t.Errorf("partner not authenticated after a reset: %s %v", partner, err)
+	}
+}
+
+func TestServer_RedeemVoucher(t *testing.T) {
+	server := setupPartner(t, "lounge", "lounge-key")
+	bLevel := server.BonusList[utHighLevelID]
+
+	voucher, err := bLevel.issueVoucher("lounge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the voucher is verified offline by the published key
+	if key := server.VoucherKeys()[utHighLevelID]; len(key) != ed25519.PublicKeySize || voucher.Verify(key) != nil {
+		t.Fatal("voucher not verified by the published key")
+	}
+
+	redemption, err := server.RedeemVoucher("lounge", voucher)
+	if err != nil || redemption.VoucherID != voucher.ID || redemption.Partner != "lounge" {
+		t.Fatalf("voucher not redeemed: %v %v", redemption, err)
+	}
+	if _, err = server.RedeemVoucher("hotel", voucher); err == nil {
+		t.Error("voucher redeemed twice")
+	}
+
+	tampered, _ := bLevel.issueVoucher("lounge")
+	tampered.RewardID = "upgrade"
+	if _, err = server.RedeemVoucher("lounge", tampered); err == nil {
+		t.Error("tampered voucher redeemed")
+	}
+	expired, _ := bLevel.issueVoucher("lounge")
+	expired.ValidUntil = time.Now().Add(-time.Hour)
+	expired.Signature = ed25519.Sign(bLevel.VoucherSkKey, expired.message())
+	if _, err = server.RedeemVoucher("lounge", expired); err == nil {
+		t.Error("expired voucher redeemed")
+	}
+	unknown, _ := bLevel.issueVoucher("lounge")
+	unknown.BLevelID = "unknown"
+	if _, err = server.RedeemVoucher("lounge", unknown); err == nil {
+		t.Error("voucher of an unknown bonus level redeemed")
+	}
+
+	// a reset forgets the redemptions, the vouchers are invalid by the new keys
+	server.Reset()
+	if _, err = server.RedeemVoucher("lounge", voucher); err == nil {
+		t.Error("voucher redeemed after a reset")
+	}
+}
@@ -0,0 +1,374 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/pb"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const MIMEProtobuf = "application/x-protobuf"
+
+// A connection which posts protocol buffer messages to the versioned routes
+type ProtoConnection struct {
+	netClient *http.Client
+	// the session of the logged in customer, sent with bookings only
+	session string
+}
+
+func NewProtoConnection() *ProtoConnection {
+	return &ProtoConnection{netClient: &http.Client{Timeout: time.Minute * 3}}
+}
+
+// Sends a request message to the given path and decodes the response message
+func (con *ProtoConnection) call(method string, path RoutePath, request, response proto.Message) error {
+	return con.callURL(method, ServerAddress+path.Versioned(APIVersion1), "", request, response)
+}
+
+// Like call, but sends the request to an url and authenticates a customer by the session
+// if it is not empty
+func (con *ProtoConnection) callURL(method, rawURL, session string, request, response proto.Message) error {
+	var body []byte
+	var err error
+	var resp *http.Response
+
+	if request != nil {
+		if body, err = proto.Marshal(request); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, rawURL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", MIMEProtobuf)
+	req.Header.Set("Accept", MIMEProtobuf)
+	if session != "" {
+		req.Header.Set(HeaderAuthorization, BearerPrefix+session)
+	}
+	if resp, err = con.netClient.Do(req); err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := ioutil.ReadAll(resp.Body)
+	if resp.Header.Get("Content-Type") != MIMEProtobuf {
+		return errors.New("no protobuf response (body:" + string(bodyBytes) + ")")
+	}
+	return proto.Unmarshal(bodyBytes, response)
+}
+
+func (con *ProtoConnection) GetSystemInformation() ([]*Flight, []*BonusLevel, error) {
+	var msg pb.SystemInfoResponse
+
+	if err := con.call(http.MethodGet, PathGetSystemInformation, nil, &msg); err != nil {
+		return nil, nil, err
+	}
+	if msg.Err != "" {
+		return nil, nil, errors.New(msg.Err)
+	}
+	flights := make([]*Flight, 0, len(msg.Flights))
+	for _, flight := range msg.Flights {
+		flights = append(flights, FlightFromProto(flight))
+	}
+	bLevels := make([]*BonusLevel, 0, len(msg.BLevels))
+	for _, bLevel := range msg.BLevels {
+		bLevels = append(bLevels, BonusLevelFromProto(bLevel))
+	}
+	return flights, bLevels, nil
+}
+
+// Sends the name and password of a customer to a customer route
+func (con *ProtoConnection) callCustomer(route, name, password string) (*pb.CustomerResponse, error) {
+	var msg pb.CustomerResponse
+
+	values := &pb.CustomerRequest{Name: name, Password: password}
+	if err := con.callURL(http.MethodPost, ServerAddress+APIVersion(APIVersion1).Prefix()+route, "", values, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	return &msg, nil
+}
+
+func (con *ProtoConnection) RegisterCustomer(name, password string) (customerID int, err error) {
+	var msg *pb.CustomerResponse
+	if msg, err = con.callCustomer(RoutePath(PathCustomerRegister).String(), name, password); err != nil {
+		return 0, err
+	}
+	return int(msg.CustomerId), nil
+}
+
+func (con *ProtoConnection) Login(name, password string) (customerID int, err error) {
+	var msg *pb.CustomerResponse
+	if msg, err = con.callCustomer(RoutePath(PathCustomerLogin).String(), name, password); err != nil {
+		return 0, err
+	}
+	con.session = msg.Session
+	return int(msg.CustomerId), nil
+}
+
+func (con *ProtoConnection) SendBooking(flightID int, fareClass string) ([]*EarnedToken, error) {
+	var msg pb.SendBookingResponse
+
+	if con.session == "" {
+		return nil, errors.New("customer is not logged in")
+	}
+	values := &pb.SendBookingRequest{FlightId: int64(flightID), FareClass: fareClass}
+	if err := con.callURL(http.MethodPost, ServerAddress+RoutePath(PathSendBooking).Versioned(APIVersion1), con.session, values, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	tokens := make([]*EarnedToken, 0, len(msg.Tokens))
+	for _, token := range msg.Tokens {
+		tokens = append(tokens, &EarnedToken{Token: token.Token, BLevelID: token.BLevelId, Points: int(token.Points)})
+	}
+	return tokens, nil
+}
+
+func (con *ProtoConnection) GetBookings() ([]*BookingStatus, error) {
+	var msg pb.BookingsResponse
+
+	if con.session == "" {
+		return nil, errors.New("customer is not logged in")
+	}
+	if err := con.callURL(http.MethodGet, ServerAddress+APIVersion(APIVersion1).Prefix()+RoutePath(PathCustomerBookings).String(), con.session, nil, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	bookings := make([]*BookingStatus, 0, len(msg.Bookings))
+	for _, booking := range msg.Bookings {
+		bookings = append(bookings, BookingStatusFromProto(booking))
+	}
+	return bookings, nil
+}
+
+func (con *ProtoConnection) GetBlindSignature(bLevelID, token string, blindToken []byte, action int) (string, error) {
+	var msg pb.BlindSignatureResponse
+
+	values := &pb.BlindSignatureRequest{BLevelId: bLevelID, Token: token, BlindToken: blindToken, Action: int32(action)}
+	if err := con.call(http.MethodPost, PathBlindSignature, values, &msg); err != nil {
+		return "", err
+	}
+	if msg.Err != "" {
+		return "", errors.New(msg.Err)
+	}
+	// the signature was sent raw
+	return base64.URLEncoding.EncodeToString(msg.BlindSignature), nil
+}
+
+func (con *ProtoConnection) GetBookingCode(bLevelID string, points int, hashValue, signature []byte) (string, error) {
+	var msg pb.BookingCodeResponse
+
+	values := &pb.BookingCodeRequest{BLevelId: bLevelID, HashValue: hashValue, Signature: signature, Points: int32(points)}
+	if err := con.call(http.MethodPost, PathGetBookingCode, values, &msg); err != nil {
+		return "", err
+	}
+	if msg.Err != "" {
+		return "", errors.New(msg.Err)
+	}
+	return msg.Code, nil
+}
+
+func (con *ProtoConnection) GetCoinDenominations() ([]*CoinDenomination, error) {
+	var msg pb.CoinDenominationsResponse
+
+	if err := con.call(http.MethodGet, PathCoinDenominations, nil, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	return CoinDenominationsFromProto(msg.Denominations), nil
+}
+
+func (con *ProtoConnection) WithdrawCoins(bLevelID, token string, blindCoins []*BlindCoin) (blindSigs []string, err error) {
+	var msg pb.WithdrawCoinsResponse
+
+	values := &pb.WithdrawCoinsRequest{BLevelId: bLevelID, Token: token, BlindCoins: BlindCoinsToProto(blindCoins)}
+	if err = con.call(http.MethodPost, PathWithdrawCoins, values, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	return msg.BlindSignatures, nil
+}
+
+func (con *ProtoConnection) SpendCoins(coins []*Coin, bLevelID, rewardID string, blindChange []*BlindCoin) (bonusData string, blindSigs []string, err error) {
+	var msg pb.SpendCoinsResponse
+
+	values := &pb.SpendCoinsRequest{Coins: CoinsToProto(coins), BLevelId: bLevelID, RewardId: rewardID,
+		BlindChange: BlindCoinsToProto(blindChange)}
+	if err = con.call(http.MethodPost, PathSpendCoins, values, &msg); err != nil {
+		return "", nil, err
+	}
+	if msg.Err != "" {
+		return "", nil, errors.New(msg.Err)
+	}
+	return msg.BonusData, msg.BlindSignatures, nil
+}
+
+func (con *ProtoConnection) TransferCode(code, bLevelID string, points int, blindToken []byte) (string, error) {
+	var msg pb.TransferCodeResponse
+
+	values := &pb.TransferCodeRequest{Code: code, BLevelId: bLevelID, Points: int32(points), BlindToken: blindToken}
+	if err := con.call(http.MethodPost, PathTransferCode, values, &msg); err != nil {
+		return "", err
+	}
+	if msg.Err != "" {
+		return "", errors.New(msg.Err)
+	}
+	// the signature was sent raw
+	return base64.URLEncoding.EncodeToString(msg.BlindSignature), nil
+}
+
+func (con *ProtoConnection) ExchangeCodes(codes []string, fromID, toID string, blindToken []byte) (string, error) {
+	var msg pb.ExchangeCodesResponse
+
+	values := &pb.ExchangeCodesRequest{Codes: codes, From: fromID, To: toID, BlindToken: blindToken}
+	if err := con.call(http.MethodPost, PathExchangeCodes, values, &msg); err != nil {
+		return "", err
+	}
+	if msg.Err != "" {
+		return "", errors.New(msg.Err)
+	}
+	// the signature was sent raw
+	return base64.URLEncoding.EncodeToString(msg.BlindSignature), nil
+}
+
+func (con *ProtoConnection) AccessBonusSystem(codes []string, adrBundle *crypt.AddressBundle) (tokens, recoveries map[string]string, err error) {
+	var msg pb.AccessBonusSystemResponse
+
+	values := &pb.AccessBonusSystemRequest{Codes: codes, AdrBundle: AdrBundleToProto(adrBundle)}
+	if err = con.call(http.MethodPost, PathAccessBonusSystem, values, &msg); err != nil {
+		return nil, nil, err
+	}
+	if msg.Err != "" {
+		return nil, nil, errors.New(msg.Err)
+	}
+	return msg.Tokens, msg.RecoveryTokens, nil
+}
+
+func (con *ProtoConnection) SetAddress(bLevelID string, hashValue, signature []byte, adrBundle *crypt.AddressBundle, action int, pkr string) (token, recovery string, err error) {
+	var msg pb.SetAddressResponse
+
+	values := &pb.SetAddressRequest{BLevelId: bLevelID, HashValue: hashValue, Signature: signature,
+		AdrBundle: AdrBundleToProto(adrBundle), Action: int32(action), Pkr: pkr}
+	if err = con.call(http.MethodPost, PathSetAddress, values, &msg); err != nil {
+		return "", "", err
+	}
+	if msg.Err != "" {
+		return "", "", errors.New(msg.Err)
+	}
+	return msg.Token, msg.RecoveryToken, nil
+}
+
+func (con *ProtoConnection) Participate(bLevelID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
+	return con.PerformAction(bLevelID, ActionParticipate, "", hashValue, signature, pkr)
+}
+
+func (con *ProtoConnection) PerformAction(bLevelID string, action int, rewardID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
+	var msg pb.ParticipateResponse
+
+	values := &pb.ParticipateRequest{BLevelId: bLevelID, HashValue: hashValue, Signature: signature, Pkr: pkr, Action: int32(action),
+		RewardId: rewardID}
+	if err = con.call(http.MethodPost, PathParticipate, values, &msg); err != nil {
+		return "", "", "", err
+	}
+	if msg.Err != "" {
+		return "", "", "", errors.New(msg.Err)
+	}
+	return msg.Token, msg.RecoveryToken, msg.BonusData, nil
+}
+
+func (con *ProtoConnection) CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.AddressBundle) (status RecoveryStatus, token string, err error) {
+	var msg pb.RecoveryStatusResponse
+
+	values := &pb.RecoveryStatusRequest{BLevelId: bLevelID, AdrBundle: AdrBundleToProto(adrBdl)}
+	if err = con.call(http.MethodPost, PathCanBesUsedForRecovery, values, &msg); err != nil {
+		return Failure, "", err
+	}
+	if msg.Err != "" {
+		return Failure, "", errors.New(msg.Err)
+	}
+	return RecoveryStatus(msg.RecoveryStatus), msg.Token, nil
+}
+
+func (con *ProtoConnection) RecoveryTest(bLevelID, recoveryToken, pkr string, adrBdl *crypt.AddressBundle) (token, foundRecoveryToken, bonusData string, err error) {
+	var msg pb.RecoveryTestResponse
+
+	values := &pb.RecoveryTestRequest{BLevelId: bLevelID, RecoveryToken: recoveryToken, Pkr: pkr,
+		AdrBundle: AdrBundleToProto(adrBdl)}
+	if err = con.call(http.MethodPost, PathRecoveryTest, values, &msg); err != nil {
+		return "", "", "", err
+	}
+	if msg.Err != "" {
+		return "", "", "", errors.New(msg.Err)
+	}
+	return msg.Token, msg.FoundRecoveryToken, msg.BonusData, nil
+}
+
+func (con *ProtoConnection) Register() (clientID int, err error) {
+	var msg pb.RegisterResponse
+
+	if err = con.call(http.MethodGet, PathRegister, nil, &msg); err != nil {
+		return -1, err
+	}
+	if msg.Err != "" {
+		return -1, errors.New(msg.Err)
+	}
+	return int(msg.ClientId), nil
+}
+
+func (con *ProtoConnection) Reset() error {
+	var msg pb.ResetResponse
+
+	if err := con.call(http.MethodGet, PathReset, nil, &msg); err != nil {
+		return err
+	}
+	if msg.Err != "" {
+		return errors.New(msg.Err)
+	}
+	return nil
+}
+
+func (con *ProtoConnection) GetDebugInfos() (s *Server, err error) {
+	var msg pb.DebugInfoResponse
+
+	if err = con.call(http.MethodGet, PathDebugInfos, nil, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	// the debug dump is sent as JSON
+	if err = json.Unmarshal(msg.ServerJson, &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (con *ProtoConnection) GetLastAdrBdl(seed []byte, bLevelID string) (adr string, accountID uint32, err error) {
+	var msg pb.LastAdrBdlResponse
+
+	values := &pb.LastAdrBdlRequest{BLevelId: bLevelID, Seed: seed}
+	if err = con.call(http.MethodPost, PathLastAdrBdl, values, &msg); err != nil {
+		return "", uint32(0), err
+	}
+	if msg.Err != "" {
+		return "", uint32(0), errors.New(msg.Err)
+	}
+	return msg.Address, msg.AccountId, nil
+}
@@ -0,0 +1,269 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"testing"
+)
+
+func testSetupForProtoTests() (connection *ProtoConnection, err error) {
+	connection = NewProtoConnection()
+
+	// check if server is up
+	if err = connection.Reset(); err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+func TestProtoConnection_GetSystemInformation(t *testing.T) {
+	var con *ProtoConnection
+	var err error
+	var flights []*Flight
+	var bLevels []*BonusLevel
+
+	if con, err = testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	if flights, bLevels, err = con.GetSystemInformation(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if len(flights) == 0 || len(bLevels) == 0 {
+		t.Errorf("missing flights (%d) or bonus levels (%d)", len(flights), len(bLevels))
+		t.Fail()
+	}
+	for _, bLevel := range bLevels {
+		for _, variant := range bLevel.ActionVariants {
+			if variant.PublicKey.N == nil || variant.PublicKey.N.Sign() == 0 || variant.PublicKey.E == 0 {
+				t.Errorf("missing public key of bonus level %s", bLevel.BonusID)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestProtoConnection_Register(t *testing.T) {
+	var con *ProtoConnection
+	var err error
+	var clientID, clientID2nd int
+
+	if con, err = testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	if clientID, err = con.Register(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if clientID2nd, err = con.Register(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if clientID2nd != clientID+1 {
+		t.Errorf("wrong client ids: %d, %d", clientID, clientID2nd)
+		t.Fail()
+	}
+}
+
+func TestProtoConnection_SendBooking(t *testing.T) {
+	var con *ProtoConnection
+	var err error
+	var tokens []*EarnedToken
+
+	if con, err = testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	if err := testLogin(con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if tokens, err = con.SendBooking(1, utHighFareClass); err != nil || len(tokens) != 1 || tokens[0].BLevelID != "high" {
+		t.Error(err)
+		t.Fail()
+	}
+	// unknown fare classes are reported by the err field
+	if _, err = con.SendBooking(1, "unknown"); err == nil {
+		t.Error("booking of unknown fare class accepted")
+		t.Fail()
+	}
+
+	bookings, err := con.GetBookings()
+	if err != nil || len(bookings) == 0 || bookings[len(bookings)-1].Status != BookingCodePending {
+		t.Errorf("no pending booking received: %v", err)
+	}
+}
+
+func TestProtoConnection_GetBlindSignature(t *testing.T) {
+	var con *ProtoConnection
+	var err error
+	var token, blindSignature string
+	var bLevels []*BonusLevel
+
+	if con, err = testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+	if _, bLevels, err = con.GetSystemInformation(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	var tokens []*EarnedToken
+	if err := testLogin(con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if tokens, err = con.SendBooking(1, utLowFareClass); err != nil || len(tokens) == 0 {
+		t.Error(err)
+		t.FailNow()
+	}
+	token = tokens[0].Token
+
+	var blindBundle *crypt.BlindBundle
+	for _, bLevel := range bLevels {
+		if bLevel.BonusID == utLowLevelID {
+			blindBundle, _ = crypt.CreateBlindBundle(bLevel.ActionVariants[ActionBooking].PublicKey)
+		}
+	}
+	if blindBundle == nil {
+		t.Error("bonus level " + utLowLevelID + " not found")
+		t.FailNow()
+	}
+	if blindSignature, err = con.GetBlindSignature(utLowLevelID, token, blindBundle.BlindToken, ActionBooking); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if blindSignature == "" {
+		t.Error("no blind signature received")
+		t.Fail()
+	}
+}
+
+func TestProtoConnection_GetDebugInfos(t *testing.T) {
+	var con *ProtoConnection
+	var err error
+	var server *Server
+
+	if con, err = testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+	if _, err = con.Register(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if server, err = con.GetDebugInfos(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if server == nil || server.CntReqRegister == 0 {
+		t.Error("no debug information received")
+		t.Fail()
+	}
+}
+
+func TestProtoConnection_ClientAccess(t *testing.T) {
+	if _, err := testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	client := NewClient(1, utMnemonic, 2)
+	client.con = NewProtoConnection()
+	if err := client.GetSystemInformation(); err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+	// create bookings
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	// try to access
+	if err := client.AccessBonusSystem(); err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+	if len(client.BLevelToTokens) != 2 {
+		t.Errorf("wrong number of accessed bonus levels: %d", len(client.BLevelToTokens))
+		t.Fail()
+	}
+}
+
+func TestProtoConnection_ClientBookingWithCoins(t *testing.T) {
+	if _, err := testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	client := NewClient(1, utMnemonic, 2)
+	client.con = NewProtoConnection()
+	if err := client.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(client.con); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.BookingWithCoins(1, utLowFareClass); err != nil {
+		t.Fatal(err)
+	}
+	if client.Balance() < 1 || len(client.coinDenominations) == 0 {
+		t.Errorf("wrong balance of %d points", client.Balance())
+	}
+}
+
+func TestProtoConnection_ClientRecovery(t *testing.T) {
+	var bData2nd string
+	var bDataRecovered map[string]string
+	var err error
+
+	if _, err = testSetupForProtoTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	client := NewClientWithAccountID(1, utMnemonic, 2, 2)
+	client.con = NewProtoConnection()
+	if err := client.GetSystemInformation(); err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+	// create bookings
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	// access bonus system 'low' and 'middle'
+	_ = client.AccessBonusSystem()
+
+	// participate with level low
+	_, _ = client.Participate(utLowLevelID)
+	if bData2nd, err = client.Participate(utLowLevelID); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// client forgets everything
+	testClientDeleteHistory(client)
+
+	// try to recover
+	if bDataRecovered, err = client.RestoreFromMnemonic(1, utMnemonic, 2); err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+	if bData2nd != bDataRecovered[utLowLevelID] {
+		t.Errorf("2nd bonus data differs. Exp: %s\t\tact: %s", bData2nd, bDataRecovered[utLowLevelID])
+		t.Fail()
+	}
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/pb"
+	"crypto/rsa"
+	"math/big"
+	"time"
+)
+
+// Converts public bonus level data into its protobuf message
+func BonusLevelToProto(bLevel *BonusLevel) *pb.BonusLevel {
+	msg := &pb.BonusLevel{BonusId: bLevel.BonusID, ValidDuration: int32(bLevel.ValidDuration),
+		MinPoints: int32(bLevel.MinPoints), VoucherPublicKey: bLevel.VoucherPublicKey,
+		MinActivationDelay: int64(bLevel.MinActivationDelay / time.Second), MaxActivationDelay: int64(bLevel.MaxActivationDelay / time.Second)}
+	for _, variant := range bLevel.ActionVariants {
+		pbVariant := &pb.ActionVariant{VariantId: int32(variant.VariantID), Name: variant.Name,
+			PublicKey: &pb.PublicKey{E: int64(variant.PublicKey.E)}}
+		if variant.PublicKey.N != nil {
+			pbVariant.PublicKey.N = variant.PublicKey.N.Bytes()
+		}
+		msg.ActionVariants = append(msg.ActionVariants, pbVariant)
+	}
+	for _, denomination := range bLevel.Denominations {
+		msg.Denominations = append(msg.Denominations, &pb.Denomination{Points: int32(denomination.Points),
+			PublicKey: &pb.PublicKey{N: denomination.PublicKey.N.Bytes(), E: int64(denomination.PublicKey.E)}})
+	}
+	for _, reward := range bLevel.Rewards {
+		msg.Rewards = append(msg.Rewards, &pb.Reward{Id: reward.ID, Description: reward.Description,
+			Value: int32(reward.Value), Currency: reward.Currency, ValidDays: int32(reward.ValidDays), Points: int32(reward.Points)})
+	}
+	for fromID, points := range bLevel.ExchangeRates {
+		if msg.ExchangeRates == nil {
+			msg.ExchangeRates = map[string]int32{}
+		}
+		msg.ExchangeRates[fromID] = int32(points)
+	}
+	for _, lLevel := range bLevel.LowerLevels {
+		msg.LowerLevels = append(msg.LowerLevels, BonusLevelToProto(lLevel))
+	}
+	return msg
+}
+
+// Converts a protobuf message into a public bonus level
+func BonusLevelFromProto(msg *pb.BonusLevel) *BonusLevel {
+	bLevel := &BonusLevel{BonusID: msg.BonusId, ValidDuration: int(msg.ValidDuration),
+		MinPoints: int(msg.MinPoints), VoucherPublicKey: msg.VoucherPublicKey, ActionVariants: make([]*BonusActionVariant, len(msg.ActionVariants)),
+		MinActivationDelay: time.Duration(msg.MinActivationDelay) * time.Second, MaxActivationDelay: time.Duration(msg.MaxActivationDelay) * time.Second}
+	for idx, pbVariant := range msg.ActionVariants {
+		variant := &BonusActionVariant{VariantID: int(pbVariant.VariantId), Name: pbVariant.Name}
+		if pbVariant.PublicKey != nil {
+			variant.PublicKey = rsa.PublicKey{N: new(big.Int).SetBytes(pbVariant.PublicKey.N), E: int(pbVariant.PublicKey.E)}
+		}
+		bLevel.ActionVariants[idx] = variant
+	}
+	for _, pbDenomination := range msg.Denominations {
+		denomination := &Denomination{Points: int(pbDenomination.Points)}
+		if pbDenomination.PublicKey != nil {
+			denomination.PublicKey = rsa.PublicKey{N: new(big.Int).SetBytes(pbDenomination.PublicKey.N), E: int(pbDenomination.PublicKey.E)}
+		}
+		bLevel.Denominations = append(bLevel.Denominations, denomination)
+	}
+	for _, pbReward := range msg.Rewards {
+		bLevel.Rewards = append(bLevel.Rewards, config.Reward{ID: pbReward.Id, Description: pbReward.Description,
+			Value: int(pbReward.Value), Currency: pbReward.Currency, ValidDays: int(pbReward.ValidDays), Points: int(pbReward.Points)})
+	}
+	for fromID, points := range msg.ExchangeRates {
+		if bLevel.ExchangeRates == nil {
+			bLevel.ExchangeRates = map[string]int{}
+		}
+		bLevel.ExchangeRates[fromID] = int(points)
+	}
+	for _, lLevel := range msg.LowerLevels {
+		bLevel.LowerLevels = append(bLevel.LowerLevels, BonusLevelFromProto(lLevel))
+	}
+	return bLevel
+}
+
+// Converts public coin denominations into their protobuf messages
+func CoinDenominationsToProto(denominations []*CoinDenomination) []*pb.CoinDenomination {
+	msgs := make([]*pb.CoinDenomination, 0, len(denominations))
+	for _, denomination := range denominations {
+		msgs = append(msgs, &pb.CoinDenomination{Value: int32(denomination.Value),
+			PublicKey: &pb.PublicKey{N: denomination.PublicKey.N.Bytes(), E: int64(denomination.PublicKey.E)}})
+	}
+	return msgs
+}
+
+func CoinDenominationsFromProto(msgs []*pb.CoinDenomination) []*CoinDenomination {
+	denominations := make([]*CoinDenomination, 0, len(msgs))
+	for _, msg := range msgs {
+		denomination := &CoinDenomination{Value: int(msg.Value)}
+		if msg.PublicKey != nil {
+			denomination.PublicKey = rsa.PublicKey{N: new(big.Int).SetBytes(msg.PublicKey.N), E: int(msg.PublicKey.E)}
+		}
+		denominations = append(denominations, denomination)
+	}
+	return denominations
+}
+
+func BlindCoinsToProto(blindCoins []*BlindCoin) []*pb.BlindCoin {
+	msgs := make([]*pb.BlindCoin, 0, len(blindCoins))
+	for _, blindCoin := range blindCoins {
+		msgs = append(msgs, &pb.BlindCoin{Value: int32(blindCoin.Value), BlindToken: blindCoin.BlindToken})
+	}
+	return msgs
+}
+
+func BlindCoinsFromProto(msgs []*pb.BlindCoin) []*BlindCoin {
+	blindCoins := make([]*BlindCoin, 0, len(msgs))
+	for _, msg := range msgs {
+		blindCoins = append(blindCoins, &BlindCoin{Value: int(msg.Value), BlindToken: msg.BlindToken})
+	}
+	return blindCoins
+}
+
+func CoinsToProto(coins []*Coin) []*pb.Coin {
+	msgs := make([]*pb.Coin, 0, len(coins))
+	for _, coin := range coins {
+		msgs = append(msgs, &pb.Coin{Value: int32(coin.Value), Serial: coin.Serial, Signature: coin.Signature})
+	}
+	return msgs
+}
+
+func CoinsFromProto(msgs []*pb.Coin) []*Coin {
+	coins := make([]*Coin, 0, len(msgs))
+	for _, msg := range msgs {
+		coins = append(coins, &Coin{Value: int(msg.Value), Serial: msg.Serial, Signature: msg.Signature})
+	}
+	return coins
+}
+
+func AdrBundleToProto(adrBundle *crypt.AddressBundle) *pb.AddressBundle {
+	return &pb.AddressBundle{Seed: adrBundle.Seed, AccountId: adrBundle.AccountID,
+		AddressId: adrBundle.AddressID, Address: adrBundle.Address}
+}
+
+func AdrBundleFromProto(msg *pb.AddressBundle) *crypt.AddressBundle {
+	if msg == nil {
+		return &crypt.AddressBundle{}
+	}
+	return &crypt.AddressBundle{Seed: msg.Seed, AccountID: msg.AccountId,
+		AddressID: msg.AddressId, Address: msg.Address}
+}
+
+func BookingStatusToProto(booking *BookingStatus) *pb.BookingStatus {
+	msg := &pb.BookingStatus{BookingId: int64(booking.BookingID), FlightId: int64(booking.FlightID),
+		FareClass: booking.FareClass, Status: booking.Status}
+	for _, token := range booking.PendingTokens {
+		msg.PendingTokens = append(msg.PendingTokens, &pb.EarnedToken{Token: token.Token, BLevelId: token.BLevelID, Points: int32(token.Points)})
+	}
+	return msg
+}
+
+func BookingStatusFromProto(msg *pb.BookingStatus) *BookingStatus {
+	booking := &BookingStatus{BookingID: int(msg.BookingId), FlightID: int(msg.FlightId),
+		FareClass: msg.FareClass, Status: msg.Status, PendingTokens: []*EarnedToken{}}
+	for _, token := range msg.PendingTokens {
+		booking.PendingTokens = append(booking.PendingTokens, &EarnedToken{Token: token.Token, BLevelID: token.BLevelId, Points: int(token.Points)})
+	}
+	return booking
+}
+
+// Converts the schedule of a flight into its protobuf message, the bookings are not sent
+func FlightToProto(flight *Flight) *pb.Flight {
+	msg := &pb.Flight{Id: int64(flight.ID), Number: flight.Number, Airline: flight.Airline, Origin: flight.Origin,
+		Destination: flight.Destination, Distance: int64(flight.Distance)}
+	if !flight.Departure.IsZero() {
+		msg.Departure = flight.Departure.Unix()
+	}
+	for _, fareClass := range flight.FareClasses {
+		msg.FareClasses = append(msg.FareClasses, &pb.FareClass{Name: fareClass.Name, Seats: int64(fareClass.Seats),
+			Booked: int64(fareClass.Booked)})
+	}
+	return msg
+}
+
+func FlightFromProto(msg *pb.Flight) *Flight {
+	flight := &Flight{ID: int(msg.Id), Number: msg.Number, Airline: msg.Airline, Origin: msg.Origin,
+		Destination: msg.Destination, Distance: int(msg.Distance), Bookings: []*Booking{}}
+	if msg.Departure != 0 {
+		flight.Departure = time.Unix(msg.Departure, 0).UTC()
+	}
+	for _, fareClass := range msg.FareClasses {
+		flight.FareClasses = append(flight.FareClasses, &FareClass{Name: fareClass.Name, Seats: int(fareClass.Seats),
+			Booked: int(fareClass.Booked)})
+	}
+	return flight
+}
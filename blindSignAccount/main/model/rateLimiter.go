@@ -0,0 +1,81 @@
+package model
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// number of requests after which idle buckets are removed
+const rateLimiterSweep = 1024
+
+// A token bucket rate limiter with one bucket per key. Every bucket holds up to
+// burst tokens and is refilled with rate tokens per second, a request takes one token.
+type RateLimiter struct {
+	mux     sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*tokenBucket
+	calls   int
+	// returns the current time, replaced by tests
+	now func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Creates a limiter which allows rate requests per second and bursts of burst requests.
+// A burst smaller than one allows one request.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{rate: rate, burst: float64(burst), buckets: map[string]*tokenBucket{}, now: time.Now}
+}
+
+// Takes a token from the bucket of the key. If the bucket is empty the request is
+// rejected and the time until the next token is returned.
+func (l *RateLimiter) Allow(key string) (allowed bool, retryAfter time.Duration) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := l.now()
+	if l.calls++; l.calls >= rateLimiterSweep {
+		l.sweep(now)
+	}
+	bucket := l.buckets[key]
+	if bucket == nil {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	if l.rate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+	return false, time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+}
+
+// Returns the number of keys with a bucket
+func (l *RateLimiter) Len() int {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return len(l.buckets)
+}
+
+// Removes all buckets which are full again, they behave like new ones
+func (l *RateLimiter) sweep(now time.Time) {
+	l.calls = 0
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+
+	// a burst of three requests is allowed
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.Allow("a"); !allowed {
+			t.Errorf("request %d rejected", i)
+			t.Fail()
+		}
+	}
+	allowed, retryAfter := limiter.Allow("a")
+	if allowed || retryAfter != 500*time.Millisecond {
+		t.Errorf("request not rejected (retry after %v)", retryAfter)
+		t.Fail()
+	}
+	// other keys have their own bucket
+	if allowed, _ = limiter.Allow("b"); !allowed {
+		t.Error("request of another key rejected")
+		t.Fail()
+	}
+
+	// two tokens per second are refilled
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		if allowed, _ = limiter.Allow("a"); !allowed {
+			t.Errorf("request %d after refill rejected", i)
+			t.Fail()
+		}
+	}
+	if allowed, _ = limiter.Allow("a"); allowed {
+		t.Error("bucket was refilled too much")
+		t.Fail()
+	}
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(1, 1)
+	limiter.now = func() time.Time { return now }
+	limiter.Allow("a")
+	limiter.Allow("b")
+
+	// full buckets are removed
+	now = now.Add(time.Second)
+	limiter.sweep(now)
+	if limiter.Len() != 0 {
+		t.Errorf("%d buckets not removed", limiter.Len())
+		t.Fail()
+	}
+}
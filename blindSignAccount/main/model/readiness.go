@@ -0,0 +1,99 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Names of the readiness checks
+const (
+	CheckKeys      = "keys"
+	CheckStorage   = "storage"
+	CheckSignQueue = "signQueue"
+)
+
+// time after which a blocked server state counts as unreachable
+const storageCheckTimeout = time.Second
+
+// The result of one readiness check
+type ReadinessCheck struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	Err   string `json:"err,omitempty"`
+}
+
+func newReadinessCheck(name string, err error) ReadinessCheck {
+	check := ReadinessCheck{Name: name, Ready: err == nil}
+	if err != nil {
+		check.Err = err.Error()
+	}
+	return check
+}
+
+// Reports whether the server can serve protocol requests: the keys of all bonus levels
+// are loaded, the server state is reachable and the signing pool accepts jobs.
+func (s *Server) Readiness() (ready bool, checks []ReadinessCheck) {
+	// the other checks need the server state
+	storageErr := s.checkStorage()
+	keysErr := errors.New("server state is unreachable")
+	signQueueErr := keysErr
+	if storageErr == nil {
+		keysErr = s.checkKeys()
+		signQueueErr = s.checkSignQueue()
+	}
+	checks = []ReadinessCheck{
+		newReadinessCheck(CheckStorage, storageErr),
+		newReadinessCheck(CheckKeys, keysErr),
+		newReadinessCheck(CheckSignQueue, signQueueErr),
+	}
+	ready = true
+	for _, check := range checks {
+		ready = ready && check.Ready
+	}
+	return
+}
+
+// The state is held in memory: it is reachable if its lock can be taken in time
+func (s *Server) checkStorage() error {
+	locked := make(chan bool, 1)
+	go func() {
+		s.Mux.RLock()
+		defer s.Mux.RUnlock()
+		locked <- s.BonusList != nil && s.BonusCodes != nil
+	}()
+	select {
+	case initialized := <-locked:
+		if !initialized {
+			return errors.New("server state is not initialized")
+		}
+		return nil
+	case <-time.After(storageCheckTimeout):
+		return errors.New("server state is locked")
+	}
+}
+
+func (s *Server) checkKeys() error {
+	bLevels := s.bonusLevels()
+	if len(bLevels) == 0 {
+		return errors.New("no bonus levels loaded")
+	}
+	for _, bLevel := range bLevels {
+		for _, variant := range bLevel.ActionVariants {
+			if variant == nil || variant.SkKey == nil || variant.PublicKey.N == nil {
+				return errors.New("keys of bonus level '" + bLevel.BonusID + "' are missing")
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) checkSignQueue() error {
+	pool := s.signPool()
+	if pool == nil {
+		return errors.New("no signing pool")
+	}
+	if pool.Depth() >= pool.QueueSize() && pool.QueueSize() > 0 {
+		return errors.New("signing queue is full")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package model
+
+import "testing"
+
+func TestServer_Readiness(t *testing.T) {
+	server := setupServer()
+	if ready, checks := server.Readiness(); !ready || len(checks) != 3 {
+		t.Errorf("new server not ready: %v", checks)
+		t.Fail()
+	}
+
+	// missing keys make the server unready
+	server.BonusList[utLowLevelID].ActionVariants[ActionBooking].SkKey = nil
+	ready, checks := server.Readiness()
+	for _, check := range checks {
+		if check.Name == CheckKeys && (check.Ready || check.Err == "") {
+			t.Error("missing keys not reported")
+			t.Fail()
+		}
+	}
+	if ready {
+		t.Error("server without keys is ready")
+		t.Fail()
+	}
+}
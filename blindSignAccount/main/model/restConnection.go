@@ -1,19 +1,25 @@
 package model
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/crypt"
 	"bytes"
-	"encoding/hex"
-	"encoding/json"
+	"encoding/base64"
 	"errors"
+	"golang.org/x/crypto/ed25519"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
+// Decodes the body of a response by its content type
 func readBody(response *http.Response, data interface{}) error {
 	bodyBytes, _ := ioutil.ReadAll(response.Body)
-	err := json.Unmarshal(bodyBytes, &data)
+	enc, _ := EncodingFromContentType(response.Header.Get("Content-Type"))
+	err := enc.Unmarshal(bodyBytes, data)
 	if err != nil {
 		err = errors.New(err.Error() + " (body:" + string(bodyBytes) + ")")
 	}
@@ -22,10 +28,167 @@ func readBody(response *http.Response, data interface{}) error {
 
 type RestConnection struct {
 	netClient *http.Client
+	// the api version used for all requests
+	version APIVersion
+	// the version is negotiated with the server before the first request
+	negotiated bool
+	mux        sync.Mutex
+	// the encoding of request and response bodies
+	encoding Encoding
+	// the id received by the registration, sent with the session only
+	clientID string
+	// the session of the logged in customer, sent with bookings only
+	session string
+	// counts the traffic of all requests
+	traffic *trafficTransport
 }
 
 func NewRestConnection() *RestConnection {
-	return &RestConnection{netClient: &http.Client{Timeout: time.Minute * 3}}
+	traffic := &trafficTransport{base: http.DefaultTransport}
+	return &RestConnection{netClient: &http.Client{Timeout: time.Minute * 3, Transport: traffic},
+		encoding: EncodingFromName(config.GetConfigEncoding()), traffic: traffic}
+}
+
+// Creates a rest connection which sends and accepts bodies with the given encoding
+func NewRestConnectionWithEncoding(encoding Encoding) *RestConnection {
+	con := NewRestConnection()
+	con.encoding = encoding
+	return con
+}
+
+// Returns the encoding of request and response bodies
+func (con *RestConnection) Encoding() Encoding {
+	return con.encoding
+}
+
+// Returns the traffic of all requests sent by the connection
+func (con *RestConnection) Traffic() Traffic {
+	return con.traffic.get()
+}
+
+// Creates a rest connection which uses the given api version without
+// negotiating it with the server
+func NewRestConnectionWithVersion(version APIVersion) *RestConnection {
+	con := NewRestConnection()
+	con.version = version
+	con.negotiated = true
+	return con
+}
+
+// Returns the api version which is used for requests
+func (con *RestConnection) APIVersion() APIVersion {
+	con.mux.Lock()
+	defer con.mux.Unlock()
+
+	if !con.negotiated {
+		con.negotiateVersion()
+	}
+	return con.version
+}
+
+// Chooses the newest api version which is supported by client and server.
+// Servers without version support are addressed by the legacy routes.
+func (con *RestConnection) negotiateVersion() {
+	var msg MsgResponseAPIVersions
+
+	resp, err := con.netClient.Get(ServerAddress + RouteAPIVersions)
+	if err != nil {
+		// the server is unreachable: try again with the next request
+		return
+	}
+	defer resp.Body.Close()
+	con.negotiated = true
+	con.version = APIVersionLegacy
+	if resp.StatusCode != http.StatusOK || readBody(resp, &msg) != nil {
+		return
+	}
+	for _, name := range msg.Data.Versions {
+		if version, known := ParseAPIVersion(name); known && version > con.version {
+			con.version = version
+		}
+	}
+}
+
+// Returns the url of a route under the negotiated api version
+func (con *RestConnection) url(path RoutePath) string {
+	return ServerAddress + path.Versioned(con.APIVersion())
+}
+
+// Sends a get request which accepts the connection's encoding
+func (con *RestConnection) get(path RoutePath) (*http.Response, error) {
+	return con.getURL(con.url(path))
+}
+
+func (con *RestConnection) getURL(rawURL string) (*http.Response, error) {
+	return con.getURLWithSession(rawURL, "")
+}
+
+func (con *RestConnection) getURLWithSession(rawURL, session string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", con.encoding.ContentType())
+	con.setSession(req, session)
+	return con.netClient.Do(req)
+}
+
+// Authenticates the logged in customer by the session, if any. The request identifies the
+// customer anyway, so the client is identified also: the server limits the requests per client.
+// Anonymous requests carry neither, see RoutePath.IdentifiesClient.
+func (con *RestConnection) setSession(req *http.Request, session string) {
+	if session == "" {
+		return
+	}
+	req.Header.Set(HeaderAuthorization, BearerPrefix+session)
+	con.mux.Lock()
+	defer con.mux.Unlock()
+	if con.clientID != "" {
+		req.Header.Set(HeaderClientID, con.clientID)
+	}
+}
+
+// Sends the values with the connection's encoding
+func (con *RestConnection) post(path RoutePath, values interface{}) (*http.Response, error) {
+	return con.postURL(con.url(path), values)
+}
+
+func (con *RestConnection) postURL(rawURL string, values interface{}) (*http.Response, error) {
+	return con.postURLWithSession(rawURL, values, "")
+}
+
+// Like postURL, but authenticates the logged in customer by the session
+func (con *RestConnection) postAsCustomer(rawURL string, values interface{}) (*http.Response, error) {
+	session, err := con.customerSession()
+	if err != nil {
+		return nil, err
+	}
+	return con.postURLWithSession(rawURL, values, session)
+}
+
+// Returns the session of the logged in customer
+func (con *RestConnection) customerSession() (string, error) {
+	con.mux.Lock()
+	defer con.mux.Unlock()
+	if con.session == "" {
+		return "", errors.New("customer is not logged in")
+	}
+	return con.session, nil
+}
+
+func (con *RestConnection) postURLWithSession(rawURL string, values interface{}, session string) (*http.Response, error) {
+	body, err := con.encoding.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, rawURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", con.encoding.ContentType())
+	req.Header.Set("Accept", con.encoding.ContentType())
+	con.setSession(req, session)
+	return con.netClient.Do(req)
 }
 
 func (con *RestConnection) GetSystemInformation() ([]*Flight, []*BonusLevel, error) {
@@ -33,7 +196,7 @@ func (con *RestConnection) GetSystemInformation() ([]*Flight, []*BonusLevel, err
 	var err error
 	var resp *http.Response
 
-	if resp, err = con.netClient.Get(ServerAddress + RoutePath(PathGetSystemInformation).String()); err != nil {
+	if resp, err = con.get(PathGetSystemInformation); err != nil {
 		return nil, nil, err
 	}
 	if err = readBody(resp, &msg); err != nil {
@@ -45,35 +208,182 @@ func (con *RestConnection) GetSystemInformation() ([]*Flight, []*BonusLevel, err
 	return msg.Data.Flights, msg.Data.BLevels, nil
 }
 
-func (con *RestConnection) SendBooking(customerID, flightID int, bLevelID string) (string, error) {
-	var msg MsgResponseSendBooking
+// Searches the flight catalogue, empty fields of the query match all flights
+func (con *RestConnection) SearchFlights(query FlightQuery) (*FlightPage, error) {
+	var msg MsgResponseFlightSearch
 	var err error
 	var resp *http.Response
 
-	values := map[string]interface{}{"customerID": customerID, "flightID": flightID, "bLevelID": bLevelID}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathSendBooking).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
-		return "", err
+	values := url.Values{}
+	for key, value := range map[string]string{"origin": query.Origin, "destination": query.Destination} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	if !query.Date.IsZero() {
+		values.Set("date", query.Date.Format(FlightDateFormat))
+	}
+	if query.Page > 0 {
+		values.Set("page", strconv.Itoa(query.Page))
+	}
+	if query.PageSize > 0 {
+		values.Set("pageSize", strconv.Itoa(query.PageSize))
+	}
+	if resp, err = con.getURL(ServerAddress + con.APIVersion().Prefix() + RoutePath(PathFlightSearch).String() + "?" + values.Encode()); err != nil {
+		return nil, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return nil, err
 	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	return &msg.Data, nil
+}
+
+// Sends the name and password of a customer to a customer route
+func (con *RestConnection) postCustomer(route, name, password string) (*MsgDataCustomer, error) {
+	var msg MsgResponseCustomer
 
+	values := MsgRequestCustomer{Name: name, Password: password}
+	resp, err := con.postURL(ServerAddress+con.APIVersion().Prefix()+route, values)
+	if err != nil {
+		return nil, err
+	}
 	if err = readBody(resp, &msg); err != nil {
-		return "", err
+		return nil, err
 	}
 	if msg.Err != "" {
-		return "", errors.New(msg.Err)
+		return nil, errors.New(msg.Err)
+	}
+	return &msg.Data, nil
+}
+
+func (con *RestConnection) RegisterCustomer(name, password string) (customerID int, err error) {
+	var data *MsgDataCustomer
+	if data, err = con.postCustomer(RoutePath(PathCustomerRegister).String(), name, password); err != nil {
+		return 0, err
+	}
+	return data.CustomerID, nil
+}
+
+func (con *RestConnection) Login(name, password string) (customerID int, err error) {
+	var data *MsgDataCustomer
+	if data, err = con.postCustomer(RoutePath(PathCustomerLogin).String(), name, password); err != nil {
+		return 0, err
+	}
+	con.mux.Lock()
+	con.session = data.Session
+	con.mux.Unlock()
+	return data.CustomerID, nil
+}
+
+// Ends the session of the logged in customer
+func (con *RestConnection) Logout() error {
+	var msg MsgResponseCustomer
+
+	resp, err := con.postAsCustomer(ServerAddress+con.APIVersion().Prefix()+RoutePath(PathCustomerLogout).String(), struct{}{})
+	if err != nil {
+		return err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return err
+	}
+	if msg.Err != "" {
+		return errors.New(msg.Err)
+	}
+	con.mux.Lock()
+	con.session = ""
+	con.mux.Unlock()
+	return nil
+}
+
+func (con *RestConnection) SendBooking(flightID int, fareClass string) ([]*EarnedToken, error) {
+	tokens, _, err := con.BookFlight(flightID, fareClass)
+	return tokens, err
+}
+
+// Sends a booking and returns the booking id also, which is needed for a cancellation
+func (con *RestConnection) BookFlight(flightID int, fareClass string) (tokens []*EarnedToken, bookingID int, err error) {
+	var msg MsgResponseSendBooking
+	var resp *http.Response
+
+	values := MsgRequestSendBooking{FlightID: flightID, FareClass: fareClass}
+	if resp, err = con.postAsCustomer(con.url(PathSendBooking), values); err != nil {
+		return nil, 0, err
+	}
+
+	if err = readBody(resp, &msg); err != nil {
+		return nil, 0, err
+	}
+	if msg.Err != "" {
+		return nil, 0, errors.New(msg.Err)
+	}
+	return msg.Data.Tokens, msg.Data.BookingID, nil
+}
+
+// Cancels a booking of the logged in customer. Reports whether a code may have been minted
+// with the booking token.
+func (con *RestConnection) CancelBooking(bookingID int) (codeMayExist bool, err error) {
+	var msg MsgResponseCancelBooking
+	var resp *http.Response
+
+	values := MsgRequestCancelBooking{BookingID: bookingID}
+	if resp, err = con.postAsCustomer(ServerAddress+con.APIVersion().Prefix()+RoutePath(PathCancelBooking).String(), values); err != nil {
+		return false, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return false, err
+	}
+	if msg.Err != "" {
+		return false, errors.New(msg.Err)
+	}
+	return msg.Data.CodeMayExist, nil
+}
+
+func (con *RestConnection) GetBookings() ([]*BookingStatus, error) {
+	var msg MsgResponseBookings
+
+	session, err := con.customerSession()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := con.getURLWithSession(ServerAddress+con.APIVersion().Prefix()+RoutePath(PathCustomerBookings).String(), session)
+	if err != nil {
+		return nil, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
 	}
-	return msg.Data.Token, nil
+	return msg.Data, nil
+}
+
+// Downloads the revocation filters of the epochs after the given epoch and the key of their signatures
+func (con *RestConnection) GetRevocations(sinceEpoch int) ([]*RevocationFilter, ed25519.PublicKey, error) {
+	var msg MsgResponseRevocations
+
+	resp, err := con.getURL(ServerAddress + con.APIVersion().Prefix() + RoutePath(PathPartnerRevocations).String() + "?since=" + strconv.Itoa(sinceEpoch))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return nil, nil, err
+	}
+	if msg.Err != "" {
+		return nil, nil, errors.New(msg.Err)
+	}
+	return msg.Data.Filters, ed25519.PublicKey(msg.Data.PublicKey), nil
 }
 
 func (con *RestConnection) GetBlindSignature(bLevelID, token string, blindToken []byte, action int) (string, error) {
 	var msg MsgResponseBlindSignature
 	var err error
 	var resp *http.Response
-	values := map[string]interface{}{"token": token, "blindToken": hex.EncodeToString(blindToken), "action": action, "bLevelID": bLevelID}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathBlindSignature).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestBlindSignature{Token: token, BlindToken: blindToken, Action: action, BLevelID: bLevelID}
+	if resp, err = con.post(PathBlindSignature, values); err != nil {
 		return "", err
 	}
 
@@ -84,18 +394,20 @@ func (con *RestConnection) GetBlindSignature(bLevelID, token string, blindToken
 		return "", errors.New(msg.Err)
 	}
 
+	if con.encoding.IsBinary() {
+		// the signature was sent raw
+		return base64.URLEncoding.EncodeToString([]byte(msg.Data.BlindSignature)), nil
+	}
 	return msg.Data.BlindSignature, nil
 }
 
-func (con *RestConnection) GetBookingCode(bLevelID string, hashValue, signature []byte) (string, error) {
+func (con *RestConnection) GetBookingCode(bLevelID string, points int, hashValue, signature []byte) (string, error) {
 	var msg MsgResponseGetBookingCode
 	var err error
 	var resp *http.Response
 
-	values := map[string]interface{}{"hashValue": hex.EncodeToString(hashValue), "signature": hex.EncodeToString(signature), "bLevelID": bLevelID}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathGetBookingCode).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestGetBookingCode{HashValue: hashValue, Signature: signature, BLevelID: bLevelID, Points: points}
+	if resp, err = con.post(PathGetBookingCode, values); err != nil {
 		return "", err
 	}
 
@@ -109,14 +421,98 @@ func (con *RestConnection) GetBookingCode(bLevelID string, hashValue, signature
 	return msg.Data.Code, nil
 }
 
+func (con *RestConnection) GetCoinDenominations() ([]*CoinDenomination, error) {
+	var msg MsgResponseCoinDenominations
+
+	resp, err := con.get(PathCoinDenominations)
+	if err != nil {
+		return nil, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	return msg.Data, nil
+}
+
+func (con *RestConnection) WithdrawCoins(bLevelID, token string, blindCoins []*BlindCoin) (blindSigs []string, err error) {
+	var msg MsgResponseWithdrawCoins
+	var resp *http.Response
+
+	values := MsgRequestWithdrawCoins{BLevelID: bLevelID, Token: token, BlindCoins: blindCoins}
+	if resp, err = con.post(PathWithdrawCoins, values); err != nil {
+		return nil, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Err != "" {
+		return nil, errors.New(msg.Err)
+	}
+	return msg.Data.BlindSignatures, nil
+}
+
+func (con *RestConnection) SpendCoins(coins []*Coin, bLevelID, rewardID string, blindChange []*BlindCoin) (bonusData string, blindSigs []string, err error) {
+	var msg MsgResponseSpendCoins
+	var resp *http.Response
+
+	values := MsgRequestSpendCoins{Coins: coins, BLevelID: bLevelID, RewardID: rewardID, BlindChange: blindChange}
+	if resp, err = con.post(PathSpendCoins, values); err != nil {
+		return "", nil, err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return "", nil, err
+	}
+	if msg.Err != "" {
+		return "", nil, errors.New(msg.Err)
+	}
+	return msg.Data.BonusData, msg.Data.BlindSignatures, nil
+}
+
+func (con *RestConnection) TransferCode(code, bLevelID string, points int, blindToken []byte) (string, error) {
+	var msg MsgResponseTransferCode
+	var err error
+	var resp *http.Response
+
+	values := MsgRequestTransferCode{Code: code, BLevelID: bLevelID, Points: points, BlindToken: blindToken}
+	if resp, err = con.post(PathTransferCode, values); err != nil {
+		return "", err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return "", err
+	}
+	if msg.Err != "" {
+		return "", errors.New(msg.Err)
+	}
+	return msg.Data.BlindSignature, nil
+}
+
+func (con *RestConnection) ExchangeCodes(codes []string, fromID, toID string, blindToken []byte) (string, error) {
+	var msg MsgResponseExchangeCodes
+	var err error
+	var resp *http.Response
+
+	values := MsgRequestExchangeCodes{Codes: codes, From: fromID, To: toID, BlindToken: blindToken}
+	if resp, err = con.post(PathExchangeCodes, values); err != nil {
+		return "", err
+	}
+	if err = readBody(resp, &msg); err != nil {
+		return "", err
+	}
+	if msg.Err != "" {
+		return "", errors.New(msg.Err)
+	}
+	return msg.Data.BlindSignature, nil
+}
+
 func (con *RestConnection) AccessBonusSystem(codes []string, adrBundle *crypt.AddressBundle) (tokens, recoveries map[string]string, err error) {
 	var msg MsgResponseAccessBS
 	var resp *http.Response
 
-	values := map[string]interface{}{"codes": codes, "adrBundle": encodeAdrBdl(adrBundle)}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathAccessBonusSystem).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestAccessBS{Codes: codes, AdrBundle: encodeAdrBdl(adrBundle)}
+	if resp, err = con.post(PathAccessBonusSystem, values); err != nil {
 		return nil, nil, err
 	}
 
@@ -134,11 +530,9 @@ func (con *RestConnection) SetAddress(bLevelID string, hashValue, signature []by
 	var msg MsgResponseSetAdr
 	var resp *http.Response
 
-	values := map[string]interface{}{"bLevelID": bLevelID, "hashValue": hex.EncodeToString(hashValue),
-		"signature": hex.EncodeToString(signature), "adrBundle": encodeAdrBdl(adrBundle), "action": action, "pkr": pkr}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathSetAddress).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestSetAdr{BLevelID: bLevelID, HashValue: hashValue,
+		Signature: signature, AdrBundle: encodeAdrBdl(adrBundle), Action: action, Pkr: pkr}
+	if resp, err = con.post(PathSetAddress, values); err != nil {
 		return "", "", err
 	}
 
@@ -153,13 +547,16 @@ func (con *RestConnection) SetAddress(bLevelID string, hashValue, signature []by
 }
 
 func (con *RestConnection) Participate(bLevelID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
+	return con.PerformAction(bLevelID, ActionParticipate, "", hashValue, signature, pkr)
+}
+
+func (con *RestConnection) PerformAction(bLevelID string, action int, rewardID string, hashValue, signature []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
 	var msg MsgResponseParticipate
 	var resp *http.Response
 
-	values := map[string]interface{}{"bLevelID": bLevelID, "hashValue": hex.EncodeToString(hashValue), "signature": hex.EncodeToString(signature), "pkr": pkr}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathParticipate).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestParticipate{BLevelID: bLevelID, HashValue: hashValue, Signature: signature, Pkr: pkr, Action: action,
+		RewardID: rewardID}
+	if resp, err = con.post(PathParticipate, values); err != nil {
 		return "", "", "", err
 	}
 
@@ -177,10 +574,8 @@ func (con *RestConnection) CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.A
 	var msg MsgResponseRecStatus
 	var resp *http.Response
 
-	values := map[string]interface{}{"bLevelID": bLevelID, "adrBundle": encodeAdrBdl(adrBdl)}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathCanBesUsedForRecovery).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestRecStatus{BLevelID: bLevelID, AdrBundle: encodeAdrBdl(adrBdl)}
+	if resp, err = con.post(PathCanBesUsedForRecovery, values); err != nil {
 		return Failure, "", err
 	}
 
@@ -198,10 +593,8 @@ func (con *RestConnection) RecoveryTest(bLevelID, recoveryToken, pkr string, adr
 	var msg MsgResponseRecoveryTest
 	var resp *http.Response
 
-	values := map[string]interface{}{"bLevelID": bLevelID, "recoveryToken": recoveryToken, "pkr": pkr, "adrBundle": encodeAdrBdl(adrBdl)}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathRecoveryTest).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestRecoveryTest{BLevelID: bLevelID, RecoveryToken: recoveryToken, Pkr: pkr, AdrBundle: encodeAdrBdl(adrBdl)}
+	if resp, err = con.post(PathRecoveryTest, values); err != nil {
 		return "", "", "", err
 	}
 
@@ -219,7 +612,7 @@ func (con *RestConnection) Register() (clientID int, err error) {
 	var msg MsgResponseRegister
 	var resp *http.Response
 
-	if resp, err = con.netClient.Get(ServerAddress + RoutePath(PathRegister).String()); err != nil {
+	if resp, err = con.get(PathRegister); err != nil {
 		return -1, err
 	}
 	if err := readBody(resp, &msg); err != nil {
@@ -228,25 +621,49 @@ func (con *RestConnection) Register() (clientID int, err error) {
 	if msg.Err != "" {
 		return -1, errors.New(msg.Err)
 	}
+	con.mux.Lock()
+	con.clientID = strconv.Itoa(msg.Data.ClientID)
+	con.mux.Unlock()
 	return msg.Data.ClientID, nil
 }
 
+// Checks that the server is ready without changing its state
+func (con *RestConnection) Ping() error {
+	var msg MsgResponseReadiness
+
+	resp, err := con.netClient.Get(ServerAddress + RouteReady)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err = readBody(resp, &msg); err != nil {
+		return err
+	}
+	if msg.Err != "" {
+		return errors.New(msg.Err)
+	}
+	if !msg.Data.Ready {
+		return errors.New("server is not ready")
+	}
+	return nil
+}
+
 func (con *RestConnection) Reset() error {
-	if _, err := con.netClient.Get(ServerAddress + RoutePath(PathReset).String()); err != nil {
+	if _, err := con.get(PathReset); err != nil {
 		return err
 	}
 	return nil
 }
 
 func encodeAdrBdl(adrBundle *crypt.AddressBundle) *MsgRequestAdrBundle {
-	return &MsgRequestAdrBundle{Seed: hex.EncodeToString(adrBundle.Seed), AddressID: adrBundle.AddressID,
+	return &MsgRequestAdrBundle{Seed: adrBundle.Seed, AddressID: adrBundle.AddressID,
 		AccountID: adrBundle.AccountID, Address: adrBundle.Address}
 }
 
 func (con *RestConnection) GetDebugInfos() (s *Server, err error) {
 	var msg MsgResponseDebugInfo
 	var resp *http.Response
-	if resp, err = con.netClient.Get(ServerAddress + RoutePath(PathDebugInfos).String()); err != nil {
+	if resp, err = con.get(PathDebugInfos); err != nil {
 		return nil, err
 	}
 	if err = readBody(resp, &msg); err != nil {
@@ -262,10 +679,8 @@ func (con *RestConnection) GetLastAdrBdl(seed []byte, bLevelID string) (adr stri
 	var msg MsgResponseLastAdrBdl
 	var resp *http.Response
 
-	values := map[string]interface{}{"bLevelID": bLevelID, "seed": hex.EncodeToString(seed)}
-	jsonValue, _ := json.Marshal(values)
-	if resp, err = con.netClient.Post(ServerAddress+RoutePath(PathLastAdrBdl).String(),
-		"application/json", bytes.NewBuffer(jsonValue)); err != nil {
+	values := MsgRequestLastAdrBdl{BLevelID: bLevelID, Seed: seed}
+	if resp, err = con.post(PathLastAdrBdl, values); err != nil {
 		return "", uint32(0), err
 	}
 
@@ -3,6 +3,8 @@ package model
 import (
 	"blindSignAccount/main/crypt"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -13,13 +15,21 @@ func testSetupForRestTests() (connection *RestConnection, err error) {
 	}
 
 	// check if server is up
-	if err = connection.Reset(); err != nil {
+	if err = connection.Ping(); err != nil {
 		return nil, err
 	}
 
 	return connection, nil
 }
 
+// Like testSetupForRestTests, the test starts with an empty server state
+func testSetupForRestTestsWithReset() (connection *RestConnection, err error) {
+	if connection, err = testSetupForRestTests(); err != nil {
+		return nil, err
+	}
+	return connection, connection.Reset()
+}
+
 func TestNewRestConnection(t *testing.T) {
 	con := NewRestConnection()
 	var err error
@@ -36,6 +46,70 @@ func TestNewRestConnection(t *testing.T) {
 	}
 }
 
+func TestRestConnection_APIVersion(t *testing.T) {
+	var con *RestConnection
+	var err error
+
+	if con, err = testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+	if con.APIVersion() != LatestAPIVersion {
+		t.Errorf("wrong negotiated version: %s", con.APIVersion())
+		t.Fail()
+	}
+
+	// a pinned legacy connection has to use the unversioned routes
+	con = NewRestConnectionWithVersion(APIVersionLegacy)
+	if _, _, err = con.GetSystemInformation(); err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+	if con.url(PathGetSystemInformation) != ServerAddress+"/system/info" {
+		t.Error("wrong legacy url: " + con.url(PathGetSystemInformation))
+		t.Fail()
+	}
+}
+
+func TestRestConnection_Encodings(t *testing.T) {
+	if _, err := testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	for _, enc := range []Encoding{EncodingMsgpack, EncodingCBOR} {
+		client := NewClient(1, utMnemonic, 2)
+		client.con = NewRestConnectionWithEncoding(enc)
+		if err := client.con.Reset(); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if err := client.GetSystemInformation(); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if err := testLogin(client.con); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		// booking needs public keys, blind signatures and hash values
+		for i := 0; i < 3; i++ {
+			if err := client.Booking(1, utMiddleFareClass); err != nil {
+				t.Errorf("%s: %s", enc, err)
+				t.FailNow()
+			}
+		}
+		if err := client.AccessBonusSystem(); err != nil {
+			t.Errorf("%s: %s", enc, err)
+			t.FailNow()
+		}
+		if _, err := client.Participate(utMiddleLevelID); err != nil {
+			t.Errorf("%s: %s", enc, err)
+			t.Fail()
+		}
+	}
+}
+
 func TestRestConnection_GetSystemInformation(t *testing.T) {
 	var con *RestConnection
 	var flights []*Flight
@@ -85,9 +159,26 @@ func TestRestConnection_Register(t *testing.T) {
 	}
 }
 
+func TestRestConnection_setSession(t *testing.T) {
+	con := NewRestConnection()
+	con.clientID = "1"
+
+	// anonymous requests are not linked to the client
+	req, _ := http.NewRequest(http.MethodGet, ServerAddress, nil)
+	con.setSession(req, "")
+	if req.Header.Get(HeaderClientID) != "" || req.Header.Get(HeaderAuthorization) != "" {
+		t.Errorf("anonymous request identified: %v", req.Header)
+	}
+	req, _ = http.NewRequest(http.MethodGet, ServerAddress, nil)
+	con.setSession(req, "session")
+	if req.Header.Get(HeaderClientID) != "1" || req.Header.Get(HeaderAuthorization) != BearerPrefix+"session" {
+		t.Errorf("customer request not identified: %v", req.Header)
+	}
+}
+
 func TestRestConnection_SendBooking(t *testing.T) {
 	var con *RestConnection
-	var token string
+	var tokens []*EarnedToken
 	var err error
 
 	if con, err = testSetupForRestTests(); err != nil {
@@ -96,11 +187,15 @@ func TestRestConnection_SendBooking(t *testing.T) {
 	}
 
 	// check if server is up
-	if token, err = con.SendBooking(1, 2, "low"); err != nil {
+	if err := testLogin(con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if tokens, err = con.SendBooking(2, utMiddleFareClass); err != nil {
 		t.Error(err)
 		t.Fail()
 	}
-	if token == "" {
+	if len(tokens) != 1 || tokens[0].Token == "" || tokens[0].BLevelID != utMiddleLevelID {
 		t.Error("no Token for booking received")
 		t.Fail()
 	}
@@ -120,7 +215,7 @@ func TestRestConnection_GetBookingCode(t *testing.T) {
 	// has to fail since no stupid hash and signature values are used
 	hash = []byte{1, 2}
 	signature = []byte{3, 4}
-	if bCode, err = con.GetBookingCode("low", hash, signature); err == nil {
+	if bCode, err = con.GetBookingCode("low", 1, hash, signature); err == nil {
 		t.Error("no error received")
 		t.FailNow()
 	}
@@ -312,7 +407,11 @@ func TestRestConnection_ClientBooking(t *testing.T) {
 		t.Fail()
 	}
 
-	if err := client.Booking(1, utLowLevelID); err != nil {
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if err := client.Booking(1, utLowFareClass); err != nil {
 		t.Error(err)
 		t.Fail()
 	}
@@ -322,6 +421,90 @@ func TestRestConnection_ClientBooking(t *testing.T) {
 	}
 }
 
+func TestRestConnection_ClientBookingWithCoins(t *testing.T) {
+	if _, err := testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	client := NewClient(1, utMnemonic, 2)
+	client.con = NewRestConnection()
+	if err := client.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(client.con); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.BookingWithCoins(1, utLowFareClass); err != nil {
+		t.Fatal(err)
+	}
+	if client.Balance() < 1 || len(client.BonusCodes) != 0 {
+		t.Errorf("wrong balance of %d points", client.Balance())
+	}
+}
+
+func TestRestConnection_ClientTransferCode(t *testing.T) {
+	if _, err := testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	sender := NewClient(1, utMnemonic, 2)
+	sender.con = NewRestConnection()
+	receiver := NewClient(2, utMnemonic, 3)
+	receiver.con = NewRestConnection()
+	if err := sender.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := receiver.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(sender.con); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Booking(1, utLowFareClass); err != nil || len(sender.BonusCodes) != 1 {
+		t.Fatalf("no code booked: %v", err)
+	}
+
+	code := sender.BonusCodes[0]
+	request, err := receiver.RequestTransfer(code.ValidFor.BonusID, code.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blindSig, err := sender.TransferCode(code.CodeID, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = receiver.ReceiveTransfer(request, blindSig); err != nil || len(receiver.BonusCodes) != 1 {
+		t.Errorf("transfer not received: %v", err)
+	}
+}
+
+func TestRestConnection_ClientExchangeCodes(t *testing.T) {
+	if _, err := testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+
+	client := NewClient(1, utMnemonic, 2)
+	client.con = NewRestConnection()
+	if err := client.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	if err := testLogin(client.con); err != nil {
+		t.Fatal(err)
+	}
+	points := client.BonusLevels[utMiddleLevelID].ExchangeRates[utLowLevelID]
+	for i := 0; i < points; i++ {
+		if err := client.Booking(1, utLowFareClass); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bCode, err := client.ExchangeCodes(utLowLevelID, utMiddleLevelID); err != nil || bCode.ValidFor.BonusID != utMiddleLevelID {
+		t.Errorf("codes not exchanged: %v", err)
+	}
+}
+
 func TestRestConnection_ClientAccess(t *testing.T) {
 	if _, err := testSetupForRestTests(); err != nil {
 		t.Log(err)
@@ -335,9 +518,13 @@ func TestRestConnection_ClientAccess(t *testing.T) {
 		t.Fail()
 	}
 	// create bookings
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
 	// try to access
 	if err := client.AccessBonusSystem(); err != nil {
 		t.Error(err)
@@ -370,9 +557,13 @@ func TestRestConnection_ClientParticipate(t *testing.T) {
 		t.Fail()
 	}
 	// create bookings
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
 	// access bonus system 'low' and 'middle'
 	_ = client.AccessBonusSystem()
 
@@ -398,6 +589,16 @@ func TestRestConnection_ClientParticipate(t *testing.T) {
 		t.Fail()
 	}
 
+	// the test configuration of the server offers a lounge access
+	if bData, err = client.PerformAction(utMiddleLevelID, "LoungeAccess"); err != nil || !strings.HasPrefix(bData, "lounge:") {
+		t.Errorf("lounge access failed: %s %v", bData, err)
+	}
+
+	// the chosen reward is received as signed voucher
+	if voucher, err := client.ClaimReward(utMiddleLevelID, "upgrade"); err != nil || voucher.RewardID != "upgrade" {
+		t.Errorf("reward not claimed: %v %v", voucher, err)
+	}
+
 	// try to participate with level high => has to fail!
 	bData = ""
 	err = nil
@@ -441,9 +642,13 @@ func TestRestConnection_ClientRecovery(t *testing.T) {
 		t.Fail()
 	}
 	// create bookings
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
 	// access bonus system 'low' and 'middle'
 	_ = client.AccessBonusSystem()
 
@@ -479,7 +684,7 @@ func TestRestConnection_GetDebugInfos(t *testing.T) {
 	var clientID int
 	var server *Server
 	var err error
-	if con, err = testSetupForRestTests(); err != nil {
+	if con, err = testSetupForRestTestsWithReset(); err != nil {
 		t.Log(err)
 		t.Skip("server is down")
 	}
@@ -512,7 +717,7 @@ func TestRestConnection_GetLastAdrBdl(t *testing.T) {
 	var adr string
 	var accountID uint32
 	var err error
-	if con, err = testSetupForRestTests(); err != nil {
+	if con, err = testSetupForRestTestsWithReset(); err != nil {
 		t.Log(err)
 		t.Skip("server is down")
 	}
@@ -523,9 +728,13 @@ func TestRestConnection_GetLastAdrBdl(t *testing.T) {
 		t.Fail()
 	}
 	// create bookings
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
-	_ = client.Booking(1, utMiddleLevelID)
+	if err := testLogin(client.con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
+	_ = client.Booking(1, utMiddleFareClass)
 	// access bonus system 'low' and 'middle'
 	_ = client.AccessBonusSystem()
 	lastAdrMiddle := crypt.GetAddress(client.Keys[4], client.AddressID-1).String()
@@ -556,3 +765,78 @@ func TestRestConnection_GetLastAdrBdl(t *testing.T) {
 	}
 
 }
+
+func TestRestConnection_SearchFlights(t *testing.T) {
+	var con *RestConnection
+	var page *FlightPage
+	var err error
+	if con, err = testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+	if page, err = con.SearchFlights(FlightQuery{PageSize: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if page.Total == 0 || len(page.Flights) != 5 {
+		t.Fatalf("wrong page: total %d, %d flights", page.Total, len(page.Flights))
+	}
+	flight := page.Flights[0]
+	query := FlightQuery{Origin: flight.Origin, Destination: flight.Destination, Date: flight.Departure}
+	if page, err = con.SearchFlights(query); err != nil {
+		t.Fatal(err)
+	}
+	if page.Total == 0 || page.Flights[0].ID != flight.ID {
+		t.Error("flight not found by its route and date")
+	}
+}
+
+func TestRestConnection_CancelBooking(t *testing.T) {
+	var con *RestConnection
+	var err error
+	if con, err = testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+	if err := testLogin(con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_, bookingID, err := con.BookFlight(2, utLowFareClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codeMayExist, err := con.CancelBooking(bookingID); err != nil || codeMayExist {
+		t.Errorf("cancellation failed: %v, code may exist %v", err, codeMayExist)
+	}
+	if _, err = con.CancelBooking(bookingID); err == nil {
+		t.Error("booking cancelled twice")
+	}
+}
+
+func TestRestConnection_GetBookings(t *testing.T) {
+	var con *RestConnection
+	var err error
+	if con, err = testSetupForRestTests(); err != nil {
+		t.Log(err)
+		t.Skip("server is down")
+	}
+	if _, err = con.GetBookings(); err == nil {
+		t.Error("bookings received without login")
+	}
+	if err := testLogin(con); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_, bookingID, err := con.BookFlight(2, utLowFareClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookings, err := con.GetBookings()
+	if err != nil || len(bookings) == 0 {
+		t.Fatalf("no bookings received: %v", err)
+	}
+	booking := bookings[len(bookings)-1]
+	if booking.BookingID != bookingID || booking.Status != BookingCodePending || len(booking.PendingTokens) != 1 {
+		t.Errorf("wrong booking status: %v", booking)
+	}
+}
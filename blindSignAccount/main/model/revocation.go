@@ -0,0 +1,166 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"golang.org/x/crypto/ed25519"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The ids of the items revoked in an epoch as Golomb-coded set, e.g. of redeemed vouchers and spent
+// codes. Filters are published per epoch, so offline verifiers download the new epochs only.
+type RevocationFilter struct {
+	// epochs start with one and are consecutive
+	Epoch     int
+	CreatedAt time.Time
+	// the SipHash key of the filter
+	Key    []byte
+	Filter crypt.GCSFilter
+	// the signature of all other fields by the revocation key of the server
+	Signature []byte
+}
+
+// Returns the signed message: all fields of the filter except the signature
+func (f *RevocationFilter) message() []byte {
+	fields := []string{strconv.Itoa(f.Epoch), strconv.FormatInt(f.CreatedAt.Unix(), 10), hex.EncodeToString(f.Key),
+		strconv.FormatUint(uint64(f.Filter.N), 10), strconv.Itoa(int(f.Filter.P)),
+		strconv.FormatUint(f.Filter.M, 10), hex.EncodeToString(f.Filter.Data)}
+	return []byte(strings.Join(fields, "\n"))
+}
+
+// Checks the signature of the filter by the published revocation key
+func (f *RevocationFilter) Verify(publicKey ed25519.PublicKey) error {
+	if len(publicKey) != ed25519.PublicKeySize || !ed25519.Verify(publicKey, f.message(), f.Signature) {
+		return errors.New("revocation filter of epoch " + strconv.Itoa(f.Epoch) + " has an invalid signature")
+	}
+	return nil
+}
+
+// Reports whether the id may be revoked in the epoch of the filter. False positives occur
+// with a probability of about 1/M.
+func (f *RevocationFilter) Contains(id string) bool {
+	var key [crypt.GCSKeySize]byte
+	copy(key[:], f.Key)
+	return f.Filter.Match(key, []byte(id))
+}
+
+// Marks an item as revoked, it is part of the filter of the next epoch.
+func (s *Server) revoke(id string) {
+	s.muxRevocations.Lock()
+	defer s.muxRevocations.Unlock()
+	s.pendingRevocations = append(s.pendingRevocations, []byte(id))
+}
+
+// Builds and signs the filter of the items revoked since the last epoch and starts a new epoch.
+// Epochs without revocations have empty filters.
+func (s *Server) SealRevocations() (*RevocationFilter, error) {
+	s.muxRevocations.Lock()
+	defer s.muxRevocations.Unlock()
+
+	var key [crypt.GCSKeySize]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return nil, err
+	}
+	filter, err := crypt.BuildGCSFilter(crypt.GCSDefaultP, crypt.GCSDefaultM, key, s.pendingRevocations)
+	if err != nil {
+		return nil, err
+	}
+	revocations := &RevocationFilter{Epoch: len(s.revocationFilters) + 1, CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Key: key[:], Filter: *filter}
+	revocations.Signature = ed25519.Sign(s.revocationSkKey, revocations.message())
+	s.revocationFilters = append(s.revocationFilters, revocations)
+	s.pendingRevocations = nil
+	return revocations, nil
+}
+
+// Seals the revocations every interval until the stop channel is closed
+func (s *Server) SealRevocationsEvery(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = s.SealRevocations()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Returns the filters of the epochs after the given epoch
+func (s *Server) GetRevocations(sinceEpoch int) []*RevocationFilter {
+	s.muxRevocations.Lock()
+	defer s.muxRevocations.Unlock()
+
+	if sinceEpoch < 0 {
+		sinceEpoch = 0
+	}
+	if sinceEpoch >= len(s.revocationFilters) {
+		return []*RevocationFilter{}
+	}
+	return append([]*RevocationFilter{}, s.revocationFilters[sinceEpoch:]...)
+}
+
+// Returns the published key of the revocation filters
+func (s *Server) RevocationPublicKey() ed25519.PublicKey {
+	s.muxRevocations.Lock()
+	defer s.muxRevocations.Unlock()
+	return s.revocationPublicKey
+}
+
+// The revocation filters known by an offline verifier, e.g. a partner. The verifier trusts the
+// published revocation key of the server and adds the filters of new epochs in their order.
+type RevocationList struct {
+	PublicKey ed25519.PublicKey
+	Filters   []*RevocationFilter
+}
+
+func NewRevocationList(publicKey ed25519.PublicKey) *RevocationList {
+	return &RevocationList{PublicKey: publicKey}
+}
+
+// Returns the last known epoch, zero if no filter is known
+func (l *RevocationList) Epoch() int {
+	return len(l.Filters)
+}
+
+// Adds the filters of the next epochs. Filters of known epochs are ignored, gaps and
+// invalid signatures are rejected.
+func (l *RevocationList) Add(filters ...*RevocationFilter) error {
+	for _, filter := range filters {
+		if filter.Epoch <= l.Epoch() {
+			continue
+		}
+		if filter.Epoch != l.Epoch()+1 {
+			return errors.New("revocation filter of epoch " + strconv.Itoa(l.Epoch()+1) + " is missing")
+		}
+		if err := filter.Verify(l.PublicKey); err != nil {
+			return err
+		}
+		l.Filters = append(l.Filters, filter)
+	}
+	return nil
+}
+
+// Downloads and adds the filters of the epochs after the last known epoch
+func (l *RevocationList) Update(con *RestConnection) error {
+	filters, _, err := con.GetRevocations(l.Epoch())
+	if err != nil {
+		return err
+	}
+	return l.Add(filters...)
+}
+
+// Reports whether the id may be revoked in any known epoch
+func (l *RevocationList) IsRevoked(id string) bool {
+	for _, filter := range l.Filters {
+		if filter.Contains(id) {
+			return true
+		}
+	}
+	return false
+}
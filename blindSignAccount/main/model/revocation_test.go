@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServer_SealRevocations(t *testing.T) {
+	server := setupPartner(t, "lounge", "lounge-key")
+
+	voucher, err := server.BonusList[utHighLevelID].issueVoucher("lounge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = server.RedeemVoucher("lounge", voucher); err != nil {
+		t.Fatal(err)
+	}
+	server.revoke("spent code")
+	first, err := server.SealRevocations()
+	if err != nil || first.Epoch != 1 || first.Filter.N != 2 {
+		t.Fatalf("wrong filter of the first epoch: %v %v", first, err)
+	}
+	// epochs without revocations are sealed also
+	if second, err := server.SealRevocations(); err != nil || second.Epoch != 2 || second.Filter.N != 0 {
+		t.Fatalf("wrong filter of the second epoch: %v %v", second, err)
+	}
+	if len(server.GetRevocations(0)) != 2 || len(server.GetRevocations(1)) != 1 || len(server.GetRevocations(2)) != 0 {
+		t.Error("wrong filters since an epoch")
+	}
+
+	// the verifier checks the signatures and the order of the epochs
+	list := NewRevocationList(server.RevocationPublicKey())
+	if err = list.Add(server.GetRevocations(1)...); err == nil {
+		t.Error("filter added without its previous epoch")
+	}
+	if err = list.Add(server.GetRevocations(0)...); err != nil || list.Epoch() != 2 {
+		t.Fatalf("filters not added: %v", err)
+	}
+	if !list.IsRevoked(voucher.ID) || !list.IsRevoked("spent code") || list.IsRevoked("valid code") {
+		t.Error("wrong membership of the revocations")
+	}
+
+	third, _ := server.SealRevocations()
+	third.Filter.N++
+	if err = list.Add(third); err == nil || list.Epoch() != 2 {
+		t.Error("tampered filter added")
+	}
+	if err = NewRevocationList(server.BonusList[utHighLevelID].VoucherPublicKey).Add(first); err == nil {
+		t.Error("filter verified by another key")
+	}
+}
+
+func TestServer_SealRevocationsEvery(t *testing.T) {
+	server := NewServer()
+	stop := make(chan struct{})
+	go server.SealRevocationsEvery(10*time.Millisecond, stop)
+	time.Sleep(55 * time.Millisecond)
+	close(stop)
+	if epochs := len(server.GetRevocations(0)); epochs < 2 {
+		t.Errorf("only %d epochs sealed", epochs)
+	}
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"encoding/json"
+	"errors"
+	"golang.org/x/crypto/ed25519"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A voucher for a reward of a bonus level. It is the bonus data of a participation and
+// signed by the ed25519 voucher key of the bonus level, so partners can verify it offline.
+type Voucher struct {
+	ID          string
+	BLevelID    string
+	RewardID    string
+	Description string
+	Value       int
+	Currency    string
+	ValidUntil  time.Time
+	// the signature of all other fields
+	Signature []byte
+}
+
+// Returns the default reward catalogues of the default bonus levels
+func DefaultRewards() map[string][]config.Reward {
+	baggage := config.Reward{ID: "baggage", Description: "extra baggage", Value: 5000, Currency: "EUR", ValidDays: 365, Points: 50}
+	upgrade := config.Reward{ID: "upgrade", Description: "seat upgrade", Value: 15000, Currency: "EUR", ValidDays: 180, Points: 150}
+	lounge := config.Reward{ID: "lounge", Description: "lounge voucher", Value: 4000, Currency: "EUR", ValidDays: 90, Points: 40}
+	return map[string][]config.Reward{
+		"low":    {baggage},
+		"middle": {baggage, upgrade},
+		"high":   {lounge, baggage, upgrade},
+	}
+}
+
+// Sets the reward catalogues of the bonus levels. All bonus levels of the catalogues have to exist.
+// The caller has to hold a write lock of the server.
+func (s *Server) setRewards(rewards map[string][]config.Reward) error {
+	for bLevelID, catalogue := range rewards {
+		if s.BonusList[bLevelID] == nil {
+			return errors.New("rewards of bonus level '" + bLevelID + "': bonus level does not exist")
+		}
+		ids := map[string]bool{}
+		for _, reward := range catalogue {
+			if reward.ID == "" || ids[reward.ID] {
+				return errors.New("rewards of bonus level '" + bLevelID + "': reward id is empty or not unique")
+			}
+			ids[reward.ID] = true
+		}
+	}
+	for bLevelID, bLevel := range s.BonusList {
+		bLevel.Rewards = rewards[bLevelID]
+	}
+	return nil
+}
+
+// Returns the reward of the catalogue with the id, the first reward if the id is empty
+func (b *BonusLevel) getReward(rewardID string) (config.Reward, error) {
+	for _, reward := range b.Rewards {
+		if rewardID == "" || reward.ID == rewardID {
+			return reward, nil
+		}
+	}
+	if rewardID == "" {
+		return config.Reward{}, errors.New("bonus level '" + b.BonusID + "' has no rewards")
+	}
+	return config.Reward{}, errors.New("bonus level '" + b.BonusID + "' has no reward '" + rewardID + "'")
+}
+
+// Issues a signed voucher for a reward of the catalogue. It fails for bonus levels
+// without voucher key, e.g. the public bonus levels of a client.
+func (b *BonusLevel) issueVoucher(rewardID string) (*Voucher, error) {
+	reward, err := b.getReward(rewardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.VoucherSkKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("bonus level '" + b.BonusID + "' has no voucher key")
+	}
+	voucher := &Voucher{ID: crypt.GenerateToken(), BLevelID: b.BonusID, RewardID: reward.ID,
+		Description: reward.Description, Value: reward.Value, Currency: reward.Currency,
+		ValidUntil: time.Now().UTC().Truncate(time.Second).AddDate(0, 0, reward.ValidDays)}
+	voucher.Signature = ed25519.Sign(b.VoucherSkKey, voucher.message())
+	return voucher, nil
+}
+
+// Returns the signed message: all fields of the voucher except the signature
+func (v *Voucher) message() []byte {
+	fields := []string{v.ID, v.BLevelID, v.RewardID, v.Description, strconv.Itoa(v.Value), v.Currency,
+		strconv.FormatInt(v.ValidUntil.Unix(), 10)}
+	return []byte(strings.Join(fields, "\n"))
+}
+
+// Checks the signature of the voucher by the public voucher key of its bonus level.
+// It needs no connection to the server.
+func (v *Voucher) Verify(publicKey ed25519.PublicKey) error {
+	if len(publicKey) != ed25519.PublicKeySize || !ed25519.Verify(publicKey, v.message(), v.Signature) {
+		return errors.New("voucher has an invalid signature")
+	}
+	return nil
+}
+
+// Reports whether the voucher is expired at the given time
+func (v *Voucher) IsExpired(now time.Time) bool {
+	return now.After(v.ValidUntil)
+}
+
+// Encodes the voucher as bonus data
+func (v *Voucher) String() string {
+	encoded, _ := json.Marshal(v)
+	return string(encoded)
+}
+
+// Decodes a voucher from bonus data
+func ParseVoucher(bonusData string) (*Voucher, error) {
+	var voucher Voucher
+	if err := json.Unmarshal([]byte(bonusData), &voucher); err != nil {
+		return nil, errors.New("bonus data is no voucher")
+	}
+	return &voucher, nil
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"blindSignAccount/main/config"
+	"encoding/hex"
+	"golang.org/x/crypto/ed25519"
+	"testing"
+	"time"
+)
+
+func TestBonusLevel_issueVoucher(t *testing.T) {
+	server := NewServer()
+	bLevel := server.BonusList[utHighLevelID]
+
+	// the first reward of the catalogue is the default
+	voucher, err := bLevel.issueVoucher("")
+	if err != nil || voucher.RewardID != bLevel.Rewards[0].ID || voucher.BLevelID != utHighLevelID {
+		t.Fatalf("wrong default voucher: %v %v", voucher, err)
+	}
+	if voucher, err = bLevel.issueVoucher("upgrade"); err != nil || voucher.RewardID != "upgrade" || voucher.Value != 15000 {
+		t.Fatalf("wrong voucher: %v %v", voucher, err)
+	}
+	if _, err = bLevel.issueVoucher("unknown"); err == nil {
+		t.Error("voucher for an unknown reward issued")
+	}
+
+	// the voucher is decoded from the bonus data and verified by the public key
+	parsed, err := ParseVoucher(voucher.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = parsed.Verify(bLevel.VoucherPublicKey); err != nil {
+		t.Error(err)
+	}
+	if parsed.IsExpired(time.Now()) || !parsed.IsExpired(time.Now().AddDate(0, 0, 181)) {
+		t.Error("wrong validity of the voucher")
+	}
+	parsed.Value *= 10
+	if err = parsed.Verify(bLevel.VoucherPublicKey); err == nil {
+		t.Error("tampered voucher verified")
+	}
+	if err = voucher.Verify(server.BonusList[utLowLevelID].VoucherPublicKey); err == nil {
+		t.Error("voucher verified by the key of another bonus level")
+	}
+	if _, err = ParseVoucher(utLowLevelID + "0102"); err == nil {
+		t.Error("random bonus data parsed as voucher")
+	}
+}
+
+func TestServer_setRewards(t *testing.T) {
+	server := NewServer()
+
+	lounge := config.Reward{ID: "lounge", Description: "lounge voucher", Value: 4000, Currency: "EUR", ValidDays: 30}
+	if err := server.setRewards(map[string][]config.Reward{"unknown": {lounge}}); err == nil {
+		t.Error("rewards of an unknown bonus level set")
+	}
+	if err := server.setRewards(map[string][]config.Reward{utLowLevelID: {lounge, lounge}}); err == nil {
+		t.Error("rewards with duplicate ids set")
+	}
+	if err := server.setRewards(map[string][]config.Reward{utLowLevelID: {lounge}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.BonusList[utLowLevelID].Rewards) != 1 || len(server.BonusList[utHighLevelID].Rewards) != 0 {
+		t.Error("wrong reward catalogues")
+	}
+	if _, err := server.BonusList[utHighLevelID].issueVoucher(""); err == nil {
+		t.Error("voucher issued without a catalogue")
+	}
+
+	// the catalogue is public
+	public := server.BonusList[utLowLevelID].CopyPublic()
+	if len(public.Rewards) != 1 || public.Rewards[0] != lounge || len(public.VoucherPublicKey) != ed25519.PublicKeySize {
+		t.Error("catalogue is not public")
+	}
+}
+
+func TestClient_ClaimReward(t *testing.T) {
+	client := setupClient(t)
+	server := client.con.(*utConnection).server
+
+	// setup a valid participation Token
+	initialToken := "INITIAL_TOKEN"
+	client.BLevelToRecovery[utMiddleLevelID] = "INITIAL RECOVERY TOKEN"
+	client.BLevelToTokens[utMiddleLevelID] = initialToken
+	serverParticipate := server.BonusList[utMiddleLevelID].ActionVariants[ActionParticipate]
+	serverParticipate.ValidTokens[initialToken] = false
+	serverParticipate.SeedToAddress[hex.EncodeToString(client.Seed)] = "INITIAL_ADDRESS"
+	serverParticipate.SeedToAccountID[hex.EncodeToString(client.Seed)] = 2
+
+	voucher, err := client.ClaimReward(utMiddleLevelID, "upgrade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voucher.RewardID != "upgrade" || voucher.BLevelID != utMiddleLevelID || voucher.IsExpired(time.Now()) {
+		t.Errorf("wrong voucher %v", voucher)
+	}
+	if _, err = client.ClaimReward(utMiddleLevelID, "lounge"); err == nil {
+		t.Error("reward of another bonus level claimed")
+	}
+	if _, err = client.ClaimReward("unknown", "upgrade"); err == nil {
+		t.Error("reward of an unknown bonus level claimed")
+	}
+}
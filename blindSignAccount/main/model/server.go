@@ -1,17 +1,23 @@
 package model
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/metrics"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"github.com/btcsuite/btcutil"
 	"github.com/cryptoballot/rsablind"
+	"golang.org/x/crypto/ed25519"
 	"io/ioutil"
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Server struct {
@@ -28,25 +34,92 @@ type Server struct {
 	ClientIDs []int
 
 	// sync
-	Mux sync.Mutex
-
-	// statistic
+	// Mux guards the structure of the server: bonus levels, codes and flights are only
+	// replaced by a reset. Protocol steps hold a read lock; the state of a bonus level is
+	// guarded by the locks of its action variants.
+	Mux        sync.RWMutex
+	muxCodes   sync.Mutex
+	muxClients sync.Mutex
+	// guards the flight map against changes of the admin routes
+	muxFlights sync.RWMutex
+	// the file of the flight catalogue, empty for the default flights
+	flightFile string
+	// maps booking ids to their flights
+	bookings    map[int]*Flight
+	muxBookings sync.Mutex
+	// the id of the last booking, kept by resets. The ids start at the creation time in
+	// milliseconds, so a restarted server does not reuse the ids of its last run.
+	lastBookingID int64
+	// decide which bonus level a booking earns, kept by resets
+	earningRules map[string][]config.EarningRule
+	// tiers of customers with another tier than the default tier
+	customerTiers map[int]string
+	// customer accounts by name and sessions of logged in customers
+	customers     map[string]*Customer
+	sessions      map[string]*customerSession
+	sessionsSwept time.Time
+	muxCustomers  sync.Mutex
+	// partner names by their keys, kept by resets, and redemptions of vouchers by voucher ids
+	partners       map[string]string
+	redemptions    map[string]*Redemption
+	muxRedemptions sync.Mutex
+	// ids revoked since the last epoch and the signed filters of all epochs
+	pendingRevocations  [][]byte
+	revocationFilters   []*RevocationFilter
+	revocationPublicKey ed25519.PublicKey
+	revocationSkKey     ed25519.PrivateKey
+	muxRevocations      sync.Mutex
+	// the denominations of loyalty coins and the serials of spent coins
+	coinDenominations []*CoinDenomination
+	spentCoins        map[string]time.Time
+	muxCoins          sync.Mutex
+	// the hash values which were exchanged for codes or spent for actions, see spentHashKey
+	spentHashes map[string]time.Time
+	muxHashes   sync.Mutex
+
+	// statistic, the counters are incremented concurrently by the handlers
 	CntReqSendBooking, CntReqGetBookingCode,
 	CntReqGetSystemInformation,
 	CntReqBlindSignature, CntReqSetAddress,
 	CntReqAccessBonusSystem, CntReqParticipate,
 	CntReqCanBesUsedForRecovery, CntReqRecoveryTest, CntReqGetLastAdrBundle,
-	CntReqRegister, CntReqExit, CntReqStatistic, CntReqReset int
+	CntReqRegister, CntReqExit, CntReqStatistic, CntReqReset, CntReqSearchFlights, CntReqCancelBooking metrics.Counter
+
+	// runs blind signatures and key derivations
+	SignPool *SignPool `json:"-"`
+
+	// metrics
+	Metrics      *metrics.Registry     `json:"-"`
+	HTTPRequests *metrics.CounterVec   `json:"-"`
+	HTTPDuration *metrics.HistogramVec `json:"-"`
+	SignWait     *metrics.HistogramVec `json:"-"`
+	RateLimited  *metrics.CounterVec   `json:"-"`
 }
 
 const lengthBonusCode = 64
 
 // Creates a new server
 func NewServer() *Server {
+	// the bonus levels offer the configured actions also
+	registerConfiguredActions()
 	s := &Server{BonusList: GetDefaultHBLS(),
-		BonusCodes: map[string]*BonusCode{},
-		flightMap:  GetDefaultFlightList(),
-		ClientIDs:  []int{}}
+		BonusCodes:    map[string]*BonusCode{},
+		flightMap:     GetDefaultFlightList(),
+		bookings:      map[int]*Flight{},
+		customerTiers: map[int]string{},
+		customers:     map[string]*Customer{},
+		sessions:      map[string]*customerSession{},
+		partners:      configuredPartners(),
+		redemptions:   map[string]*Redemption{},
+		spentCoins:    map[string]time.Time{},
+		spentHashes:   map[string]time.Time{},
+		lastBookingID: time.Now().UnixNano() / int64(time.Millisecond),
+		ClientIDs:     []int{}}
+	s.revocationPublicKey, s.revocationSkKey, _ = ed25519.GenerateKey(rand.Reader)
+	s.coinDenominations = newCoinDenominations(configuredCoinValues())
+	s.initMetrics()
+	s.SetSignPool(NewSignPool(config.GetConfigSignWorkers(), config.GetConfigSignQueueSize(),
+		config.GetConfigSignRetryAfter()))
 
 	// check out the priorities
 	priorities := map[*BonusLevel]int{}
@@ -59,6 +132,21 @@ func NewServer() *Server {
 		return priorities[s.Hierarchy[i]] > priorities[s.Hierarchy[j]]
 	})
 
+	// configured earning rules with unknown bonus levels are rejected when they are set
+	s.earningRules = config.GetConfigEarningRules()
+	if len(s.earningRules) == 0 || s.checkEarningRules(s.earningRules) != nil {
+		s.earningRules = DefaultEarningRules()
+	}
+	s.addDenominations()
+	// configured reward catalogues with unknown bonus levels are rejected
+	if rewards := config.GetConfigRewards(); len(rewards) == 0 || s.setRewards(rewards) != nil {
+		_ = s.setRewards(DefaultRewards())
+	}
+	if rates := config.GetConfigExchangeRates(); len(rates) == 0 || s.setExchangeRates(rates) != nil {
+		_ = s.setExchangeRates(DefaultExchangeRates())
+	}
+	// codes are activated at once if the configured delays are not valid
+	_ = s.setActivationDelays(config.GetConfigActivationDelays())
 	return s
 }
 
@@ -88,49 +176,168 @@ func NewServerFromFile(fileName string) (*Server, error) {
 	return server, nil
 }
 
+// Replaces the signing pool, jobs of the former pool are finished
+func (s *Server) SetSignPool(pool *SignPool) {
+	s.Mux.Lock()
+	defer s.Mux.Unlock()
+	pool.wait = s.SignWait
+	s.SignPool = pool
+}
+
+// Derives the address of the seed in the signing pool
+func (s *Server) addressFromSeed(adrBundle *crypt.AddressBundle) (address *btcutil.AddressPubKeyHash, err error) {
+	err = s.SignPool.Do(func() error {
+		address, err = crypt.GetAddressFromSeed(adrBundle, false)
+		return err
+	})
+	return
+}
+
 // generates and adds a new bonus code for the bonus level with given id
 func (s *Server) GenerateNewBonusCode(bonusLevelID string) *BonusCode {
+	return s.generateBonusCode(bonusLevelID, 1)
+}
+
+// Like GenerateNewBonusCode, but the code is worth the given points
+func (s *Server) generateBonusCode(bonusLevelID string, points int) *BonusCode {
 	code := NewBonusCode(s.BonusList[bonusLevelID])
+	code.Points = points
+	s.muxCodes.Lock()
+	defer s.muxCodes.Unlock()
 	s.BonusCodes[code.CodeID] = code
 	return code
 }
 
-// Handles a new booking of a given customer for a given bonus level.
-// If successful a new Token is generated. This Token can be used for generating a new code
-func (s *Server) Booking(flightID, customerID int, bonusLevelID string) (string, error) {
+// Handles a new booking of a given customer in a fare class, any class if it is empty.
+// The earning rules decide which bonus level the booking earns: one Token is generated per
+// earned code. The tokens can be used for generating new codes.
+func (s *Server) Booking(flightID, customerID int, fareClass string) ([]*EarnedToken, error) {
+	tokens, _, err := s.BookFlight(flightID, customerID, fareClass)
+	return tokens, err
+}
+
+// Like Booking, but returns the id of the booking also. The id is needed for a cancellation.
+func (s *Server) BookFlight(flightID, customerID int, fareClass string) (tokens []*EarnedToken, bookingID int, err error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
-	// find bonus level
-	bLevel := s.BonusList[bonusLevelID]
-	if bLevel == nil {
-		return "", errors.New("bonus level " + bonusLevelID + " does not exist")
-	}
 	// find flight
-	flight := s.flightMap[flightID]
+	flight := s.flight(flightID)
 	if flight == nil {
-		return "", errors.New("flight with id " + strconv.Itoa(flightID) + " does not exist")
+		return nil, 0, errors.New("flight with id " + strconv.Itoa(flightID) + " does not exist")
 	}
-	// generate a code
-	token := crypt.GenerateToken()
-	err := bLevel.addValidToken(token, ActionBooking)
-	if err != nil {
-		return "", nil
+	// create a new booking, a fully booked flight gets no token
+	booking := &Booking{CustomerID: customerID, Tokens: []*EarnedToken{}}
+	if err = flight.addBooking(booking, fareClass, s.nextBookingID); err != nil {
+		return nil, 0, err
+	}
+	bLevel, codes, points := s.earn(flight, booking.FareClass, customerID)
+	for i := 0; i < codes; i++ {
+		token := crypt.GenerateToken()
+		if err = bLevel.addValidBookingToken(token, points); err != nil {
+			s.revokeBooking(flight, booking.ID)
+			return nil, 0, err
+		}
+		booking.Tokens = append(booking.Tokens, &EarnedToken{Token: token, BLevelID: bLevel.BonusID, Points: points})
 	}
+	flight.mux.Lock()
+	booking.BonusLevel = bLevel
+	flight.mux.Unlock()
 
-	// create a new booking
-	flight.AddBooking(customerID, bLevel)
+	s.muxBookings.Lock()
+	s.bookings[booking.ID] = flight
+	s.muxBookings.Unlock()
+	return booking.Tokens, booking.ID, nil
+}
 
-	return token, nil
+// Returns a new booking id, booking ids are never reused
+func (s *Server) nextBookingID() int {
+	return int(atomic.AddInt64(&s.lastBookingID, 1))
 }
 
-// Generates a new code which is valid for a requested bonus level.
-// The code will be generated if and only if the hash value and the signature are fitting together
-func (s *Server) GetBookingCode(bLevelID string, hashValue, signature []byte) (string, error) {
+// Cancels a booking of the customer and frees its seat. The booking tokens are revoked if no blind
+// signature was requested with them. Otherwise a code may have been minted already, which is reported.
+func (s *Server) CancelBooking(customerID, bookingID int) (codeMayExist bool, err error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	// bookings of other customers are not revealed
+	s.muxBookings.Lock()
+	flight := s.bookings[bookingID]
+	if flight == nil || flight.bookingCustomer(bookingID) != customerID {
+		s.muxBookings.Unlock()
+		return false, errors.New("booking with id " + strconv.Itoa(bookingID) + " does not exist")
+	}
+	delete(s.bookings, bookingID)
+	s.muxBookings.Unlock()
+	return s.revokeBooking(flight, bookingID)
+}
+
+// Returns the bookings of a customer in order of booking. The tokens of pending bookings can be
+// exchanged for blind signatures, so an interrupted exchange can be resumed.
+func (s *Server) GetBookings(customerID int) []*BookingStatus {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	// the tokens of a booking are complete, when it is added to the bookings
+	s.muxBookings.Lock()
+	flights := make(map[int]*Flight, len(s.bookings))
+	for bookingID, flight := range s.bookings {
+		flights[bookingID] = flight
+	}
+	s.muxBookings.Unlock()
+
+	bookings := make([]*BookingStatus, 0)
+	for bookingID, flight := range flights {
+		if booking := flight.booking(bookingID); booking != nil && booking.CustomerID == customerID {
+			bookings = append(bookings, s.bookingStatus(flight, booking))
+		}
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].BookingID < bookings[j].BookingID })
+	return bookings
+}
+
+func (s *Server) bookingStatus(flight *Flight, booking *Booking) *BookingStatus {
+	status := &BookingStatus{BookingID: booking.ID, FlightID: flight.ID, FareClass: booking.FareClass,
+		Status: BookingCodeNone, PendingTokens: []*EarnedToken{}}
+	for _, token := range booking.Tokens {
+		if s.BonusList[token.BLevelID].isTokenValid(token.Token, ActionBooking) {
+			status.PendingTokens = append(status.PendingTokens, token)
+		}
+	}
+	if len(status.PendingTokens) > 0 {
+		status.Status = BookingCodePending
+	} else if len(booking.Tokens) > 0 {
+		status.Status = BookingCodeIssued
+	}
+	return status
+}
+
+// Removes a booking of the flight and revokes its unused tokens.
+// Reports whether a token of the booking was used already.
+func (s *Server) revokeBooking(flight *Flight, bookingID int) (codeMayExist bool, err error) {
+	booking := flight.removeBooking(bookingID)
+	if booking == nil {
+		return false, errors.New("booking with id " + strconv.Itoa(bookingID) + " does not exist")
+	}
+	for _, token := range booking.Tokens {
+		if !s.BonusList[token.BLevelID].revokeToken(token.Token, ActionBooking) {
+			codeMayExist = true
+		}
+	}
+	return codeMayExist, nil
+}
+
+// Generates a new code which is valid for a requested bonus level and worth the given points.
+// The code will be generated if and only if the hash value and the signature are fitting together:
+// the signature has to be made by the key of the denomination. Each hash value is exchanged only once.
+func (s *Server) GetBookingCode(bLevelID string, points int, hashValue, signature []byte) (string, error) {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
 	// find bonus level
 	bLevel := s.BonusList[bLevelID]
@@ -142,31 +349,72 @@ func (s *Server) GetBookingCode(bLevelID string, hashValue, signature []byte) (s
 	if len(hashValue) == 0 || len(signature) == 0 {
 		return "", errors.New("hash value or signature is empty")
 	}
-	if err := rsablind.VerifyBlindSignature(&bLevel.ActionVariants[ActionBooking].SkKey.PublicKey, hashValue, signature); err != nil {
+	if points < 1 {
+		points = 1
+	}
+	key := bLevel.bookingKey(points)
+	if key == nil {
+		return "", errors.New("bonus level '" + bLevelID + "' has no denomination of " + strconv.Itoa(points) + " points")
+	}
+	if err := rsablind.VerifyBlindSignature(&key.PublicKey, hashValue, signature); err != nil {
+		return "", err
+	}
+	spentKey := spentHashKey(bLevelID, strconv.Itoa(points), hashValue)
+	if err := s.spendHash(spentKey); err != nil {
 		return "", err
 	}
 
-	bCode := s.GenerateNewBonusCode(bLevelID)
+	bCode := s.generateBonusCode(bLevelID, points)
 	if bCode.CodeID == "" {
+		s.releaseHash(spentKey)
 		return "", errors.New("no code generated")
 	}
 	return bCode.CodeID, nil
 }
 
+// The key of a spent hash value. The scope separates the points of codes from the actions,
+// so a hash value signed by one key cannot block a hash value signed by another key.
+func spentHashKey(bLevelID, scope string, hashValue []byte) string {
+	return bLevelID + "|" + scope + "|" + hex.EncodeToString(hashValue)
+}
+
+// The scope of the hash values which were spent for an action
+func actionScope(action int) string {
+	return "action" + strconv.Itoa(action)
+}
+
+// Marks the hash value of a signed Token as spent. Fails if it was spent already.
+func (s *Server) spendHash(key string) error {
+	s.muxHashes.Lock()
+	defer s.muxHashes.Unlock()
+
+	if _, spent := s.spentHashes[key]; spent {
+		return errors.New("signature was used already")
+	}
+	s.spentHashes[key] = time.Now()
+	return nil
+}
+
+func (s *Server) releaseHash(key string) {
+	s.muxHashes.Lock()
+	defer s.muxHashes.Unlock()
+	delete(s.spentHashes, key)
+}
+
 // Checks if codes are valid and if bonus system can be accessed
 // If successful a Token is generated, returned and linked to the
 // given seed.
 func (s *Server) AccessBonusSystem(codes []string, adrBundle *crypt.AddressBundle) (tokens, recoveryTokens map[string]string, err error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
-	tokens = make(map[string]string, len(s.BonusCodes))
-	recoveryTokens = make(map[string]string, len(s.BonusCodes))
+	tokens = make(map[string]string, len(codes))
+	recoveryTokens = make(map[string]string, len(codes))
 	var calcAddress *btcutil.AddressPubKeyHash
 
 	// check that seed and address fit together
-	calcAddress, err = crypt.GetAddressFromSeed(adrBundle, false)
+	calcAddress, err = s.addressFromSeed(adrBundle)
 	if err != nil {
 		return
 	}
@@ -217,6 +465,10 @@ func (s *Server) AccessBonusSystem(codes []string, adrBundle *crypt.AddressBundl
 // Checks if given codes are valid and receive list of bonus levels for which
 // they are valid
 func (s *Server) verifyCodes(codes []string) (accessible []*BonusLevel) {
+	// the codes are checked and marked as used at once
+	s.muxCodes.Lock()
+	defer s.muxCodes.Unlock()
+
 	// initialize a map of valid levels
 	validLevels := map[*BonusLevel]int{}
 	for _, level := range s.BonusList {
@@ -231,21 +483,26 @@ func (s *Server) verifyCodes(codes []string) (accessible []*BonusLevel) {
 				continue
 			}
 			for _, level := range validForCode {
-				validLevels[level]++
+				validLevels[level] += bCode.Value()
 			}
 		}
 	}
 
-	// check that the minimal number of codes per level was reached
+	// check that the minimal number of points per level was reached
 	// The levels are ordered by hierarchy priority
 	for _, level := range s.Hierarchy {
-		if validLevels[level] >= level.MinNrCodes {
+		if validLevels[level] >= level.MinPoints {
 			// a valid level was found and can be accessed
 			// all lower levels can be accessed as well
 			accessible = append(level.LowerLevels, level)
-			// mark all codes as used
+			// mark all codes as used, codes which are not activated yet are kept
+			now := time.Now()
 			for _, code := range codes {
+				if bCode := s.BonusCodes[code]; bCode != nil && !bCode.isActive(now) {
+					continue
+				}
 				s.BonusCodes[code] = nil
+				s.revoke(code)
 			}
 			return accessible
 		}
@@ -258,29 +515,46 @@ func (s *Server) verifyCodes(codes []string) (accessible []*BonusLevel) {
 // The signature is calculated if an other given Token is valid
 func (s *Server) GetBlindSignature(bLevelID, token string, blindToken []byte, action int) (string, error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
 	bLevel := s.getBonusLevel(bLevelID)
 	if bLevel == nil {
 		return "", errors.New("no level known with given id")
 	}
-	// check that the Token is valid
-	isValid := bLevel.isTokenValid(token, action)
-	if !isValid {
-		return "", errors.New("Token is not valid")
+	actionType := getActionType(action)
+	if actionType == nil || bLevel.actionVariant(action) == nil {
+		return "", errors.New("unknown action")
+	}
+	// signing is done in the signing pool without holding any lock of the bonus level,
+	// a rejected request does not use the Token
+	var blindSig []byte
+	err := s.SignPool.Do(func() (err error) {
+		// check that the Token is valid and mark it as used: a Token can be used for one signature only.
+		// Actions are entered with tokens of their entry action.
+		if !bLevel.useToken(token, actionType.Entry) {
+			return errors.New("Token is not valid")
+		}
+		key := bLevel.ActionVariants[action].SkKey
+		if action == ActionBooking {
+			// booking tokens are signed by the key of their denomination
+			key = bLevel.bookingKey(bLevel.tokenPoints(token))
+		}
+		blindSig, err = rsablind.BlindSign(key, blindToken)
+		return
+	})
+	if err != nil {
+		return "", err
 	}
-	blindSig, err := rsablind.BlindSign(bLevel.ActionVariants[action].SkKey, blindToken)
-	bLevel.markTokenAsUsed(token, action)
-	return base64.URLEncoding.EncodeToString(blindSig), err
+	return base64.URLEncoding.EncodeToString(blindSig), nil
 }
 
 // sets a new address if signature of given the hash value is valid to the given signature
 // pkr - blinded recovery Token
 func (s *Server) SetAddress(bLevelID string, hashed, sig []byte, adrBundle *crypt.AddressBundle, action int, pkr string) (token, recoveryToken string, err error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
 	var calcAddress *btcutil.AddressPubKeyHash
 
@@ -288,9 +562,15 @@ func (s *Server) SetAddress(bLevelID string, hashed, sig []byte, adrBundle *cryp
 	if bLevel == nil {
 		return "", "", errors.New("no level known with given id")
 	}
+	variant := bLevel.actionVariant(action)
+	if variant == nil {
+		return "", "", errors.New("unknown action")
+	}
 	// check that the seed is known
-	_, ok := bLevel.ActionVariants[action].SeedToAddress[hex.EncodeToString(adrBundle.Seed)]
-	SaveRead(StatSeedToAddress, bLevel.ActionVariants[action])
+	variant.Mux.Lock()
+	_, ok := variant.SeedToAddress[hex.EncodeToString(adrBundle.Seed)]
+	variant.Mux.Unlock()
+	SaveRead(StatSeedToAddress, variant)
 	if !ok {
 		return "", "", errors.New("seed unknown")
 	}
@@ -299,13 +579,14 @@ func (s *Server) SetAddress(bLevelID string, hashed, sig []byte, adrBundle *cryp
 		return "", "", errors.New("hash value or signature is empty")
 	}
 
+	// the signature check and the key derivation are done without holding a lock
 	// check that the hash value fits the signature
-	if err = rsablind.VerifyBlindSignature(&bLevel.ActionVariants[action].SkKey.PublicKey, hashed, sig); err != nil {
+	if err = rsablind.VerifyBlindSignature(&variant.SkKey.PublicKey, hashed, sig); err != nil {
 		return "", "", err
 	}
 
 	// check that the address fits to the seed
-	calcAddress, err = crypt.GetAddressFromSeed(adrBundle, false)
+	calcAddress, err = s.addressFromSeed(adrBundle)
 	if err != nil {
 		return "", "", err
 	}
@@ -313,23 +594,27 @@ func (s *Server) SetAddress(bLevelID string, hashed, sig []byte, adrBundle *cryp
 		return "", "", errors.New("address does not fit to given seed")
 	}
 
+	token = crypt.GenerateToken()
+	recoveryToken = crypt.GenerateToken()
+
+	// the check of the address and the update have to be atomic
+	variant.Mux.Lock()
+	defer variant.Mux.Unlock()
+
 	// check that the address was not used before
-	SaveRead(StatAddressToToken, bLevel.ActionVariants[action])
-	if _, found := bLevel.ActionVariants[action].AddressToToken[adrBundle.Address]; found {
+	SaveRead(StatAddressToToken, variant)
+	if _, found := variant.AddressToToken[adrBundle.Address]; found {
 		return "", "", errors.New("address is not valid. Already used")
 	}
 
-	token = crypt.GenerateToken()
-	recoveryToken = crypt.GenerateToken()
-
 	// refresh maps
-	bLevel.refreshMaps(recoveryToken, token, adrBundle.Address, adrBundle.Seed, action, adrBundle.AccountID)
+	bLevel.refreshMapsLocked(recoveryToken, token, adrBundle.Address, adrBundle.Seed, action, adrBundle.AccountID)
 	if err = bLevel.addValidToken(token, action); err != nil {
 		return "", "", err
 	}
 	// the pkr has to be mapped to the address: Needed for recovery test
-	SaveWrite(StatPkrToAdrUpd, bLevel.ActionVariants[action])
-	bLevel.ActionVariants[action].PkrToAdrUpd[pkr] = adrBundle.Address
+	variant.PkrToAdrUpd[pkr] = adrBundle.Address
+	SaveWrite(StatPkrToAdrUpd, variant)
 	return token, recoveryToken, nil
 }
 
@@ -337,38 +622,61 @@ func (s *Server) SetAddress(bLevelID string, hashed, sig []byte, adrBundle *cryp
 // pkr - The blinded recovery Token
 // A new Token is generated in case of success.
 func (s *Server) Participate(bLevelID string, hashed, sig []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
+	return s.PerformAction(bLevelID, ActionParticipate, "", hashed, sig, pkr)
+}
+
+// Like Participate, but performs any action with a handler. The signature has to be made by the
+// key of the action. The new Token is valid for the entry action of the action.
+// The bonus data of a participation is a voucher for the reward with the id, the first reward
+// of the catalogue if it is empty.
+func (s *Server) PerformAction(bLevelID string, action int, rewardID string, hashed, sig []byte, pkr string) (token, recoveryToken, bonusData string, err error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
 	bLevel := s.getBonusLevel(bLevelID)
 	if bLevel == nil {
 		return "", "", "", errors.New("no level known with given id")
 	}
+	actionType := getActionType(action)
+	variant := bLevel.actionVariant(action)
+	if actionType == nil || actionType.Handler == nil || variant == nil {
+		return "", "", "", errors.New("action cannot be performed")
+	}
 
 	// check that the hash value fits the signature
 	if len(hashed) == 0 || len(sig) == 0 {
 		return "", "", "", errors.New("hash value or signature is empty")
 	}
-	if err = rsablind.VerifyBlindSignature(&bLevel.ActionVariants[ActionParticipate].SkKey.PublicKey, hashed, sig); err != nil {
+	if err = rsablind.VerifyBlindSignature(&variant.SkKey.PublicKey, hashed, sig); err != nil {
+		return "", "", "", err
+	}
+	// each signature performs the action once, it is kept if no bonus data was generated
+	spentKey := spentHashKey(bLevelID, actionScope(action), hashed)
+	if err = s.spendHash(spentKey); err != nil {
+		return "", "", "", err
+	}
+
+	// generate bonus data
+	if bonusData, err = actionType.Handler(bLevel, rewardID); err != nil {
+		s.releaseHash(spentKey)
 		return "", "", "", err
 	}
 
 	token = crypt.GenerateToken()
-	if err = bLevel.addValidToken(token, ActionParticipate); err != nil {
+	if err = bLevel.addValidToken(token, actionType.Entry); err != nil {
 		return "", "", "", err
 	}
 
 	// generate a new recovery Token
 	recoveryToken = crypt.GenerateToken()
 
-	// generate bonus data
-	bonusData = bLevel.GetBonusData()
-
 	// map the pkr to the bonus data
 	bonusDataPair := &bonusDataPair{Token: token, RecoveryToken: recoveryToken, BonusData: bonusData}
-	bLevel.ActionVariants[ActionParticipate].PkrToBonusData[pkr] = bonusDataPair
-	SaveWrite(StatPkrToBonusData, bLevel.ActionVariants[ActionParticipate])
+	variant.Mux.Lock()
+	variant.PkrToBonusData[pkr] = bonusDataPair
+	SaveWrite(StatPkrToBonusData, variant)
+	variant.Mux.Unlock()
 
 	return token, recoveryToken, bonusData, nil
 }
@@ -383,18 +691,20 @@ func (s *Server) getBonusLevel(levelID string) (bLevel *BonusLevel) {
 // bonus levels
 func (s *Server) GetSystemInformation() ([]*Flight, []*BonusLevel, error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
 	flights := make([]*Flight, 0)
 	bLevels := make([]*BonusLevel, 0)
 	var err error
 
 	// get flight information
-	for fID := range s.flightMap {
-		// only IDs are needed
-		flights = append(flights, &Flight{ID: fID, Bookings: []*Booking{}})
+	s.muxFlights.RLock()
+	for _, flight := range s.flightMap {
+		// the schedules without bookings
+		flights = append(flights, flight.copySchedule())
 	}
+	s.muxFlights.RUnlock()
 	sort.Slice(flights, func(i, j int) bool { return flights[i].ID < flights[j].ID })
 
 	// get bonus level information
@@ -410,10 +720,24 @@ func (s *Server) GetSystemInformation() ([]*Flight, []*BonusLevel, error) {
 // Checks if a given address was set for the last address update. If it was used, then the recovery Token will be
 // returned as well.
 func (s *Server) CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.AddressBundle) (status RecoveryStatus, token string, err error) {
-	bLevelParticipate := s.getBonusLevel(bLevelID).ActionVariants[ActionParticipate]
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	bLevel := s.getBonusLevel(bLevelID)
+	if bLevel == nil {
+		return Failure, "", errors.New("no level known with given id")
+	}
+	bLevel.ActionVariants[ActionParticipate].Mux.Lock()
+	defer bLevel.ActionVariants[ActionParticipate].Mux.Unlock()
+	return recoveryStatus(bLevel, adrBdl)
+}
+
+// Determines the recovery status of an address bundle.
+// The caller has to hold the lock of the participation variant.
+func recoveryStatus(bLevel *BonusLevel, adrBdl *crypt.AddressBundle) (status RecoveryStatus, token string, err error) {
+	bLevelParticipate := bLevel.ActionVariants[ActionParticipate]
 
-	bLevelParticipate.Mux.Lock()
-	defer bLevelParticipate.Mux.Unlock()
 	adr := bLevelParticipate.SeedToAddress[hex.EncodeToString(adrBdl.Seed)]
 	SaveRead(StatSeedToAddress, bLevelParticipate)
 	//bLevelParticipate.Mux.Unlock()
@@ -427,7 +751,7 @@ func (s *Server) CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.AddressBund
 	token = bLevelParticipate.AddressToToken[adr]
 	SaveRead(StatAddressToToken, bLevelParticipate)
 	//bLevelParticipate.Mux.Unlock()
-	if s.getBonusLevel(bLevelID).isTokenValid(token, ActionParticipate) {
+	if bLevel.isTokenValid(token, ActionParticipate) {
 		// the Token is still valid and was not used for participation
 		// => Special case: 1st address update
 		//bLevelParticipate.Mux.Lock()
@@ -467,18 +791,25 @@ func (s *Server) CanBeUsedForRecovery(bLevelID string, adrBdl *crypt.AddressBund
 // Checks if the given address can be used for address update and if the RecoveryToken and the pkr are valid
 func (s *Server) RecoveryTest(bLevelID, recoveryToken, pkr string, adrBdl *crypt.AddressBundle) (token, foundRecoveryToken, bonusData string, err error) {
 	// sync
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
 
 	var adr string
 	var found bool
 	var bData *bonusDataPair
 	var status RecoveryStatus
 
-	bAction := s.BonusList[bLevelID].ActionVariants[ActionParticipate]
+	bLevel := s.getBonusLevel(bLevelID)
+	if bLevel == nil {
+		err = errors.New("no level known with given id")
+		return
+	}
+	bAction := bLevel.ActionVariants[ActionParticipate]
+	bAction.Mux.Lock()
+	defer bAction.Mux.Unlock()
 
 	// the check has to be redone
-	status, _, err = s.CanBeUsedForRecovery(bLevelID, adrBdl)
+	status, _, err = recoveryStatus(bLevel, adrBdl)
 	if err != nil {
 		return
 	}
@@ -542,71 +873,70 @@ func (s *Server) Reset() {
 	sReset := NewServer()
 	s.BonusList = sReset.BonusList
 	s.BonusCodes = sReset.BonusCodes
-	s.flightMap = sReset.flightMap
+	s.flightMap = s.initialFlights()
+	s.bookings = sReset.bookings
+	s.muxCustomers.Lock()
+	s.customerTiers = sReset.customerTiers
+	s.customers = sReset.customers
+	s.sessions = sReset.sessions
+	s.muxCustomers.Unlock()
+	s.muxRedemptions.Lock()
+	s.redemptions = sReset.redemptions
+	s.muxRedemptions.Unlock()
+	s.muxRevocations.Lock()
+	s.pendingRevocations = sReset.pendingRevocations
+	s.revocationFilters = sReset.revocationFilters
+	s.revocationPublicKey, s.revocationSkKey = sReset.revocationPublicKey, sReset.revocationSkKey
+	s.muxRevocations.Unlock()
+	s.coinDenominations = sReset.coinDenominations
+	s.muxCoins.Lock()
+	s.spentCoins = sReset.spentCoins
+	s.muxCoins.Unlock()
+	s.muxHashes.Lock()
+	s.spentHashes = sReset.spentHashes
+	s.muxHashes.Unlock()
 	s.Hierarchy = sReset.Hierarchy
+	s.addDenominations()
 	s.ClientIDs = sReset.ClientIDs
 
 	// reset the statistic also
-	s.CntReqSendBooking = 0
-	s.CntReqGetBookingCode = 0
-	s.CntReqGetSystemInformation = 0
-	s.CntReqBlindSignature = 0
-	s.CntReqSetAddress = 0
-	s.CntReqAccessBonusSystem = 0
-	s.CntReqParticipate = 0
-	s.CntReqCanBesUsedForRecovery = 0
-	s.CntReqRecoveryTest = 0
-	s.CntReqRegister = 0
-	s.CntReqExit = 0
-	s.CntReqStatistic = 0
-	s.CntReqReset = 0
+	s.resetRequestCounters()
 }
 
 func (s *Server) Register() (clientID int, err error) {
-	s.Mux.Lock()
-	defer s.Mux.Unlock()
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	s.muxClients.Lock()
+	defer s.muxClients.Unlock()
 
 	clientID = len(s.ClientIDs)
 	s.ClientIDs = append(s.ClientIDs, clientID)
 	return
 }
 
-// Returns a statistical summary for all action variants of
-// the server's bonus levels
-func (s *Server) GetStatisticSummary() *StatisticSummary {
-	var stat StatisticSummary
-	stat.BLevelToSummary = make(map[string][]StatisticSummaryTuple)
-
-	for bLevelName, bLevel := range s.BonusList {
-		for _, variant := range bLevel.ActionVariants {
-			var statTuple StatisticSummaryTuple
-			statTuple.BonusActionVariant = variant.GetName()
-			statTuple.Statistic = variant.Statistic
-			stat.BLevelToSummary[bLevelName] = append(stat.BLevelToSummary[bLevelName], statTuple)
-		}
-	}
-
-	stat.CntReqSendBooking = s.CntReqSendBooking
-	stat.CntReqGetBookingCode = s.CntReqGetBookingCode
-	stat.CntReqGetSystemInformation = s.CntReqGetSystemInformation
-	stat.CntReqBlindSignature = s.CntReqBlindSignature
-	stat.CntReqSetAddress = s.CntReqSetAddress
-	stat.CntReqAccessBonusSystem = s.CntReqAccessBonusSystem
-	stat.CntReqParticipate = s.CntReqParticipate
-	stat.CntReqCanBesUsedForRecovery = s.CntReqCanBesUsedForRecovery
-	stat.CntReqRecoveryTest = s.CntReqRecoveryTest
-	stat.CntReqRegister = s.CntReqRegister
-	stat.CntReqExit = s.CntReqExit
-	stat.CntReqStatistic = s.CntReqStatistic
-	stat.CntReqReset = s.CntReqReset
-	stat.CntReqGetLastAdrBundle = s.CntReqGetLastAdrBundle
-
-	return &stat
+// Reports whether a client with the id was registered
+func (s *Server) IsRegistered(clientID int) bool {
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+	s.muxClients.Lock()
+	defer s.muxClients.Unlock()
+	// the ids are the indexes of the list
+	return clientID >= 0 && clientID < len(s.ClientIDs)
 }
 
 func (s *Server) GetLastAdrBundle(seed []byte, bLevelID string) (adr string, accountID uint32, err error) {
 	var found bool
-	bLevelParticipate := s.getBonusLevel(bLevelID).ActionVariants[ActionParticipate]
+
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	bLevel := s.getBonusLevel(bLevelID)
+	if bLevel == nil {
+		err = errors.New("no level known with given id")
+		return
+	}
+	bLevelParticipate := bLevel.ActionVariants[ActionParticipate]
 
 	bLevelParticipate.Mux.Lock()
 	defer bLevelParticipate.Mux.Unlock()
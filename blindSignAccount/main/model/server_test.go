@@ -11,9 +11,12 @@ import (
 	"github.com/cryptoballot/rsablind"
 	"sort"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
+var utCustomerName, utCustomerPassword = "utCustomer", "utPassword"
 var utMnemonic = "coil early bronze maze battle any core sweet burger busy cotton impact evoke oven jeans glance clock final eight crowd tool okay mushroom shrimp"
 
 func setupServer() *Server {
@@ -62,19 +65,28 @@ func createValidTestCodes(t *testing.T, server *Server) (codes []string) {
 	return
 }
 
+// Books a flight in the fare class of the low bonus level and returns the earned Token
+func bookLowToken(s *Server, flightID int) (string, error) {
+	tokens, err := s.Booking(flightID, 1, utLowFareClass)
+	if err != nil {
+		return "", err
+	}
+	return tokens[0].Token, nil
+}
+
 func TestServer_Booking(t *testing.T) {
 	s := setupServer()
 	flightID := 0
 	customerID := 12
-	token, err := s.Booking(flightID, customerID, utLowLevelID)
+	tokens, err := s.Booking(flightID, customerID, utLowFareClass)
 	if err != nil {
 		t.Error(err)
 		t.Fail()
 	}
-	if token == "" {
-		t.Error("no Token returned")
-		t.Fail()
+	if len(tokens) != 1 || tokens[0].Token == "" || tokens[0].BLevelID != utLowLevelID {
+		t.Fatal("no Token returned")
 	}
+	token := tokens[0].Token
 	// the server has to know the Token
 	if used, known := s.BonusList[utLowLevelID].ActionVariants[ActionBooking].ValidTokens[token]; !known || used {
 		t.Errorf("Token is unknown (%t) or was marked as used (%t)", known, used)
@@ -103,14 +115,14 @@ func TestServer_Booking(t *testing.T) {
 func TestServer_Booking_Fails(t *testing.T) {
 	s := setupServer()
 	// unknown flight id
-	token, err := s.Booking(-1, 0, utLowLevelID)
-	if token != "" || err == nil {
+	tokens, err := s.Booking(-1, 0, utLowFareClass)
+	if len(tokens) != 0 || err == nil {
 		t.Error(err)
 		t.Fail()
 	}
-	// unknown bonus level
-	token, err = s.Booking(0, 0, utLowLevelID+"_unknown")
-	if token != "" || err == nil {
+	// unknown fare class
+	tokens, err = s.Booking(0, 0, utLowFareClass+"_unknown")
+	if len(tokens) != 0 || err == nil {
 		t.Error(err)
 		t.Fail()
 	}
@@ -119,7 +131,7 @@ func TestServer_Booking_Fails(t *testing.T) {
 func TestServer_GetBookingCode(t *testing.T) {
 	s := setupServer()
 	_, _, hashValue, signature, _ := crypt.GetBlindSignatureTestData("test123456", s.BonusList[utLowLevelID].ActionVariants[ActionBooking].SkKey)
-	code, err := s.GetBookingCode(utLowLevelID, hashValue, signature)
+	code, err := s.GetBookingCode(utLowLevelID, 1, hashValue, signature)
 	if err != nil {
 		t.Error(err)
 		t.Fail()
@@ -710,6 +722,46 @@ func TestServer_Participate_failure(t *testing.T) {
 
 }
 
+func TestServer_Participate_Replay(t *testing.T) {
+	server := setupServer()
+	pkr := "pkr"
+
+	token := generateToken()
+	_, _, hashValue, sig, err := crypt.GetBlindSignatureTestData(token, server.BonusList[utLowLevelID].ActionVariants[ActionParticipate].SkKey)
+	if err != nil {
+		fail(t, err.Error())
+	}
+	if _, _, _, err = server.Participate(utLowLevelID, hashValue, sig, pkr); err != nil {
+		fail(t, err.Error())
+	}
+	// the signature was spent by the participation
+	if _, _, bonusData, err := server.Participate(utLowLevelID, hashValue, sig, pkr); err == nil || bonusData != "" {
+		t.Error("participation replayed with the same signature")
+	}
+}
+
+func TestServer_Participate_VoucherFails(t *testing.T) {
+	server := setupServer()
+	bLevel := server.BonusList[utLowLevelID]
+
+	token := generateToken()
+	_, _, hashValue, sig, err := crypt.GetBlindSignatureTestData(token, bLevel.ActionVariants[ActionParticipate].SkKey)
+	if err != nil {
+		fail(t, err.Error())
+	}
+	// no voucher can be issued without the signing key
+	skKey := bLevel.VoucherSkKey
+	bLevel.VoucherSkKey = nil
+	if _, _, _, err = server.Participate(utLowLevelID, hashValue, sig, "pkr"); err == nil {
+		t.Error("participation without voucher")
+	}
+	// the signature is kept for another try
+	bLevel.VoucherSkKey = skKey
+	if _, _, _, err = server.Participate(utLowLevelID, hashValue, sig, "pkr"); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestServer_GenerateNewBonusCode(t *testing.T) {
 	server := setupServer()
 	code := server.GenerateNewBonusCode(utLowLevelID)
@@ -1279,3 +1331,161 @@ func TestServer_GetStatisticSummary(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestServer_ConcurrentClients(t *testing.T) {
+	const nrClients = 8
+	server := setupServer()
+	var wg sync.WaitGroup
+	errs := make(chan error, nrClients)
+
+	for i := 0; i < nrClients; i++ {
+		wg.Add(1)
+		go func(clientID int) {
+			defer wg.Done()
+			client := NewClientWithAccountID(clientID, utMnemonic, 2, uint32(100+clientID))
+			client.con = &utConnection{server: server}
+			if err := client.GetSystemInformation(); err != nil {
+				errs <- err
+				return
+			}
+			if err := testLogin(client.con); err != nil {
+				errs <- err
+				return
+			}
+			for j := 0; j < 3; j++ {
+				if err := client.Booking(1, utMiddleFareClass); err != nil {
+					errs <- err
+					return
+				}
+			}
+			if err := client.AccessBonusSystem(); err != nil {
+				errs <- err
+				return
+			}
+			for j := 0; j < 2; j++ {
+				if _, err := client.Participate(utLowLevelID); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+		t.Fail()
+	}
+
+	participate := server.BonusList[utLowLevelID].ActionVariants[ActionParticipate]
+	if len(participate.PkrToBonusData) != 2*nrClients {
+		t.Errorf("wrong number of participations %d", len(participate.PkrToBonusData))
+		t.Fail()
+	}
+}
+
+func TestServer_GetBlindSignature_Concurrent(t *testing.T) {
+	const nrRequests = 8
+	server := setupServer()
+	bLevel := server.BonusList[utLowLevelID]
+	token, err := bookLowToken(server, 1)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	blindBundle, _ := crypt.CreateBlindBundle(bLevel.ActionVariants[ActionBooking].PublicKey)
+
+	// a token can be used for one signature only, even by concurrent requests
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	signatures := 0
+	for i := 0; i < nrRequests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := server.GetBlindSignature(utLowLevelID, token, blindBundle.BlindToken, ActionBooking); err == nil {
+				mux.Lock()
+				signatures++
+				mux.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if signatures != 1 {
+		t.Errorf("token used for %d signatures", signatures)
+		t.Fail()
+	}
+}
+
+func TestServer_SetAddress_Concurrent(t *testing.T) {
+	const nrRequests = 4
+	client := setupClient(t)
+	server := client.con.(*utConnection).server
+	adrBdl, keys, tokens, recoveries := testExecuteAccessBonusSystem(server, 0, 11, t)
+	pkr, _ := client.blindRecoveryToken(recoveries[utMiddleLevelID])
+
+	// all requests update to the same address: only one of them may succeed
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	updates := 0
+	for i := 0; i < nrRequests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _, err := testExecuteSetAddressNoErrorHdl(client, server, utMiddleLevelID, tokens[utMiddleLevelID], adrBdl, keys, 12, pkr)
+			if err == nil {
+				mux.Lock()
+				updates++
+				mux.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if updates != 1 {
+		t.Errorf("address was set %d times", updates)
+		t.Fail()
+	}
+}
+
+func BenchmarkServer_BookingSignature(b *testing.B) {
+	server := setupServer()
+	_ = server.AddFlight(&Flight{ID: 1000, Origin: "FRA", Destination: "MUC",
+		FareClasses: []*FareClass{{Name: utLowFareClass, Seats: b.N + 1}}})
+	blindBundle, _ := crypt.CreateBlindBundle(server.BonusList[utLowLevelID].ActionVariants[ActionBooking].PublicKey)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			token, err := bookLowToken(server, 1000)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if _, err = server.GetBlindSignature(utLowLevelID, token, blindBundle.BlindToken, ActionBooking); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkServer_GetBookingCode(b *testing.B) {
+	server := setupServer()
+	// each signature is exchanged once
+	hashValues, signatures := make([][]byte, b.N), make([][]byte, b.N)
+	for i := range hashValues {
+		_, _, hashValues[i], signatures[i], _ = crypt.GetBlindSignatureTestData("test"+strconv.Itoa(i), server.BonusList[utLowLevelID].ActionVariants[ActionBooking].SkKey)
+	}
+	var next int64 = -1
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := atomic.AddInt64(&next, 1)
+			if _, err := server.GetBookingCode(utLowLevelID, 1, hashValues[i], signatures[i]); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"blindSignAccount/main/metrics"
+	"errors"
+	"time"
+)
+
+// Returned if the queue of the signing pool is full, the request should be retried later
+var ErrSignPoolBusy = errors.New("server is busy, retry later")
+
+// A bounded pool for expensive cryptographic jobs like blind signatures and the
+// derivation of addresses from seeds. At most workers jobs run at once, up to
+// queueSize further jobs wait for a free worker. Other jobs are rejected at once.
+type SignPool struct {
+	// a slot is taken by every admitted job, running or waiting
+	admitted chan struct{}
+	// a slot is taken by every running job
+	running chan struct{}
+	// suggested delay for rejected requests
+	RetryAfter time.Duration
+	// number of rejected jobs
+	Rejected metrics.Counter
+	// observes the time a job waits for a free worker
+	wait *metrics.HistogramVec
+}
+
+func NewSignPool(workers, queueSize int, retryAfter time.Duration) *SignPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	return &SignPool{admitted: make(chan struct{}, workers+queueSize),
+		running:    make(chan struct{}, workers),
+		RetryAfter: retryAfter}
+}
+
+// Runs the job as soon as a worker is free. Returns ErrSignPoolBusy without running
+// the job if the queue is full.
+func (p *SignPool) Do(job func() error) error {
+	select {
+	case p.admitted <- struct{}{}:
+	default:
+		p.Rejected.Inc()
+		return ErrSignPoolBusy
+	}
+	defer func() { <-p.admitted }()
+
+	start := time.Now()
+	p.running <- struct{}{}
+	defer func() { <-p.running }()
+	if p.wait != nil {
+		p.wait.Observe(time.Since(start).Seconds())
+	}
+	return job()
+}
+
+// Returns the number of jobs waiting for a free worker
+func (p *SignPool) Depth() int {
+	depth := len(p.admitted) - len(p.running)
+	if depth < 0 {
+		return 0
+	}
+	return depth
+}
+
+func (p *SignPool) Workers() int {
+	return cap(p.running)
+}
+
+func (p *SignPool) QueueSize() int {
+	return cap(p.admitted) - cap(p.running)
+}
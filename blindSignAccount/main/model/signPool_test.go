@@ -0,0 +1,87 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"testing"
+	"time"
+)
+
+// Blocks the only worker of the pool and fills the queue. Release returns when
+// all blocked jobs are done.
+func blockSignPool(pool *SignPool) (release func()) {
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go pool.Do(func() error {
+		close(started)
+		<-done
+		return nil
+	})
+	<-started
+	for i := 0; i < pool.QueueSize(); i++ {
+		go pool.Do(func() error { return nil })
+	}
+	for pool.Depth() < pool.QueueSize() {
+		time.Sleep(time.Millisecond)
+	}
+	return func() {
+		close(done)
+		for len(pool.admitted) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestSignPool_Do(t *testing.T) {
+	pool := NewSignPool(1, 2, time.Second)
+	if pool.Workers() != 1 || pool.QueueSize() != 2 {
+		t.Errorf("wrong size %d/%d", pool.Workers(), pool.QueueSize())
+		t.Fail()
+	}
+	release := blockSignPool(pool)
+	if pool.Depth() != 2 {
+		t.Errorf("wrong queue depth %d", pool.Depth())
+		t.Fail()
+	}
+
+	// a full queue rejects jobs without running them
+	run := false
+	if err := pool.Do(func() error { run = true; return nil }); err != ErrSignPoolBusy || run {
+		t.Errorf("job not rejected: %v", err)
+		t.Fail()
+	}
+	if pool.Rejected.Value() != 1 {
+		t.Errorf("wrong number of rejected jobs %d", pool.Rejected.Value())
+		t.Fail()
+	}
+
+	release()
+	if err := pool.Do(func() error { run = true; return nil }); err != nil || !run {
+		t.Errorf("job not run: %v", err)
+		t.Fail()
+	}
+}
+
+func TestServer_GetBlindSignature_Busy(t *testing.T) {
+	server := setupServer()
+	server.SetSignPool(NewSignPool(1, 0, time.Second))
+	token, _ := bookLowToken(server, 1)
+	blindBundle, _ := crypt.CreateBlindBundle(server.BonusList[utLowLevelID].ActionVariants[ActionBooking].PublicKey)
+
+	release := blockSignPool(server.SignPool)
+	if _, err := server.GetBlindSignature(utLowLevelID, token, blindBundle.BlindToken, ActionBooking); err != ErrSignPoolBusy {
+		t.Errorf("request not rejected: %v", err)
+		t.Fail()
+	}
+	release()
+
+	// the rejected request must not use the token
+	if _, err := server.GetBlindSignature(utLowLevelID, token, blindBundle.BlindToken, ActionBooking); err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+	summary := server.GetStatisticSummary()
+	if summary.SignRejected != 1 || summary.SignWaits == 0 {
+		t.Errorf("wrong statistic of the signing pool: %d rejected, %d waits", summary.SignRejected, summary.SignWaits)
+		t.Fail()
+	}
+}
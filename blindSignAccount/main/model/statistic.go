@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type StatName int
 
 const (
@@ -84,6 +86,15 @@ type StatisticSummary struct {
 	CntReqExit                  int                                `json:"CntReqExit"`
 	CntReqStatistic             int                                `json:"CntReqStatistic"`
 	CntReqReset                 int                                `json:"CntReqReset"`
+	CntReqSearchFlights         int                                `json:"CntReqSearchFlights"`
+	CntReqCancelBooking         int                                `json:"CntReqCancelBooking"`
+	// signing pool: waiting jobs, rejected jobs and the number and total time of waits
+	SignQueueDepth  int     `json:"SignQueueDepth"`
+	SignRejected    int     `json:"SignRejected"`
+	SignWaits       int     `json:"SignWaits"`
+	SignWaitSeconds float64 `json:"SignWaitSeconds"`
+	// requests rejected by the rate limits
+	RateLimited int `json:"RateLimited"`
 }
 
 type StatisticSummaryTuple struct {
@@ -125,3 +136,127 @@ func SaveRead(name StatName, variant *BonusActionVariant) {
 	defer variant.MuxStatistic.Unlock()
 	variant.Statistic[name].NrReads++
 }
+
+// Returns a snapshot of the variant's statistic which is not changed by later requests
+func (variant *BonusActionVariant) CopyStatistic() (statistic [10]*Statistic) {
+	variant.MuxStatistic.Lock()
+	defer variant.MuxStatistic.Unlock()
+	for idx, stat := range variant.Statistic {
+		statCopy := *stat
+		statistic[idx] = &statCopy
+	}
+	return
+}
+
+// A statistic event of the live statistic stream
+type StatisticEvent struct {
+	Time time.Time `json:"Time"`
+	// seconds since the last event
+	Interval float64 `json:"Interval"`
+	// absolute values
+	Summary *StatisticSummary `json:"Summary"`
+	// changes since the last event, map lengths are the current ones
+	Delta *StatisticSummary `json:"Delta"`
+	// reads and writes per second since the last event
+	Rates map[string][]StatisticRateTuple `json:"Rates"`
+}
+
+type StatisticRateTuple struct {
+	BonusActionVariant string          `json:"BonusActionVariant"`
+	Rates              []StatisticRate `json:"Rates"`
+}
+
+type StatisticRate struct {
+	Name       string  `json:"Name"`
+	ReadsPerS  float64 `json:"ReadsPerS"`
+	WritesPerS float64 `json:"WritesPerS"`
+	Length     int     `json:"Length"`
+}
+
+// Creates the event of the current summary. The deltas and rates refer to the summary
+// of the last event.
+func NewStatisticEvent(current, last *StatisticSummary, interval time.Duration) *StatisticEvent {
+	event := &StatisticEvent{Time: time.Now(), Interval: interval.Seconds(), Summary: current,
+		Delta: current.Delta(last), Rates: make(map[string][]StatisticRateTuple)}
+
+	for bLevelName, tuples := range event.Delta.BLevelToSummary {
+		for _, tuple := range tuples {
+			rateTuple := StatisticRateTuple{BonusActionVariant: tuple.BonusActionVariant}
+			for _, stat := range tuple.Statistic {
+				rate := StatisticRate{Name: stat.Name, Length: stat.Length}
+				if event.Interval > 0 {
+					rate.ReadsPerS = float64(stat.NrReads) / event.Interval
+					rate.WritesPerS = float64(stat.NrWrites) / event.Interval
+				}
+				rateTuple.Rates = append(rateTuple.Rates, rate)
+			}
+			event.Rates[bLevelName] = append(event.Rates[bLevelName], rateTuple)
+		}
+	}
+	return event
+}
+
+// Returns the changes of the request counters and of the map reads and writes since
+// the last summary. Map lengths and the queue depth are taken from the current summary.
+func (stat *StatisticSummary) Delta(last *StatisticSummary) *StatisticSummary {
+	if last == nil {
+		last = &StatisticSummary{}
+	}
+	delta := &StatisticSummary{BLevelToSummary: make(map[string][]StatisticSummaryTuple),
+		CntReqGetLastAdrBundle:      counterDelta(stat.CntReqGetLastAdrBundle, last.CntReqGetLastAdrBundle),
+		CntReqSendBooking:           counterDelta(stat.CntReqSendBooking, last.CntReqSendBooking),
+		CntReqGetBookingCode:        counterDelta(stat.CntReqGetBookingCode, last.CntReqGetBookingCode),
+		CntReqGetSystemInformation:  counterDelta(stat.CntReqGetSystemInformation, last.CntReqGetSystemInformation),
+		CntReqBlindSignature:        counterDelta(stat.CntReqBlindSignature, last.CntReqBlindSignature),
+		CntReqSetAddress:            counterDelta(stat.CntReqSetAddress, last.CntReqSetAddress),
+		CntReqAccessBonusSystem:     counterDelta(stat.CntReqAccessBonusSystem, last.CntReqAccessBonusSystem),
+		CntReqParticipate:           counterDelta(stat.CntReqParticipate, last.CntReqParticipate),
+		CntReqCanBesUsedForRecovery: counterDelta(stat.CntReqCanBesUsedForRecovery, last.CntReqCanBesUsedForRecovery),
+		CntReqRecoveryTest:          counterDelta(stat.CntReqRecoveryTest, last.CntReqRecoveryTest),
+		CntReqRegister:              counterDelta(stat.CntReqRegister, last.CntReqRegister),
+		CntReqExit:                  counterDelta(stat.CntReqExit, last.CntReqExit),
+		CntReqStatistic:             counterDelta(stat.CntReqStatistic, last.CntReqStatistic),
+		CntReqReset:                 counterDelta(stat.CntReqReset, last.CntReqReset),
+		CntReqSearchFlights:         counterDelta(stat.CntReqSearchFlights, last.CntReqSearchFlights),
+		CntReqCancelBooking:         counterDelta(stat.CntReqCancelBooking, last.CntReqCancelBooking),
+		SignQueueDepth:              stat.SignQueueDepth,
+		SignRejected:                counterDelta(stat.SignRejected, last.SignRejected),
+		SignWaits:                   counterDelta(stat.SignWaits, last.SignWaits),
+		SignWaitSeconds:             stat.SignWaitSeconds - last.SignWaitSeconds,
+		RateLimited:                 counterDelta(stat.RateLimited, last.RateLimited),
+	}
+
+	for bLevelName, tuples := range stat.BLevelToSummary {
+		for _, tuple := range tuples {
+			deltaTuple := StatisticSummaryTuple{BonusActionVariant: tuple.BonusActionVariant}
+			lastStatistic := last.findStatistic(bLevelName, tuple.BonusActionVariant)
+			for idx, cur := range tuple.Statistic {
+				deltaStat := *cur
+				if lastStatistic[idx] != nil {
+					deltaStat.NrReads = counterDelta(cur.NrReads, lastStatistic[idx].NrReads)
+					deltaStat.NrWrites = counterDelta(cur.NrWrites, lastStatistic[idx].NrWrites)
+				}
+				deltaTuple.Statistic[idx] = &deltaStat
+			}
+			delta.BLevelToSummary[bLevelName] = append(delta.BLevelToSummary[bLevelName], deltaTuple)
+		}
+	}
+	return delta
+}
+
+// Counters start again at zero after a reset of the server
+func counterDelta(current, last int) int {
+	if current < last {
+		return current
+	}
+	return current - last
+}
+
+func (stat *StatisticSummary) findStatistic(bLevelName, variantName string) [10]*Statistic {
+	for _, tuple := range stat.BLevelToSummary[bLevelName] {
+		if tuple.BonusActionVariant == variantName {
+			return tuple.Statistic
+		}
+	}
+	return [10]*Statistic{}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNewStatisticArray(t *testing.T) {
@@ -82,3 +83,47 @@ func TestSaveReadWrite(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestStatisticSummary_Delta(t *testing.T) {
+	server := NewServer()
+	variant := server.BonusList[utLowLevelID].ActionVariants[ActionBooking]
+
+	server.CntReqSendBooking = 2
+	SaveRead(StatSeedToAddress, variant)
+	last := server.GetStatisticSummary()
+
+	server.CntReqSendBooking = 5
+	variant.SeedToAddress["seed"] = "adr1"
+	SaveWrite(StatSeedToAddress, variant)
+	SaveRead(StatSeedToAddress, variant)
+	SaveRead(StatSeedToAddress, variant)
+	event := NewStatisticEvent(server.GetStatisticSummary(), last, 2*time.Second)
+
+	if event.Delta.CntReqSendBooking != 3 || event.Summary.CntReqSendBooking != 5 {
+		t.Errorf("wrong booking counter delta %d", event.Delta.CntReqSendBooking)
+		t.Fail()
+	}
+	stat := event.Delta.findStatistic(utLowLevelID, variant.GetName())[StatSeedToAddress]
+	if stat.NrReads != 2 || stat.NrWrites != 1 || stat.Length != 1 {
+		t.Errorf("wrong delta: reads %d, writes %d, length %d", stat.NrReads, stat.NrWrites, stat.Length)
+		t.Fail()
+	}
+	// the last summary is a snapshot
+	if last.findStatistic(utLowLevelID, variant.GetName())[StatSeedToAddress].NrReads != 1 {
+		t.Error("summary is changed by later reads")
+		t.Fail()
+	}
+	for _, tuple := range event.Rates[utLowLevelID] {
+		if tuple.BonusActionVariant == variant.GetName() && tuple.Rates[StatSeedToAddress].ReadsPerS != 1 {
+			t.Errorf("wrong read rate %f", tuple.Rates[StatSeedToAddress].ReadsPerS)
+			t.Fail()
+		}
+	}
+
+	// counters start at zero after a reset
+	server.Reset()
+	if delta := server.GetStatisticSummary().Delta(event.Summary); delta.CntReqSendBooking != 0 {
+		t.Errorf("negative delta after reset: %d", delta.CntReqSendBooking)
+		t.Fail()
+	}
+}
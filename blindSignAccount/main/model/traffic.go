@@ -0,0 +1,100 @@
+package model
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The traffic of a connection, e.g. to compare the encodings in load tests
+type Traffic struct {
+	Requests int64
+	// sizes of the request and response bodies
+	BytesSent     int64
+	BytesReceived int64
+	// summed time from sending a request until its response body is read or closed
+	Duration time.Duration
+}
+
+// Returns the mean time of a request
+func (t Traffic) MeanLatency() time.Duration {
+	if t.Requests == 0 {
+		return 0
+	}
+	return t.Duration / time.Duration(t.Requests)
+}
+
+func (t Traffic) String() string {
+	return "requests=" + strconv.FormatInt(t.Requests, 10) +
+		" sent=" + strconv.FormatInt(t.BytesSent, 10) +
+		" received=" + strconv.FormatInt(t.BytesReceived, 10) +
+		" latency=" + t.MeanLatency().String()
+}
+
+// A transport which counts the traffic of all requests
+type trafficTransport struct {
+	base    http.RoundTripper
+	mux     sync.Mutex
+	traffic Traffic
+}
+
+func (tt *trafficTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := tt.base.RoundTrip(req)
+	if err != nil {
+		tt.add(Traffic{Requests: 1, BytesSent: req.ContentLength, Duration: time.Since(start)})
+		return nil, err
+	}
+	resp.Body = &trafficBody{ReadCloser: resp.Body, transport: tt, start: start, sent: req.ContentLength}
+	return resp, nil
+}
+
+func (tt *trafficTransport) add(traffic Traffic) {
+	tt.mux.Lock()
+	defer tt.mux.Unlock()
+	tt.traffic.Requests += traffic.Requests
+	tt.traffic.BytesSent += traffic.BytesSent
+	tt.traffic.BytesReceived += traffic.BytesReceived
+	tt.traffic.Duration += traffic.Duration
+}
+
+func (tt *trafficTransport) get() Traffic {
+	tt.mux.Lock()
+	defer tt.mux.Unlock()
+	return tt.traffic
+}
+
+// A response body which adds the request to the traffic when it is read completely or closed
+type trafficBody struct {
+	io.ReadCloser
+	transport *trafficTransport
+	start     time.Time
+	sent      int64
+	received  int64
+	counted   bool
+}
+
+func (body *trafficBody) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+	body.received += int64(n)
+	if err == io.EOF {
+		body.count()
+	}
+	return n, err
+}
+
+func (body *trafficBody) Close() error {
+	body.count()
+	return body.ReadCloser.Close()
+}
+
+func (body *trafficBody) count() {
+	if body.counted {
+		return
+	}
+	body.counted = true
+	body.transport.add(Traffic{Requests: 1, BytesSent: body.sent, BytesReceived: body.received,
+		Duration: time.Since(body.start)})
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrafficTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(append(body, body...))
+	}))
+	defer ts.Close()
+
+	traffic := &trafficTransport{base: http.DefaultTransport}
+	client := &http.Client{Transport: traffic}
+
+	// a body read completely is counted without closing it
+	resp, err := client.Post(ts.URL, MIMEJSON, strings.NewReader("12345"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = ioutil.ReadAll(resp.Body)
+	if got := traffic.get(); got.Requests != 1 || got.BytesSent != 5 || got.BytesReceived != 10 || got.Duration <= 0 {
+		t.Errorf("wrong traffic: %s", got)
+	}
+
+	// and counted once only
+	_ = resp.Body.Close()
+	if resp, err = client.Get(ts.URL); err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if got := traffic.get(); got.Requests != 2 || got.BytesSent != 5 || got.BytesReceived != 10 {
+		t.Errorf("wrong traffic: %s", got)
+	}
+}
+
+func TestTraffic_MeanLatency(t *testing.T) {
+	if latency := (Traffic{}).MeanLatency(); latency != 0 {
+		t.Errorf("wrong latency without requests: %s", latency)
+	}
+	if latency := (Traffic{Requests: 4, Duration: time.Second}).MeanLatency(); latency != time.Second/4 {
+		t.Errorf("wrong latency: %s", latency)
+	}
+}
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/cryptoballot/rsablind"
+	"strconv"
+	"time"
+)
+
+// Codes are transferred anonymously: the receiver blinds a new Token for the bonus level and the points
+// of the code. The sender exchanges the code at the server for the blind signature of the Token, which
+// only the receiver can unblind. The receiver gets a fresh code for the signature like for a booking.
+
+// The request of the receiver of a code. It is passed to the sender by other means, e.g. a message.
+type TransferRequest struct {
+	BLevelID   string   `json:"bLevelID"`
+	Points     int      `json:"points"`
+	BlindToken HexBytes `json:"blindToken"`
+}
+
+// Spends a code and signs the blind Token of the receiver by the key of the code's denomination.
+// The code has to be valid for the bonus level and worth the points. Returns the blind signature.
+func (s *Server) TransferCode(code, bLevelID string, points int, blindToken []byte) (string, error) {
+	// sync
+	s.Mux.RLock()
+	defer s.Mux.RUnlock()
+
+	if len(blindToken) == 0 {
+		return "", errors.New("blind token is empty")
+	}
+	bCode, err := s.takeCode(code, bLevelID, points)
+	if err != nil {
+		return "", err
+	}
+
+	// the code is restored if the Token cannot be signed
+	var blindSig []byte
+	err = s.SignPool.Do(func() (err error) {
+		blindSig, err = rsablind.BlindSign(bCode.ValidFor.bookingKey(bCode.Value()), blindToken)
+		return
+	})
+	if err != nil {
+		s.restoreCode(bCode)
+		return "", err
+	}
+	s.revoke(code)
+	return base64.URLEncoding.EncodeToString(blindSig), nil
+}
+
+// Checks that a code is valid for the bonus level and worth the points and marks it as used
+func (s *Server) takeCode(code, bLevelID string, points int) (*BonusCode, error) {
+	s.muxCodes.Lock()
+	defer s.muxCodes.Unlock()
+
+	bCode, err := s.checkCode(code, bLevelID)
+	if err != nil {
+		return nil, err
+	}
+	if points < 1 {
+		points = 1
+	}
+	if bCode.Value() != points {
+		return nil, errors.New("code is not worth " + strconv.Itoa(points) + " points")
+	}
+	s.BonusCodes[code] = nil
+	return bCode, nil
+}
+
+// Returns a code which is unused, activated, not expired and issued for the bonus level.
+// The caller has to hold the lock of the codes.
+func (s *Server) checkCode(code, bLevelID string) (*BonusCode, error) {
+	bCode := s.BonusCodes[code]
+	if bCode == nil {
+		return nil, errors.New("code is not valid")
+	}
+	if bCode.ValidFor.BonusID != bLevelID {
+		return nil, errors.New("code is not valid for bonus level '" + bLevelID + "'")
+	}
+	if !bCode.isActive(time.Now()) {
+		return nil, errors.New("code is not activated yet")
+	}
+	if len(bCode.GetValidBonusLevels()) == 0 {
+		return nil, errors.New("code is expired")
+	}
+	return bCode, nil
+}
+
+func (s *Server) restoreCode(bCode *BonusCode) {
+	s.muxCodes.Lock()
+	defer s.muxCodes.Unlock()
+	s.BonusCodes[bCode.CodeID] = bCode
+}
+
+// Creates the request for a code of a bonus level worth the given points. The blinding
+// is kept until the transfer is received.
+func (c *Client) RequestTransfer(bLevelID string, points int) (*TransferRequest, error) {
+	bLevel := c.BonusLevels[bLevelID]
+	if bLevel == nil {
+		return nil, errors.New("bonus level does not exist")
+	}
+	if points < 1 {
+		points = 1
+	}
+	publicKey := bLevel.BookingPublicKey(points)
+	if publicKey == nil {
+		return nil, errors.New("unknown denomination of " + strconv.Itoa(points) + " points")
+	}
+	blindBundle, err := crypt.CreateBlindBundle(*publicKey)
+	if err != nil {
+		return nil, err
+	}
+	c.pendingTransfers[hex.EncodeToString(blindBundle.BlindToken)] = blindBundle
+	return &TransferRequest{BLevelID: bLevelID, Points: points, BlindToken: blindBundle.BlindToken}, nil
+}
+
+// Transfers a code of the client to the receiver of the request. The code is removed from the
+// client's codes. Returns the blind signature for the receiver.
+func (c *Client) TransferCode(codeID string, request *TransferRequest) (string, error) {
+	for i, bCode := range c.BonusCodes {
+		if bCode.CodeID != codeID {
+			continue
+		}
+		if bCode.ValidFor == nil || bCode.ValidFor.BonusID != request.BLevelID || bCode.Value() != request.Points {
+			return "", errors.New("code does not fit the transfer request")
+		}
+		blindSig, err := c.con.TransferCode(codeID, request.BLevelID, request.Points, request.BlindToken)
+		if err != nil {
+			return "", err
+		}
+		c.BonusCodes = append(c.BonusCodes[:i], c.BonusCodes[i+1:]...)
+		return blindSig, nil
+	}
+	return "", errors.New("unknown code")
+}
+
+// Receives the code of a transfer request by the blind signature of the sender
+func (c *Client) ReceiveTransfer(request *TransferRequest, blindSig string) (*BonusCode, error) {
+	bLevel := c.BonusLevels[request.BLevelID]
+	if bLevel == nil {
+		return nil, errors.New("bonus level does not exist")
+	}
+	blindBundle := c.pendingTransfers[hex.EncodeToString(request.BlindToken)]
+	if blindBundle == nil {
+		return nil, errors.New("unknown transfer request")
+	}
+	rawSig, err := base64.URLEncoding.DecodeString(blindSig)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := bLevel.BookingPublicKey(request.Points)
+	if publicKey == nil {
+		return nil, errors.New("unknown denomination of " + strconv.Itoa(request.Points) + " points")
+	}
+	signature := rsablind.Unblind(publicKey, rawSig, blindBundle.UnBlinder)
+	if err = rsablind.VerifyBlindSignature(publicKey, blindBundle.HashValue, signature); err != nil {
+		return nil, errors.New("transfer has an invalid signature")
+	}
+
+	code, err := c.con.GetBookingCode(request.BLevelID, request.Points, blindBundle.HashValue, signature)
+	if err != nil {
+		return nil, err
+	}
+	delete(c.pendingTransfers, hex.EncodeToString(request.BlindToken))
+
+	bCode := NewBonusCodeWithID(code, bLevel)
+	bCode.Points = request.Points
+	c.BonusCodes = append(c.BonusCodes, bCode)
+	return bCode, nil
+}
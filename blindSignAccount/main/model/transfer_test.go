@@ -0,0 +1,103 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"github.com/cryptoballot/rsablind"
+	"testing"
+)
+
+func TestServer_TransferCode(t *testing.T) {
+	server := NewServer()
+	bLevel := server.BonusList[utLowLevelID]
+	bCode := server.generateBonusCode(utLowLevelID, 1)
+	blindBundle, _ := crypt.CreateBlindBundle(*bLevel.BookingPublicKey(1))
+
+	if _, err := server.TransferCode(bCode.CodeID, utHighLevelID, 1, blindBundle.BlindToken); err == nil {
+		t.Error("code transferred for another bonus level")
+	}
+	if _, err := server.TransferCode(bCode.CodeID, utLowLevelID, 3, blindBundle.BlindToken); err == nil {
+		t.Error("code transferred for other points")
+	}
+	if _, err := server.TransferCode(bCode.CodeID, utLowLevelID, 1, nil); err == nil {
+		t.Error("code transferred without a blind token")
+	}
+	blindSig, err := server.TransferCode(bCode.CodeID, utLowLevelID, 1, blindBundle.BlindToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the old code is spent and revoked
+	if _, err = server.TransferCode(bCode.CodeID, utLowLevelID, 1, blindBundle.BlindToken); err == nil {
+		t.Error("code transferred twice")
+	}
+	if server.BonusCodes[bCode.CodeID] != nil {
+		t.Error("transferred code is still valid")
+	}
+	filter, _ := server.SealRevocations()
+	if !filter.Contains(bCode.CodeID) {
+		t.Error("transferred code is not revoked")
+	}
+
+	// the receiver gets a fresh code for the unblinded signature
+	rawSig, _ := base64.URLEncoding.DecodeString(blindSig)
+	signature := rsablind.Unblind(bLevel.BookingPublicKey(1), rawSig, blindBundle.UnBlinder)
+	code, err := server.GetBookingCode(utLowLevelID, 1, blindBundle.HashValue, signature)
+	if err != nil || code == bCode.CodeID {
+		t.Fatalf("no fresh code received: %v", err)
+	}
+	if server.BonusCodes[code] == nil {
+		t.Error("received code is not valid")
+	}
+
+	// the signature of the transfer is exchanged for one code only
+	if _, err = server.GetBookingCode(utLowLevelID, 1, blindBundle.HashValue, signature); err == nil {
+		t.Error("transfer signature exchanged twice")
+	}
+}
+
+func TestClient_TransferCode(t *testing.T) {
+	sender := setupClient(t)
+	if err := testLogin(sender.con); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Booking(1, utLowFareClass); err != nil || len(sender.BonusCodes) != 1 {
+		t.Fatalf("no code booked: %v", err)
+	}
+	code := sender.BonusCodes[0]
+
+	receiver := NewClient(2, utMnemonic, 3)
+	receiver.con = sender.con
+	if err := receiver.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	request, err := receiver.RequestTransfer(utLowLevelID, code.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sender.TransferCode("unknown", request); err == nil {
+		t.Error("unknown code transferred")
+	}
+	if _, err = sender.TransferCode(code.CodeID, &TransferRequest{BLevelID: utHighLevelID, Points: 1,
+		BlindToken: request.BlindToken}); err == nil || len(sender.BonusCodes) != 1 {
+		t.Error("code transferred for a wrong request")
+	}
+
+	blindSig, err := sender.TransferCode(code.CodeID, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.BonusCodes) != 0 {
+		t.Error("transferred code is kept by the sender")
+	}
+	received, err := receiver.ReceiveTransfer(request, blindSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received.CodeID == code.CodeID || received.ValidFor != receiver.BonusLevels[utLowLevelID] || len(receiver.BonusCodes) != 1 {
+		t.Errorf("wrong received code %v", received)
+	}
+	if _, err = receiver.ReceiveTransfer(request, blindSig); err == nil {
+		t.Error("transfer received twice")
+	}
+}
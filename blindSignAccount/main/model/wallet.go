@@ -0,0 +1,229 @@
+package model
+
+import (
+	"blindSignAccount/main/crypt"
+	"encoding/base64"
+	"errors"
+	"github.com/cryptoballot/rsablind"
+	"sort"
+	"strconv"
+)
+
+// Returns the coin denominations of the server, they are loaded once
+func (c *Client) getCoinDenominations() ([]*CoinDenomination, error) {
+	if c.coinDenominations == nil {
+		denominations, err := c.con.GetCoinDenominations()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(denominations, func(i, j int) bool { return denominations[i].Value < denominations[j].Value })
+		c.coinDenominations = denominations
+	}
+	return c.coinDenominations, nil
+}
+
+func (c *Client) coinDenomination(value int) *CoinDenomination {
+	for _, denomination := range c.coinDenominations {
+		if denomination.Value == value {
+			return denomination
+		}
+	}
+	return nil
+}
+
+// Splits a value into the fewest coins of the denominations
+func (c *Client) splitIntoCoins(value int) ([]int, error) {
+	denominations, err := c.getCoinDenominations()
+	if err != nil {
+		return nil, err
+	}
+	var values []int
+	for i := len(denominations) - 1; i >= 0 && value > 0; i-- {
+		for ; value >= denominations[i].Value; value -= denominations[i].Value {
+			values = append(values, denominations[i].Value)
+		}
+	}
+	if value > 0 {
+		return nil, errors.New("no coin denominations for " + strconv.Itoa(value) + " points")
+	}
+	return values, nil
+}
+
+// Creates a blind coin per value
+func (c *Client) blindCoins(values []int) ([]*crypt.BlindBundle, []*BlindCoin, error) {
+	bundles := make([]*crypt.BlindBundle, 0, len(values))
+	blindCoins := make([]*BlindCoin, 0, len(values))
+	for _, value := range values {
+		bundle, err := crypt.CreateBlindBundle(c.coinDenomination(value).PublicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		bundles = append(bundles, bundle)
+		blindCoins = append(blindCoins, &BlindCoin{Value: value, BlindToken: bundle.BlindToken})
+	}
+	return bundles, blindCoins, nil
+}
+
+// Unblinds the signatures of the blind coins and checks them by the keys of the denominations
+func (c *Client) unblindCoins(bundles []*crypt.BlindBundle, blindCoins []*BlindCoin, blindSigs []string) ([]*Coin, error) {
+	if len(blindSigs) != len(blindCoins) {
+		return nil, errors.New("received " + strconv.Itoa(len(blindSigs)) + " signatures for " +
+			strconv.Itoa(len(blindCoins)) + " coins")
+	}
+	coins := make([]*Coin, 0, len(blindCoins))
+	for i, blindCoin := range blindCoins {
+		blindSig, err := base64.URLEncoding.DecodeString(blindSigs[i])
+		if err != nil {
+			return nil, err
+		}
+		publicKey := &c.coinDenomination(blindCoin.Value).PublicKey
+		signature := rsablind.Unblind(publicKey, blindSig, bundles[i].UnBlinder)
+		if err = rsablind.VerifyBlindSignature(publicKey, bundles[i].HashValue, signature); err != nil {
+			return nil, errors.New("coin has an invalid signature")
+		}
+		coins = append(coins, &Coin{Value: blindCoin.Value, Serial: bundles[i].Token, Signature: signature})
+	}
+	return coins, nil
+}
+
+// Withdraws coins worth the points of a booking Token instead of a code
+func (c *Client) WithdrawCoins(token *EarnedToken) error {
+	if token.Token == "" {
+		return errors.New("no Token received for booking")
+	}
+	points := token.Points
+	if points < 1 {
+		points = 1
+	}
+	values, err := c.splitIntoCoins(points)
+	if err != nil {
+		return err
+	}
+	bundles, blindCoins, err := c.blindCoins(values)
+	if err != nil {
+		return err
+	}
+	blindSigs, err := c.con.WithdrawCoins(token.BLevelID, token.Token, blindCoins)
+	if err != nil {
+		return err
+	}
+	coins, err := c.unblindCoins(bundles, blindCoins, blindSigs)
+	if err != nil {
+		return err
+	}
+	c.Coins = append(c.Coins, coins...)
+	return nil
+}
+
+// Creates a new booking of the logged in customer and withdraws coins for every earned Token
+func (c *Client) BookingWithCoins(flightID int, fareClass string) error {
+	tokens, err := c.con.SendBooking(flightID, fareClass)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if err = c.WithdrawCoins(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the points of the client's coins
+func (c *Client) Balance() int {
+	balance := 0
+	for _, coin := range c.Coins {
+		balance += coin.Value
+	}
+	return balance
+}
+
+// Selects coins which pay the price. The largest coins which fit are taken, a remaining
+// difference is paid by the smallest coin which is left. Returns the value of the coins.
+func (c *Client) selectCoins(price int) ([]*Coin, int, error) {
+	coins := append([]*Coin{}, c.Coins...)
+	sort.SliceStable(coins, func(i, j int) bool { return coins[i].Value > coins[j].Value })
+
+	var selected, left []*Coin
+	value := 0
+	for _, coin := range coins {
+		if value+coin.Value <= price {
+			selected = append(selected, coin)
+			value += coin.Value
+		} else {
+			left = append(left, coin)
+		}
+	}
+	if value < price {
+		if len(left) == 0 {
+			return nil, 0, errors.New("coins worth " + strconv.Itoa(c.Balance()) + " points do not pay " +
+				strconv.Itoa(price) + " points")
+		}
+		selected = append(selected, left[len(left)-1])
+		value += left[len(left)-1].Value
+	}
+	return selected, value, nil
+}
+
+// Spends coins for a reward of the catalogue of a bonus level. The change is received as new
+// coins. Returns the voucher of the reward after checking its signature.
+func (c *Client) SpendCoins(bLevelID, rewardID string) (*Voucher, error) {
+	bLevel := c.BonusLevels[bLevelID]
+	if bLevel == nil {
+		return nil, errors.New("bonus level does not exist")
+	}
+	reward, err := bLevel.getReward(rewardID)
+	if err != nil {
+		return nil, err
+	}
+	if reward.Points < 1 {
+		return nil, errors.New("reward '" + reward.ID + "' cannot be bought with coins")
+	}
+	coins, value, err := c.selectCoins(reward.Points)
+	if err != nil {
+		return nil, err
+	}
+	values, err := c.splitIntoCoins(value - reward.Points)
+	if err != nil {
+		return nil, err
+	}
+	bundles, blindChange, err := c.blindCoins(values)
+	if err != nil {
+		return nil, err
+	}
+
+	bonusData, blindSigs, err := c.con.SpendCoins(coins, bLevelID, reward.ID, blindChange)
+	if err != nil {
+		return nil, err
+	}
+	c.removeCoins(coins)
+	change, err := c.unblindCoins(bundles, blindChange, blindSigs)
+	if err != nil {
+		return nil, err
+	}
+	c.Coins = append(c.Coins, change...)
+
+	voucher, err := ParseVoucher(bonusData)
+	if err != nil {
+		return nil, err
+	}
+	if err = voucher.Verify(bLevel.VoucherPublicKey); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+func (c *Client) removeCoins(spent []*Coin) {
+	isSpent := make(map[string]bool, len(spent))
+	for _, coin := range spent {
+		isSpent[coin.Serial] = true
+	}
+	coins := c.Coins[:0]
+	for _, coin := range c.Coins {
+		if !isSpent[coin.Serial] {
+			coins = append(coins, coin)
+		}
+	}
+	c.Coins = coins
+}
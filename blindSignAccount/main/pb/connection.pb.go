@@ -0,0 +1,2926 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: connection.proto
+
+package pb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{0}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+type AddressBundle struct {
+	Seed                 []byte   `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	AccountId            uint32   `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AddressId            uint32   `protobuf:"varint,3,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Address              string   `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressBundle) Reset()         { *m = AddressBundle{} }
+func (m *AddressBundle) String() string { return proto.CompactTextString(m) }
+func (*AddressBundle) ProtoMessage()    {}
+func (*AddressBundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{1}
+}
+
+func (m *AddressBundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressBundle.Unmarshal(m, b)
+}
+func (m *AddressBundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressBundle.Marshal(b, m, deterministic)
+}
+func (m *AddressBundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressBundle.Merge(m, src)
+}
+func (m *AddressBundle) XXX_Size() int {
+	return xxx_messageInfo_AddressBundle.Size(m)
+}
+func (m *AddressBundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressBundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressBundle proto.InternalMessageInfo
+
+func (m *AddressBundle) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *AddressBundle) GetAccountId() uint32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *AddressBundle) GetAddressId() uint32 {
+	if m != nil {
+		return m.AddressId
+	}
+	return 0
+}
+
+func (m *AddressBundle) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type PublicKey struct {
+	N                    []byte   `protobuf:"bytes,1,opt,name=n,proto3" json:"n,omitempty"`
+	E                    int64    `protobuf:"varint,2,opt,name=e,proto3" json:"e,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublicKey) Reset()         { *m = PublicKey{} }
+func (m *PublicKey) String() string { return proto.CompactTextString(m) }
+func (*PublicKey) ProtoMessage()    {}
+func (*PublicKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{2}
+}
+
+func (m *PublicKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublicKey.Unmarshal(m, b)
+}
+func (m *PublicKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublicKey.Marshal(b, m, deterministic)
+}
+func (m *PublicKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublicKey.Merge(m, src)
+}
+func (m *PublicKey) XXX_Size() int {
+	return xxx_messageInfo_PublicKey.Size(m)
+}
+func (m *PublicKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublicKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublicKey proto.InternalMessageInfo
+
+func (m *PublicKey) GetN() []byte {
+	if m != nil {
+		return m.N
+	}
+	return nil
+}
+
+func (m *PublicKey) GetE() int64 {
+	if m != nil {
+		return m.E
+	}
+	return 0
+}
+
+type ActionVariant struct {
+	VariantId            int32      `protobuf:"varint,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	PublicKey            *PublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Name                 string     `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ActionVariant) Reset()         { *m = ActionVariant{} }
+func (m *ActionVariant) String() string { return proto.CompactTextString(m) }
+func (*ActionVariant) ProtoMessage()    {}
+func (*ActionVariant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{3}
+}
+
+func (m *ActionVariant) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionVariant.Unmarshal(m, b)
+}
+func (m *ActionVariant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionVariant.Marshal(b, m, deterministic)
+}
+func (m *ActionVariant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionVariant.Merge(m, src)
+}
+func (m *ActionVariant) XXX_Size() int {
+	return xxx_messageInfo_ActionVariant.Size(m)
+}
+func (m *ActionVariant) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionVariant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionVariant proto.InternalMessageInfo
+
+func (m *ActionVariant) GetVariantId() int32 {
+	if m != nil {
+		return m.VariantId
+	}
+	return 0
+}
+
+func (m *ActionVariant) GetPublicKey() *PublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *ActionVariant) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type Denomination struct {
+	Points               int32      `protobuf:"varint,1,opt,name=points,proto3" json:"points,omitempty"`
+	PublicKey            *PublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Denomination) Reset()         { *m = Denomination{} }
+func (m *Denomination) String() string { return proto.CompactTextString(m) }
+func (*Denomination) ProtoMessage()    {}
+func (*Denomination) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{4}
+}
+
+func (m *Denomination) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Denomination.Unmarshal(m, b)
+}
+func (m *Denomination) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Denomination.Marshal(b, m, deterministic)
+}
+func (m *Denomination) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Denomination.Merge(m, src)
+}
+func (m *Denomination) XXX_Size() int {
+	return xxx_messageInfo_Denomination.Size(m)
+}
+func (m *Denomination) XXX_DiscardUnknown() {
+	xxx_messageInfo_Denomination.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Denomination proto.InternalMessageInfo
+
+func (m *Denomination) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *Denomination) GetPublicKey() *PublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type Reward struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Value                int32    `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	ValidDays            int32    `protobuf:"varint,5,opt,name=valid_days,json=validDays,proto3" json:"valid_days,omitempty"`
+	Points               int32    `protobuf:"varint,6,opt,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Reward) Reset()         { *m = Reward{} }
+func (m *Reward) String() string { return proto.CompactTextString(m) }
+func (*Reward) ProtoMessage()    {}
+func (*Reward) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{5}
+}
+
+func (m *Reward) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reward.Unmarshal(m, b)
+}
+func (m *Reward) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reward.Marshal(b, m, deterministic)
+}
+func (m *Reward) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reward.Merge(m, src)
+}
+func (m *Reward) XXX_Size() int {
+	return xxx_messageInfo_Reward.Size(m)
+}
+func (m *Reward) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reward.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reward proto.InternalMessageInfo
+
+func (m *Reward) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Reward) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Reward) GetValue() int32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Reward) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Reward) GetValidDays() int32 {
+	if m != nil {
+		return m.ValidDays
+	}
+	return 0
+}
+
+func (m *Reward) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+type BonusLevel struct {
+	BonusId              string           `protobuf:"bytes,1,opt,name=bonus_id,json=bonusId,proto3" json:"bonus_id,omitempty"`
+	ValidDuration        int32            `protobuf:"varint,2,opt,name=valid_duration,json=validDuration,proto3" json:"valid_duration,omitempty"`
+	MinPoints            int32            `protobuf:"varint,3,opt,name=min_points,json=minPoints,proto3" json:"min_points,omitempty"`
+	ActionVariants       []*ActionVariant `protobuf:"bytes,4,rep,name=action_variants,json=actionVariants,proto3" json:"action_variants,omitempty"`
+	LowerLevels          []*BonusLevel    `protobuf:"bytes,5,rep,name=lower_levels,json=lowerLevels,proto3" json:"lower_levels,omitempty"`
+	Denominations        []*Denomination  `protobuf:"bytes,6,rep,name=denominations,proto3" json:"denominations,omitempty"`
+	Rewards              []*Reward        `protobuf:"bytes,7,rep,name=rewards,proto3" json:"rewards,omitempty"`
+	VoucherPublicKey     []byte           `protobuf:"bytes,8,opt,name=voucher_public_key,json=voucherPublicKey,proto3" json:"voucher_public_key,omitempty"`
+	ExchangeRates        map[string]int32 `protobuf:"bytes,9,rep,name=exchange_rates,json=exchangeRates,proto3" json:"exchange_rates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MinActivationDelay   int64            `protobuf:"varint,10,opt,name=min_activation_delay,json=minActivationDelay,proto3" json:"min_activation_delay,omitempty"`
+	MaxActivationDelay   int64            `protobuf:"varint,11,opt,name=max_activation_delay,json=maxActivationDelay,proto3" json:"max_activation_delay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BonusLevel) Reset()         { *m = BonusLevel{} }
+func (m *BonusLevel) String() string { return proto.CompactTextString(m) }
+func (*BonusLevel) ProtoMessage()    {}
+func (*BonusLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{6}
+}
+
+func (m *BonusLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BonusLevel.Unmarshal(m, b)
+}
+func (m *BonusLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BonusLevel.Marshal(b, m, deterministic)
+}
+func (m *BonusLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BonusLevel.Merge(m, src)
+}
+func (m *BonusLevel) XXX_Size() int {
+	return xxx_messageInfo_BonusLevel.Size(m)
+}
+func (m *BonusLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_BonusLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BonusLevel proto.InternalMessageInfo
+
+func (m *BonusLevel) GetBonusId() string {
+	if m != nil {
+		return m.BonusId
+	}
+	return ""
+}
+
+func (m *BonusLevel) GetValidDuration() int32 {
+	if m != nil {
+		return m.ValidDuration
+	}
+	return 0
+}
+
+func (m *BonusLevel) GetMinPoints() int32 {
+	if m != nil {
+		return m.MinPoints
+	}
+	return 0
+}
+
+func (m *BonusLevel) GetActionVariants() []*ActionVariant {
+	if m != nil {
+		return m.ActionVariants
+	}
+	return nil
+}
+
+func (m *BonusLevel) GetLowerLevels() []*BonusLevel {
+	if m != nil {
+		return m.LowerLevels
+	}
+	return nil
+}
+
+func (m *BonusLevel) GetDenominations() []*Denomination {
+	if m != nil {
+		return m.Denominations
+	}
+	return nil
+}
+
+func (m *BonusLevel) GetRewards() []*Reward {
+	if m != nil {
+		return m.Rewards
+	}
+	return nil
+}
+
+func (m *BonusLevel) GetVoucherPublicKey() []byte {
+	if m != nil {
+		return m.VoucherPublicKey
+	}
+	return nil
+}
+
+func (m *BonusLevel) GetExchangeRates() map[string]int32 {
+	if m != nil {
+		return m.ExchangeRates
+	}
+	return nil
+}
+
+func (m *BonusLevel) GetMinActivationDelay() int64 {
+	if m != nil {
+		return m.MinActivationDelay
+	}
+	return 0
+}
+
+func (m *BonusLevel) GetMaxActivationDelay() int64 {
+	if m != nil {
+		return m.MaxActivationDelay
+	}
+	return 0
+}
+
+type FareClass struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Seats                int64    `protobuf:"varint,2,opt,name=seats,proto3" json:"seats,omitempty"`
+	Booked               int64    `protobuf:"varint,3,opt,name=booked,proto3" json:"booked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FareClass) Reset()         { *m = FareClass{} }
+func (m *FareClass) String() string { return proto.CompactTextString(m) }
+func (*FareClass) ProtoMessage()    {}
+func (*FareClass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{7}
+}
+
+func (m *FareClass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FareClass.Unmarshal(m, b)
+}
+func (m *FareClass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FareClass.Marshal(b, m, deterministic)
+}
+func (m *FareClass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FareClass.Merge(m, src)
+}
+func (m *FareClass) XXX_Size() int {
+	return xxx_messageInfo_FareClass.Size(m)
+}
+func (m *FareClass) XXX_DiscardUnknown() {
+	xxx_messageInfo_FareClass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FareClass proto.InternalMessageInfo
+
+func (m *FareClass) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FareClass) GetSeats() int64 {
+	if m != nil {
+		return m.Seats
+	}
+	return 0
+}
+
+func (m *FareClass) GetBooked() int64 {
+	if m != nil {
+		return m.Booked
+	}
+	return 0
+}
+
+type Flight struct {
+	Id                   int64        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number               string       `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	Airline              string       `protobuf:"bytes,3,opt,name=airline,proto3" json:"airline,omitempty"`
+	Origin               string       `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination          string       `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	Departure            int64        `protobuf:"varint,6,opt,name=departure,proto3" json:"departure,omitempty"`
+	Distance             int64        `protobuf:"varint,7,opt,name=distance,proto3" json:"distance,omitempty"`
+	FareClasses          []*FareClass `protobuf:"bytes,8,rep,name=fare_classes,json=fareClasses,proto3" json:"fare_classes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Flight) Reset()         { *m = Flight{} }
+func (m *Flight) String() string { return proto.CompactTextString(m) }
+func (*Flight) ProtoMessage()    {}
+func (*Flight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{8}
+}
+
+func (m *Flight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Flight.Unmarshal(m, b)
+}
+func (m *Flight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Flight.Marshal(b, m, deterministic)
+}
+func (m *Flight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Flight.Merge(m, src)
+}
+func (m *Flight) XXX_Size() int {
+	return xxx_messageInfo_Flight.Size(m)
+}
+func (m *Flight) XXX_DiscardUnknown() {
+	xxx_messageInfo_Flight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Flight proto.InternalMessageInfo
+
+func (m *Flight) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Flight) GetNumber() string {
+	if m != nil {
+		return m.Number
+	}
+	return ""
+}
+
+func (m *Flight) GetAirline() string {
+	if m != nil {
+		return m.Airline
+	}
+	return ""
+}
+
+func (m *Flight) GetOrigin() string {
+	if m != nil {
+		return m.Origin
+	}
+	return ""
+}
+
+func (m *Flight) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Flight) GetDeparture() int64 {
+	if m != nil {
+		return m.Departure
+	}
+	return 0
+}
+
+func (m *Flight) GetDistance() int64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+func (m *Flight) GetFareClasses() []*FareClass {
+	if m != nil {
+		return m.FareClasses
+	}
+	return nil
+}
+
+type SystemInfoResponse struct {
+	Err                  string        `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Flights              []*Flight     `protobuf:"bytes,2,rep,name=flights,proto3" json:"flights,omitempty"`
+	BLevels              []*BonusLevel `protobuf:"bytes,3,rep,name=b_levels,json=bLevels,proto3" json:"b_levels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SystemInfoResponse) Reset()         { *m = SystemInfoResponse{} }
+func (m *SystemInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*SystemInfoResponse) ProtoMessage()    {}
+func (*SystemInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{9}
+}
+
+func (m *SystemInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SystemInfoResponse.Unmarshal(m, b)
+}
+func (m *SystemInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SystemInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *SystemInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SystemInfoResponse.Merge(m, src)
+}
+func (m *SystemInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_SystemInfoResponse.Size(m)
+}
+func (m *SystemInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SystemInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SystemInfoResponse proto.InternalMessageInfo
+
+func (m *SystemInfoResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *SystemInfoResponse) GetFlights() []*Flight {
+	if m != nil {
+		return m.Flights
+	}
+	return nil
+}
+
+func (m *SystemInfoResponse) GetBLevels() []*BonusLevel {
+	if m != nil {
+		return m.BLevels
+	}
+	return nil
+}
+
+type SendBookingRequest struct {
+	FlightId             int64    `protobuf:"varint,2,opt,name=flight_id,json=flightId,proto3" json:"flight_id,omitempty"`
+	FareClass            string   `protobuf:"bytes,4,opt,name=fare_class,json=fareClass,proto3" json:"fare_class,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendBookingRequest) Reset()         { *m = SendBookingRequest{} }
+func (m *SendBookingRequest) String() string { return proto.CompactTextString(m) }
+func (*SendBookingRequest) ProtoMessage()    {}
+func (*SendBookingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{10}
+}
+
+func (m *SendBookingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendBookingRequest.Unmarshal(m, b)
+}
+func (m *SendBookingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendBookingRequest.Marshal(b, m, deterministic)
+}
+func (m *SendBookingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendBookingRequest.Merge(m, src)
+}
+func (m *SendBookingRequest) XXX_Size() int {
+	return xxx_messageInfo_SendBookingRequest.Size(m)
+}
+func (m *SendBookingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendBookingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendBookingRequest proto.InternalMessageInfo
+
+func (m *SendBookingRequest) GetFlightId() int64 {
+	if m != nil {
+		return m.FlightId
+	}
+	return 0
+}
+
+func (m *SendBookingRequest) GetFareClass() string {
+	if m != nil {
+		return m.FareClass
+	}
+	return ""
+}
+
+type EarnedToken struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	BLevelId             string   `protobuf:"bytes,2,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	Points               int32    `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EarnedToken) Reset()         { *m = EarnedToken{} }
+func (m *EarnedToken) String() string { return proto.CompactTextString(m) }
+func (*EarnedToken) ProtoMessage()    {}
+func (*EarnedToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{11}
+}
+
+func (m *EarnedToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EarnedToken.Unmarshal(m, b)
+}
+func (m *EarnedToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EarnedToken.Marshal(b, m, deterministic)
+}
+func (m *EarnedToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EarnedToken.Merge(m, src)
+}
+func (m *EarnedToken) XXX_Size() int {
+	return xxx_messageInfo_EarnedToken.Size(m)
+}
+func (m *EarnedToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_EarnedToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EarnedToken proto.InternalMessageInfo
+
+func (m *EarnedToken) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *EarnedToken) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *EarnedToken) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+type SendBookingResponse struct {
+	Err                  string         `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Token                string         `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	BookingId            int64          `protobuf:"varint,3,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	Tokens               []*EarnedToken `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SendBookingResponse) Reset()         { *m = SendBookingResponse{} }
+func (m *SendBookingResponse) String() string { return proto.CompactTextString(m) }
+func (*SendBookingResponse) ProtoMessage()    {}
+func (*SendBookingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{12}
+}
+
+func (m *SendBookingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendBookingResponse.Unmarshal(m, b)
+}
+func (m *SendBookingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendBookingResponse.Marshal(b, m, deterministic)
+}
+func (m *SendBookingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendBookingResponse.Merge(m, src)
+}
+func (m *SendBookingResponse) XXX_Size() int {
+	return xxx_messageInfo_SendBookingResponse.Size(m)
+}
+func (m *SendBookingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendBookingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendBookingResponse proto.InternalMessageInfo
+
+func (m *SendBookingResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *SendBookingResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SendBookingResponse) GetBookingId() int64 {
+	if m != nil {
+		return m.BookingId
+	}
+	return 0
+}
+
+func (m *SendBookingResponse) GetTokens() []*EarnedToken {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type BlindSignatureRequest struct {
+	BLevelId             string   `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	BlindToken           []byte   `protobuf:"bytes,3,opt,name=blind_token,json=blindToken,proto3" json:"blind_token,omitempty"`
+	Action               int32    `protobuf:"varint,4,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlindSignatureRequest) Reset()         { *m = BlindSignatureRequest{} }
+func (m *BlindSignatureRequest) String() string { return proto.CompactTextString(m) }
+func (*BlindSignatureRequest) ProtoMessage()    {}
+func (*BlindSignatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{13}
+}
+
+func (m *BlindSignatureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlindSignatureRequest.Unmarshal(m, b)
+}
+func (m *BlindSignatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlindSignatureRequest.Marshal(b, m, deterministic)
+}
+func (m *BlindSignatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlindSignatureRequest.Merge(m, src)
+}
+func (m *BlindSignatureRequest) XXX_Size() int {
+	return xxx_messageInfo_BlindSignatureRequest.Size(m)
+}
+func (m *BlindSignatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlindSignatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlindSignatureRequest proto.InternalMessageInfo
+
+func (m *BlindSignatureRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *BlindSignatureRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *BlindSignatureRequest) GetBlindToken() []byte {
+	if m != nil {
+		return m.BlindToken
+	}
+	return nil
+}
+
+func (m *BlindSignatureRequest) GetAction() int32 {
+	if m != nil {
+		return m.Action
+	}
+	return 0
+}
+
+type BlindSignatureResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	BlindSignature       []byte   `protobuf:"bytes,2,opt,name=blind_signature,json=blindSignature,proto3" json:"blind_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlindSignatureResponse) Reset()         { *m = BlindSignatureResponse{} }
+func (m *BlindSignatureResponse) String() string { return proto.CompactTextString(m) }
+func (*BlindSignatureResponse) ProtoMessage()    {}
+func (*BlindSignatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{14}
+}
+
+func (m *BlindSignatureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlindSignatureResponse.Unmarshal(m, b)
+}
+func (m *BlindSignatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlindSignatureResponse.Marshal(b, m, deterministic)
+}
+func (m *BlindSignatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlindSignatureResponse.Merge(m, src)
+}
+func (m *BlindSignatureResponse) XXX_Size() int {
+	return xxx_messageInfo_BlindSignatureResponse.Size(m)
+}
+func (m *BlindSignatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlindSignatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlindSignatureResponse proto.InternalMessageInfo
+
+func (m *BlindSignatureResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *BlindSignatureResponse) GetBlindSignature() []byte {
+	if m != nil {
+		return m.BlindSignature
+	}
+	return nil
+}
+
+type BookingCodeRequest struct {
+	BLevelId             string   `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	HashValue            []byte   `protobuf:"bytes,2,opt,name=hash_value,json=hashValue,proto3" json:"hash_value,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Points               int32    `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookingCodeRequest) Reset()         { *m = BookingCodeRequest{} }
+func (m *BookingCodeRequest) String() string { return proto.CompactTextString(m) }
+func (*BookingCodeRequest) ProtoMessage()    {}
+func (*BookingCodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{15}
+}
+
+func (m *BookingCodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookingCodeRequest.Unmarshal(m, b)
+}
+func (m *BookingCodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookingCodeRequest.Marshal(b, m, deterministic)
+}
+func (m *BookingCodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookingCodeRequest.Merge(m, src)
+}
+func (m *BookingCodeRequest) XXX_Size() int {
+	return xxx_messageInfo_BookingCodeRequest.Size(m)
+}
+func (m *BookingCodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookingCodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookingCodeRequest proto.InternalMessageInfo
+
+func (m *BookingCodeRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *BookingCodeRequest) GetHashValue() []byte {
+	if m != nil {
+		return m.HashValue
+	}
+	return nil
+}
+
+func (m *BookingCodeRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *BookingCodeRequest) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+type BookingCodeResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Code                 string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookingCodeResponse) Reset()         { *m = BookingCodeResponse{} }
+func (m *BookingCodeResponse) String() string { return proto.CompactTextString(m) }
+func (*BookingCodeResponse) ProtoMessage()    {}
+func (*BookingCodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{16}
+}
+
+func (m *BookingCodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookingCodeResponse.Unmarshal(m, b)
+}
+func (m *BookingCodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookingCodeResponse.Marshal(b, m, deterministic)
+}
+func (m *BookingCodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookingCodeResponse.Merge(m, src)
+}
+func (m *BookingCodeResponse) XXX_Size() int {
+	return xxx_messageInfo_BookingCodeResponse.Size(m)
+}
+func (m *BookingCodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookingCodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookingCodeResponse proto.InternalMessageInfo
+
+func (m *BookingCodeResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *BookingCodeResponse) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type AccessBonusSystemRequest struct {
+	Codes                []string       `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	AdrBundle            *AddressBundle `protobuf:"bytes,2,opt,name=adr_bundle,json=adrBundle,proto3" json:"adr_bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AccessBonusSystemRequest) Reset()         { *m = AccessBonusSystemRequest{} }
+func (m *AccessBonusSystemRequest) String() string { return proto.CompactTextString(m) }
+func (*AccessBonusSystemRequest) ProtoMessage()    {}
+func (*AccessBonusSystemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{17}
+}
+
+func (m *AccessBonusSystemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessBonusSystemRequest.Unmarshal(m, b)
+}
+func (m *AccessBonusSystemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessBonusSystemRequest.Marshal(b, m, deterministic)
+}
+func (m *AccessBonusSystemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessBonusSystemRequest.Merge(m, src)
+}
+func (m *AccessBonusSystemRequest) XXX_Size() int {
+	return xxx_messageInfo_AccessBonusSystemRequest.Size(m)
+}
+func (m *AccessBonusSystemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessBonusSystemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessBonusSystemRequest proto.InternalMessageInfo
+
+func (m *AccessBonusSystemRequest) GetCodes() []string {
+	if m != nil {
+		return m.Codes
+	}
+	return nil
+}
+
+func (m *AccessBonusSystemRequest) GetAdrBundle() *AddressBundle {
+	if m != nil {
+		return m.AdrBundle
+	}
+	return nil
+}
+
+type AccessBonusSystemResponse struct {
+	Err                  string            `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Tokens               map[string]string `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	RecoveryTokens       map[string]string `protobuf:"bytes,3,rep,name=recovery_tokens,json=recoveryTokens,proto3" json:"recovery_tokens,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AccessBonusSystemResponse) Reset()         { *m = AccessBonusSystemResponse{} }
+func (m *AccessBonusSystemResponse) String() string { return proto.CompactTextString(m) }
+func (*AccessBonusSystemResponse) ProtoMessage()    {}
+func (*AccessBonusSystemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{18}
+}
+
+func (m *AccessBonusSystemResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessBonusSystemResponse.Unmarshal(m, b)
+}
+func (m *AccessBonusSystemResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessBonusSystemResponse.Marshal(b, m, deterministic)
+}
+func (m *AccessBonusSystemResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessBonusSystemResponse.Merge(m, src)
+}
+func (m *AccessBonusSystemResponse) XXX_Size() int {
+	return xxx_messageInfo_AccessBonusSystemResponse.Size(m)
+}
+func (m *AccessBonusSystemResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessBonusSystemResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessBonusSystemResponse proto.InternalMessageInfo
+
+func (m *AccessBonusSystemResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *AccessBonusSystemResponse) GetTokens() map[string]string {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+func (m *AccessBonusSystemResponse) GetRecoveryTokens() map[string]string {
+	if m != nil {
+		return m.RecoveryTokens
+	}
+	return nil
+}
+
+type CoinDenomination struct {
+	Value                int32      `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	PublicKey            *PublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CoinDenomination) Reset()         { *m = CoinDenomination{} }
+func (m *CoinDenomination) String() string { return proto.CompactTextString(m) }
+func (*CoinDenomination) ProtoMessage()    {}
+func (*CoinDenomination) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{19}
+}
+
+func (m *CoinDenomination) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoinDenomination.Unmarshal(m, b)
+}
+func (m *CoinDenomination) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoinDenomination.Marshal(b, m, deterministic)
+}
+func (m *CoinDenomination) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoinDenomination.Merge(m, src)
+}
+func (m *CoinDenomination) XXX_Size() int {
+	return xxx_messageInfo_CoinDenomination.Size(m)
+}
+func (m *CoinDenomination) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoinDenomination.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoinDenomination proto.InternalMessageInfo
+
+func (m *CoinDenomination) GetValue() int32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *CoinDenomination) GetPublicKey() *PublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type CoinDenominationsResponse struct {
+	Err                  string              `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Denominations        []*CoinDenomination `protobuf:"bytes,2,rep,name=denominations,proto3" json:"denominations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *CoinDenominationsResponse) Reset()         { *m = CoinDenominationsResponse{} }
+func (m *CoinDenominationsResponse) String() string { return proto.CompactTextString(m) }
+func (*CoinDenominationsResponse) ProtoMessage()    {}
+func (*CoinDenominationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{20}
+}
+
+func (m *CoinDenominationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoinDenominationsResponse.Unmarshal(m, b)
+}
+func (m *CoinDenominationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoinDenominationsResponse.Marshal(b, m, deterministic)
+}
+func (m *CoinDenominationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoinDenominationsResponse.Merge(m, src)
+}
+func (m *CoinDenominationsResponse) XXX_Size() int {
+	return xxx_messageInfo_CoinDenominationsResponse.Size(m)
+}
+func (m *CoinDenominationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoinDenominationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoinDenominationsResponse proto.InternalMessageInfo
+
+func (m *CoinDenominationsResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *CoinDenominationsResponse) GetDenominations() []*CoinDenomination {
+	if m != nil {
+		return m.Denominations
+	}
+	return nil
+}
+
+type BlindCoin struct {
+	Value                int32    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	BlindToken           []byte   `protobuf:"bytes,2,opt,name=blind_token,json=blindToken,proto3" json:"blind_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlindCoin) Reset()         { *m = BlindCoin{} }
+func (m *BlindCoin) String() string { return proto.CompactTextString(m) }
+func (*BlindCoin) ProtoMessage()    {}
+func (*BlindCoin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{21}
+}
+
+func (m *BlindCoin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlindCoin.Unmarshal(m, b)
+}
+func (m *BlindCoin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlindCoin.Marshal(b, m, deterministic)
+}
+func (m *BlindCoin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlindCoin.Merge(m, src)
+}
+func (m *BlindCoin) XXX_Size() int {
+	return xxx_messageInfo_BlindCoin.Size(m)
+}
+func (m *BlindCoin) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlindCoin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlindCoin proto.InternalMessageInfo
+
+func (m *BlindCoin) GetValue() int32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *BlindCoin) GetBlindToken() []byte {
+	if m != nil {
+		return m.BlindToken
+	}
+	return nil
+}
+
+type Coin struct {
+	Value                int32    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Serial               string   `protobuf:"bytes,2,opt,name=serial,proto3" json:"serial,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Coin) Reset()         { *m = Coin{} }
+func (m *Coin) String() string { return proto.CompactTextString(m) }
+func (*Coin) ProtoMessage()    {}
+func (*Coin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{22}
+}
+
+func (m *Coin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Coin.Unmarshal(m, b)
+}
+func (m *Coin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Coin.Marshal(b, m, deterministic)
+}
+func (m *Coin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Coin.Merge(m, src)
+}
+func (m *Coin) XXX_Size() int {
+	return xxx_messageInfo_Coin.Size(m)
+}
+func (m *Coin) XXX_DiscardUnknown() {
+	xxx_messageInfo_Coin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Coin proto.InternalMessageInfo
+
+func (m *Coin) GetValue() int32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Coin) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *Coin) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type WithdrawCoinsRequest struct {
+	BLevelId             string       `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	Token                string       `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	BlindCoins           []*BlindCoin `protobuf:"bytes,3,rep,name=blind_coins,json=blindCoins,proto3" json:"blind_coins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *WithdrawCoinsRequest) Reset()         { *m = WithdrawCoinsRequest{} }
+func (m *WithdrawCoinsRequest) String() string { return proto.CompactTextString(m) }
+func (*WithdrawCoinsRequest) ProtoMessage()    {}
+func (*WithdrawCoinsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{23}
+}
+
+func (m *WithdrawCoinsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WithdrawCoinsRequest.Unmarshal(m, b)
+}
+func (m *WithdrawCoinsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WithdrawCoinsRequest.Marshal(b, m, deterministic)
+}
+func (m *WithdrawCoinsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WithdrawCoinsRequest.Merge(m, src)
+}
+func (m *WithdrawCoinsRequest) XXX_Size() int {
+	return xxx_messageInfo_WithdrawCoinsRequest.Size(m)
+}
+func (m *WithdrawCoinsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WithdrawCoinsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WithdrawCoinsRequest proto.InternalMessageInfo
+
+func (m *WithdrawCoinsRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *WithdrawCoinsRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *WithdrawCoinsRequest) GetBlindCoins() []*BlindCoin {
+	if m != nil {
+		return m.BlindCoins
+	}
+	return nil
+}
+
+type WithdrawCoinsResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	BlindSignatures      []string `protobuf:"bytes,2,rep,name=blind_signatures,json=blindSignatures,proto3" json:"blind_signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WithdrawCoinsResponse) Reset()         { *m = WithdrawCoinsResponse{} }
+func (m *WithdrawCoinsResponse) String() string { return proto.CompactTextString(m) }
+func (*WithdrawCoinsResponse) ProtoMessage()    {}
+func (*WithdrawCoinsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{24}
+}
+
+func (m *WithdrawCoinsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WithdrawCoinsResponse.Unmarshal(m, b)
+}
+func (m *WithdrawCoinsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WithdrawCoinsResponse.Marshal(b, m, deterministic)
+}
+func (m *WithdrawCoinsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WithdrawCoinsResponse.Merge(m, src)
+}
+func (m *WithdrawCoinsResponse) XXX_Size() int {
+	return xxx_messageInfo_WithdrawCoinsResponse.Size(m)
+}
+func (m *WithdrawCoinsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WithdrawCoinsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WithdrawCoinsResponse proto.InternalMessageInfo
+
+func (m *WithdrawCoinsResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *WithdrawCoinsResponse) GetBlindSignatures() []string {
+	if m != nil {
+		return m.BlindSignatures
+	}
+	return nil
+}
+
+type TransferCodeRequest struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	BLevelId             string   `protobuf:"bytes,2,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	Points               int32    `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`
+	BlindToken           []byte   `protobuf:"bytes,4,opt,name=blind_token,json=blindToken,proto3" json:"blind_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferCodeRequest) Reset()         { *m = TransferCodeRequest{} }
+func (m *TransferCodeRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferCodeRequest) ProtoMessage()    {}
+func (*TransferCodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{25}
+}
+
+func (m *TransferCodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferCodeRequest.Unmarshal(m, b)
+}
+func (m *TransferCodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferCodeRequest.Marshal(b, m, deterministic)
+}
+func (m *TransferCodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferCodeRequest.Merge(m, src)
+}
+func (m *TransferCodeRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferCodeRequest.Size(m)
+}
+func (m *TransferCodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferCodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferCodeRequest proto.InternalMessageInfo
+
+func (m *TransferCodeRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *TransferCodeRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *TransferCodeRequest) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *TransferCodeRequest) GetBlindToken() []byte {
+	if m != nil {
+		return m.BlindToken
+	}
+	return nil
+}
+
+type TransferCodeResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	BlindSignature       []byte   `protobuf:"bytes,2,opt,name=blind_signature,json=blindSignature,proto3" json:"blind_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferCodeResponse) Reset()         { *m = TransferCodeResponse{} }
+func (m *TransferCodeResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferCodeResponse) ProtoMessage()    {}
+func (*TransferCodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{26}
+}
+
+func (m *TransferCodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferCodeResponse.Unmarshal(m, b)
+}
+func (m *TransferCodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferCodeResponse.Marshal(b, m, deterministic)
+}
+func (m *TransferCodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferCodeResponse.Merge(m, src)
+}
+func (m *TransferCodeResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferCodeResponse.Size(m)
+}
+func (m *TransferCodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferCodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferCodeResponse proto.InternalMessageInfo
+
+func (m *TransferCodeResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *TransferCodeResponse) GetBlindSignature() []byte {
+	if m != nil {
+		return m.BlindSignature
+	}
+	return nil
+}
+
+type ExchangeCodesRequest struct {
+	Codes                []string `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	From                 string   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string   `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	BlindToken           []byte   `protobuf:"bytes,4,opt,name=blind_token,json=blindToken,proto3" json:"blind_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExchangeCodesRequest) Reset()         { *m = ExchangeCodesRequest{} }
+func (m *ExchangeCodesRequest) String() string { return proto.CompactTextString(m) }
+func (*ExchangeCodesRequest) ProtoMessage()    {}
+func (*ExchangeCodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{27}
+}
+
+func (m *ExchangeCodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeCodesRequest.Unmarshal(m, b)
+}
+func (m *ExchangeCodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeCodesRequest.Marshal(b, m, deterministic)
+}
+func (m *ExchangeCodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeCodesRequest.Merge(m, src)
+}
+func (m *ExchangeCodesRequest) XXX_Size() int {
+	return xxx_messageInfo_ExchangeCodesRequest.Size(m)
+}
+func (m *ExchangeCodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeCodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeCodesRequest proto.InternalMessageInfo
+
+func (m *ExchangeCodesRequest) GetCodes() []string {
+	if m != nil {
+		return m.Codes
+	}
+	return nil
+}
+
+func (m *ExchangeCodesRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ExchangeCodesRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ExchangeCodesRequest) GetBlindToken() []byte {
+	if m != nil {
+		return m.BlindToken
+	}
+	return nil
+}
+
+type ExchangeCodesResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	BlindSignature       []byte   `protobuf:"bytes,2,opt,name=blind_signature,json=blindSignature,proto3" json:"blind_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExchangeCodesResponse) Reset()         { *m = ExchangeCodesResponse{} }
+func (m *ExchangeCodesResponse) String() string { return proto.CompactTextString(m) }
+func (*ExchangeCodesResponse) ProtoMessage()    {}
+func (*ExchangeCodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{28}
+}
+
+func (m *ExchangeCodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeCodesResponse.Unmarshal(m, b)
+}
+func (m *ExchangeCodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeCodesResponse.Marshal(b, m, deterministic)
+}
+func (m *ExchangeCodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeCodesResponse.Merge(m, src)
+}
+func (m *ExchangeCodesResponse) XXX_Size() int {
+	return xxx_messageInfo_ExchangeCodesResponse.Size(m)
+}
+func (m *ExchangeCodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeCodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeCodesResponse proto.InternalMessageInfo
+
+func (m *ExchangeCodesResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *ExchangeCodesResponse) GetBlindSignature() []byte {
+	if m != nil {
+		return m.BlindSignature
+	}
+	return nil
+}
+
+type SpendCoinsRequest struct {
+	Coins                []*Coin      `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	BLevelId             string       `protobuf:"bytes,2,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	RewardId             string       `protobuf:"bytes,3,opt,name=reward_id,json=rewardId,proto3" json:"reward_id,omitempty"`
+	BlindChange          []*BlindCoin `protobuf:"bytes,4,rep,name=blind_change,json=blindChange,proto3" json:"blind_change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SpendCoinsRequest) Reset()         { *m = SpendCoinsRequest{} }
+func (m *SpendCoinsRequest) String() string { return proto.CompactTextString(m) }
+func (*SpendCoinsRequest) ProtoMessage()    {}
+func (*SpendCoinsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{29}
+}
+
+func (m *SpendCoinsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpendCoinsRequest.Unmarshal(m, b)
+}
+func (m *SpendCoinsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpendCoinsRequest.Marshal(b, m, deterministic)
+}
+func (m *SpendCoinsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpendCoinsRequest.Merge(m, src)
+}
+func (m *SpendCoinsRequest) XXX_Size() int {
+	return xxx_messageInfo_SpendCoinsRequest.Size(m)
+}
+func (m *SpendCoinsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpendCoinsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpendCoinsRequest proto.InternalMessageInfo
+
+func (m *SpendCoinsRequest) GetCoins() []*Coin {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+func (m *SpendCoinsRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *SpendCoinsRequest) GetRewardId() string {
+	if m != nil {
+		return m.RewardId
+	}
+	return ""
+}
+
+func (m *SpendCoinsRequest) GetBlindChange() []*BlindCoin {
+	if m != nil {
+		return m.BlindChange
+	}
+	return nil
+}
+
+type SpendCoinsResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	BonusData            string   `protobuf:"bytes,2,opt,name=bonus_data,json=bonusData,proto3" json:"bonus_data,omitempty"`
+	BlindSignatures      []string `protobuf:"bytes,3,rep,name=blind_signatures,json=blindSignatures,proto3" json:"blind_signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpendCoinsResponse) Reset()         { *m = SpendCoinsResponse{} }
+func (m *SpendCoinsResponse) String() string { return proto.CompactTextString(m) }
+func (*SpendCoinsResponse) ProtoMessage()    {}
+func (*SpendCoinsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{30}
+}
+
+func (m *SpendCoinsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpendCoinsResponse.Unmarshal(m, b)
+}
+func (m *SpendCoinsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpendCoinsResponse.Marshal(b, m, deterministic)
+}
+func (m *SpendCoinsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpendCoinsResponse.Merge(m, src)
+}
+func (m *SpendCoinsResponse) XXX_Size() int {
+	return xxx_messageInfo_SpendCoinsResponse.Size(m)
+}
+func (m *SpendCoinsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpendCoinsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpendCoinsResponse proto.InternalMessageInfo
+
+func (m *SpendCoinsResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *SpendCoinsResponse) GetBonusData() string {
+	if m != nil {
+		return m.BonusData
+	}
+	return ""
+}
+
+func (m *SpendCoinsResponse) GetBlindSignatures() []string {
+	if m != nil {
+		return m.BlindSignatures
+	}
+	return nil
+}
+
+type SetAddressRequest struct {
+	BLevelId             string         `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	HashValue            []byte         `protobuf:"bytes,2,opt,name=hash_value,json=hashValue,proto3" json:"hash_value,omitempty"`
+	Signature            []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	AdrBundle            *AddressBundle `protobuf:"bytes,4,opt,name=adr_bundle,json=adrBundle,proto3" json:"adr_bundle,omitempty"`
+	Action               int32          `protobuf:"varint,5,opt,name=action,proto3" json:"action,omitempty"`
+	Pkr                  string         `protobuf:"bytes,6,opt,name=pkr,proto3" json:"pkr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetAddressRequest) Reset()         { *m = SetAddressRequest{} }
+func (m *SetAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*SetAddressRequest) ProtoMessage()    {}
+func (*SetAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{31}
+}
+
+func (m *SetAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAddressRequest.Unmarshal(m, b)
+}
+func (m *SetAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *SetAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAddressRequest.Merge(m, src)
+}
+func (m *SetAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_SetAddressRequest.Size(m)
+}
+func (m *SetAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAddressRequest proto.InternalMessageInfo
+
+func (m *SetAddressRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *SetAddressRequest) GetHashValue() []byte {
+	if m != nil {
+		return m.HashValue
+	}
+	return nil
+}
+
+func (m *SetAddressRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SetAddressRequest) GetAdrBundle() *AddressBundle {
+	if m != nil {
+		return m.AdrBundle
+	}
+	return nil
+}
+
+func (m *SetAddressRequest) GetAction() int32 {
+	if m != nil {
+		return m.Action
+	}
+	return 0
+}
+
+func (m *SetAddressRequest) GetPkr() string {
+	if m != nil {
+		return m.Pkr
+	}
+	return ""
+}
+
+type SetAddressResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	RecoveryToken        string   `protobuf:"bytes,3,opt,name=recovery_token,json=recoveryToken,proto3" json:"recovery_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAddressResponse) Reset()         { *m = SetAddressResponse{} }
+func (m *SetAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*SetAddressResponse) ProtoMessage()    {}
+func (*SetAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{32}
+}
+
+func (m *SetAddressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAddressResponse.Unmarshal(m, b)
+}
+func (m *SetAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAddressResponse.Marshal(b, m, deterministic)
+}
+func (m *SetAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAddressResponse.Merge(m, src)
+}
+func (m *SetAddressResponse) XXX_Size() int {
+	return xxx_messageInfo_SetAddressResponse.Size(m)
+}
+func (m *SetAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAddressResponse proto.InternalMessageInfo
+
+func (m *SetAddressResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *SetAddressResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SetAddressResponse) GetRecoveryToken() string {
+	if m != nil {
+		return m.RecoveryToken
+	}
+	return ""
+}
+
+type ParticipateRequest struct {
+	BLevelId             string   `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	HashValue            []byte   `protobuf:"bytes,2,opt,name=hash_value,json=hashValue,proto3" json:"hash_value,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Pkr                  string   `protobuf:"bytes,4,opt,name=pkr,proto3" json:"pkr,omitempty"`
+	Action               int32    `protobuf:"varint,5,opt,name=action,proto3" json:"action,omitempty"`
+	RewardId             string   `protobuf:"bytes,6,opt,name=reward_id,json=rewardId,proto3" json:"reward_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParticipateRequest) Reset()         { *m = ParticipateRequest{} }
+func (m *ParticipateRequest) String() string { return proto.CompactTextString(m) }
+func (*ParticipateRequest) ProtoMessage()    {}
+func (*ParticipateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{33}
+}
+
+func (m *ParticipateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParticipateRequest.Unmarshal(m, b)
+}
+func (m *ParticipateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParticipateRequest.Marshal(b, m, deterministic)
+}
+func (m *ParticipateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParticipateRequest.Merge(m, src)
+}
+func (m *ParticipateRequest) XXX_Size() int {
+	return xxx_messageInfo_ParticipateRequest.Size(m)
+}
+func (m *ParticipateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParticipateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParticipateRequest proto.InternalMessageInfo
+
+func (m *ParticipateRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *ParticipateRequest) GetHashValue() []byte {
+	if m != nil {
+		return m.HashValue
+	}
+	return nil
+}
+
+func (m *ParticipateRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *ParticipateRequest) GetPkr() string {
+	if m != nil {
+		return m.Pkr
+	}
+	return ""
+}
+
+func (m *ParticipateRequest) GetAction() int32 {
+	if m != nil {
+		return m.Action
+	}
+	return 0
+}
+
+func (m *ParticipateRequest) GetRewardId() string {
+	if m != nil {
+		return m.RewardId
+	}
+	return ""
+}
+
+type ParticipateResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	RecoveryToken        string   `protobuf:"bytes,3,opt,name=recovery_token,json=recoveryToken,proto3" json:"recovery_token,omitempty"`
+	BonusData            string   `protobuf:"bytes,4,opt,name=bonus_data,json=bonusData,proto3" json:"bonus_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParticipateResponse) Reset()         { *m = ParticipateResponse{} }
+func (m *ParticipateResponse) String() string { return proto.CompactTextString(m) }
+func (*ParticipateResponse) ProtoMessage()    {}
+func (*ParticipateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{34}
+}
+
+func (m *ParticipateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParticipateResponse.Unmarshal(m, b)
+}
+func (m *ParticipateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParticipateResponse.Marshal(b, m, deterministic)
+}
+func (m *ParticipateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParticipateResponse.Merge(m, src)
+}
+func (m *ParticipateResponse) XXX_Size() int {
+	return xxx_messageInfo_ParticipateResponse.Size(m)
+}
+func (m *ParticipateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParticipateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParticipateResponse proto.InternalMessageInfo
+
+func (m *ParticipateResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *ParticipateResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ParticipateResponse) GetRecoveryToken() string {
+	if m != nil {
+		return m.RecoveryToken
+	}
+	return ""
+}
+
+func (m *ParticipateResponse) GetBonusData() string {
+	if m != nil {
+		return m.BonusData
+	}
+	return ""
+}
+
+type RecoveryStatusRequest struct {
+	BLevelId             string         `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	AdrBundle            *AddressBundle `protobuf:"bytes,2,opt,name=adr_bundle,json=adrBundle,proto3" json:"adr_bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RecoveryStatusRequest) Reset()         { *m = RecoveryStatusRequest{} }
+func (m *RecoveryStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*RecoveryStatusRequest) ProtoMessage()    {}
+func (*RecoveryStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{35}
+}
+
+func (m *RecoveryStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoveryStatusRequest.Unmarshal(m, b)
+}
+func (m *RecoveryStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoveryStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *RecoveryStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryStatusRequest.Merge(m, src)
+}
+func (m *RecoveryStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_RecoveryStatusRequest.Size(m)
+}
+func (m *RecoveryStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryStatusRequest proto.InternalMessageInfo
+
+func (m *RecoveryStatusRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *RecoveryStatusRequest) GetAdrBundle() *AddressBundle {
+	if m != nil {
+		return m.AdrBundle
+	}
+	return nil
+}
+
+type RecoveryStatusResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	RecoveryStatus       int32    `protobuf:"varint,2,opt,name=recovery_status,json=recoveryStatus,proto3" json:"recovery_status,omitempty"`
+	Token                string   `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecoveryStatusResponse) Reset()         { *m = RecoveryStatusResponse{} }
+func (m *RecoveryStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*RecoveryStatusResponse) ProtoMessage()    {}
+func (*RecoveryStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{36}
+}
+
+func (m *RecoveryStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoveryStatusResponse.Unmarshal(m, b)
+}
+func (m *RecoveryStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoveryStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *RecoveryStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryStatusResponse.Merge(m, src)
+}
+func (m *RecoveryStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_RecoveryStatusResponse.Size(m)
+}
+func (m *RecoveryStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryStatusResponse proto.InternalMessageInfo
+
+func (m *RecoveryStatusResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *RecoveryStatusResponse) GetRecoveryStatus() int32 {
+	if m != nil {
+		return m.RecoveryStatus
+	}
+	return 0
+}
+
+func (m *RecoveryStatusResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type RecoveryTestRequest struct {
+	BLevelId             string         `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	RecoveryToken        string         `protobuf:"bytes,2,opt,name=recovery_token,json=recoveryToken,proto3" json:"recovery_token,omitempty"`
+	Pkr                  string         `protobuf:"bytes,3,opt,name=pkr,proto3" json:"pkr,omitempty"`
+	AdrBundle            *AddressBundle `protobuf:"bytes,4,opt,name=adr_bundle,json=adrBundle,proto3" json:"adr_bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RecoveryTestRequest) Reset()         { *m = RecoveryTestRequest{} }
+func (m *RecoveryTestRequest) String() string { return proto.CompactTextString(m) }
+func (*RecoveryTestRequest) ProtoMessage()    {}
+func (*RecoveryTestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{37}
+}
+
+func (m *RecoveryTestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoveryTestRequest.Unmarshal(m, b)
+}
+func (m *RecoveryTestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoveryTestRequest.Marshal(b, m, deterministic)
+}
+func (m *RecoveryTestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryTestRequest.Merge(m, src)
+}
+func (m *RecoveryTestRequest) XXX_Size() int {
+	return xxx_messageInfo_RecoveryTestRequest.Size(m)
+}
+func (m *RecoveryTestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryTestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryTestRequest proto.InternalMessageInfo
+
+func (m *RecoveryTestRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *RecoveryTestRequest) GetRecoveryToken() string {
+	if m != nil {
+		return m.RecoveryToken
+	}
+	return ""
+}
+
+func (m *RecoveryTestRequest) GetPkr() string {
+	if m != nil {
+		return m.Pkr
+	}
+	return ""
+}
+
+func (m *RecoveryTestRequest) GetAdrBundle() *AddressBundle {
+	if m != nil {
+		return m.AdrBundle
+	}
+	return nil
+}
+
+type RecoveryTestResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	FoundRecoveryToken   string   `protobuf:"bytes,3,opt,name=found_recovery_token,json=foundRecoveryToken,proto3" json:"found_recovery_token,omitempty"`
+	BonusData            string   `protobuf:"bytes,4,opt,name=bonus_data,json=bonusData,proto3" json:"bonus_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecoveryTestResponse) Reset()         { *m = RecoveryTestResponse{} }
+func (m *RecoveryTestResponse) String() string { return proto.CompactTextString(m) }
+func (*RecoveryTestResponse) ProtoMessage()    {}
+func (*RecoveryTestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{38}
+}
+
+func (m *RecoveryTestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoveryTestResponse.Unmarshal(m, b)
+}
+func (m *RecoveryTestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoveryTestResponse.Marshal(b, m, deterministic)
+}
+func (m *RecoveryTestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryTestResponse.Merge(m, src)
+}
+func (m *RecoveryTestResponse) XXX_Size() int {
+	return xxx_messageInfo_RecoveryTestResponse.Size(m)
+}
+func (m *RecoveryTestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryTestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryTestResponse proto.InternalMessageInfo
+
+func (m *RecoveryTestResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *RecoveryTestResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *RecoveryTestResponse) GetFoundRecoveryToken() string {
+	if m != nil {
+		return m.FoundRecoveryToken
+	}
+	return ""
+}
+
+func (m *RecoveryTestResponse) GetBonusData() string {
+	if m != nil {
+		return m.BonusData
+	}
+	return ""
+}
+
+type CustomerRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomerRequest) Reset()         { *m = CustomerRequest{} }
+func (m *CustomerRequest) String() string { return proto.CompactTextString(m) }
+func (*CustomerRequest) ProtoMessage()    {}
+func (*CustomerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{39}
+}
+
+func (m *CustomerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomerRequest.Unmarshal(m, b)
+}
+func (m *CustomerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomerRequest.Marshal(b, m, deterministic)
+}
+func (m *CustomerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerRequest.Merge(m, src)
+}
+func (m *CustomerRequest) XXX_Size() int {
+	return xxx_messageInfo_CustomerRequest.Size(m)
+}
+func (m *CustomerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerRequest proto.InternalMessageInfo
+
+func (m *CustomerRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CustomerRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type CustomerResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	CustomerId           int64    `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Session              string   `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomerResponse) Reset()         { *m = CustomerResponse{} }
+func (m *CustomerResponse) String() string { return proto.CompactTextString(m) }
+func (*CustomerResponse) ProtoMessage()    {}
+func (*CustomerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{40}
+}
+
+func (m *CustomerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomerResponse.Unmarshal(m, b)
+}
+func (m *CustomerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomerResponse.Marshal(b, m, deterministic)
+}
+func (m *CustomerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerResponse.Merge(m, src)
+}
+func (m *CustomerResponse) XXX_Size() int {
+	return xxx_messageInfo_CustomerResponse.Size(m)
+}
+func (m *CustomerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerResponse proto.InternalMessageInfo
+
+func (m *CustomerResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *CustomerResponse) GetCustomerId() int64 {
+	if m != nil {
+		return m.CustomerId
+	}
+	return 0
+}
+
+func (m *CustomerResponse) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+type BookingStatus struct {
+	BookingId            int64          `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	FlightId             int64          `protobuf:"varint,2,opt,name=flight_id,json=flightId,proto3" json:"flight_id,omitempty"`
+	FareClass            string         `protobuf:"bytes,3,opt,name=fare_class,json=fareClass,proto3" json:"fare_class,omitempty"`
+	Status               string         `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	PendingTokens        []*EarnedToken `protobuf:"bytes,5,rep,name=pending_tokens,json=pendingTokens,proto3" json:"pending_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BookingStatus) Reset()         { *m = BookingStatus{} }
+func (m *BookingStatus) String() string { return proto.CompactTextString(m) }
+func (*BookingStatus) ProtoMessage()    {}
+func (*BookingStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{41}
+}
+
+func (m *BookingStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookingStatus.Unmarshal(m, b)
+}
+func (m *BookingStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookingStatus.Marshal(b, m, deterministic)
+}
+func (m *BookingStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookingStatus.Merge(m, src)
+}
+func (m *BookingStatus) XXX_Size() int {
+	return xxx_messageInfo_BookingStatus.Size(m)
+}
+func (m *BookingStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookingStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookingStatus proto.InternalMessageInfo
+
+func (m *BookingStatus) GetBookingId() int64 {
+	if m != nil {
+		return m.BookingId
+	}
+	return 0
+}
+
+func (m *BookingStatus) GetFlightId() int64 {
+	if m != nil {
+		return m.FlightId
+	}
+	return 0
+}
+
+func (m *BookingStatus) GetFareClass() string {
+	if m != nil {
+		return m.FareClass
+	}
+	return ""
+}
+
+func (m *BookingStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *BookingStatus) GetPendingTokens() []*EarnedToken {
+	if m != nil {
+		return m.PendingTokens
+	}
+	return nil
+}
+
+type BookingsResponse struct {
+	Err                  string           `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Bookings             []*BookingStatus `protobuf:"bytes,2,rep,name=bookings,proto3" json:"bookings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BookingsResponse) Reset()         { *m = BookingsResponse{} }
+func (m *BookingsResponse) String() string { return proto.CompactTextString(m) }
+func (*BookingsResponse) ProtoMessage()    {}
+func (*BookingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{42}
+}
+
+func (m *BookingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookingsResponse.Unmarshal(m, b)
+}
+func (m *BookingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookingsResponse.Marshal(b, m, deterministic)
+}
+func (m *BookingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookingsResponse.Merge(m, src)
+}
+func (m *BookingsResponse) XXX_Size() int {
+	return xxx_messageInfo_BookingsResponse.Size(m)
+}
+func (m *BookingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookingsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookingsResponse proto.InternalMessageInfo
+
+func (m *BookingsResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *BookingsResponse) GetBookings() []*BookingStatus {
+	if m != nil {
+		return m.Bookings
+	}
+	return nil
+}
+
+type RegisterResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	ClientId             int64    `protobuf:"varint,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResponse) Reset()         { *m = RegisterResponse{} }
+func (m *RegisterResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterResponse) ProtoMessage()    {}
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{43}
+}
+
+func (m *RegisterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResponse.Unmarshal(m, b)
+}
+func (m *RegisterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResponse.Marshal(b, m, deterministic)
+}
+func (m *RegisterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResponse.Merge(m, src)
+}
+func (m *RegisterResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterResponse.Size(m)
+}
+func (m *RegisterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResponse proto.InternalMessageInfo
+
+func (m *RegisterResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *RegisterResponse) GetClientId() int64 {
+	if m != nil {
+		return m.ClientId
+	}
+	return 0
+}
+
+type ResetResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetResponse) Reset()         { *m = ResetResponse{} }
+func (m *ResetResponse) String() string { return proto.CompactTextString(m) }
+func (*ResetResponse) ProtoMessage()    {}
+func (*ResetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{44}
+}
+
+func (m *ResetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetResponse.Unmarshal(m, b)
+}
+func (m *ResetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetResponse.Marshal(b, m, deterministic)
+}
+func (m *ResetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetResponse.Merge(m, src)
+}
+func (m *ResetResponse) XXX_Size() int {
+	return xxx_messageInfo_ResetResponse.Size(m)
+}
+func (m *ResetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetResponse proto.InternalMessageInfo
+
+func (m *ResetResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+type DebugInfoResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	ServerJson           []byte   `protobuf:"bytes,2,opt,name=server_json,json=serverJson,proto3" json:"server_json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DebugInfoResponse) Reset()         { *m = DebugInfoResponse{} }
+func (m *DebugInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*DebugInfoResponse) ProtoMessage()    {}
+func (*DebugInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{45}
+}
+
+func (m *DebugInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebugInfoResponse.Unmarshal(m, b)
+}
+func (m *DebugInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebugInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *DebugInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugInfoResponse.Merge(m, src)
+}
+func (m *DebugInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_DebugInfoResponse.Size(m)
+}
+func (m *DebugInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebugInfoResponse proto.InternalMessageInfo
+
+func (m *DebugInfoResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *DebugInfoResponse) GetServerJson() []byte {
+	if m != nil {
+		return m.ServerJson
+	}
+	return nil
+}
+
+type LastAdrBdlRequest struct {
+	BLevelId             string   `protobuf:"bytes,1,opt,name=b_level_id,json=bLevelId,proto3" json:"b_level_id,omitempty"`
+	Seed                 []byte   `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LastAdrBdlRequest) Reset()         { *m = LastAdrBdlRequest{} }
+func (m *LastAdrBdlRequest) String() string { return proto.CompactTextString(m) }
+func (*LastAdrBdlRequest) ProtoMessage()    {}
+func (*LastAdrBdlRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{46}
+}
+
+func (m *LastAdrBdlRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LastAdrBdlRequest.Unmarshal(m, b)
+}
+func (m *LastAdrBdlRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LastAdrBdlRequest.Marshal(b, m, deterministic)
+}
+func (m *LastAdrBdlRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastAdrBdlRequest.Merge(m, src)
+}
+func (m *LastAdrBdlRequest) XXX_Size() int {
+	return xxx_messageInfo_LastAdrBdlRequest.Size(m)
+}
+func (m *LastAdrBdlRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastAdrBdlRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastAdrBdlRequest proto.InternalMessageInfo
+
+func (m *LastAdrBdlRequest) GetBLevelId() string {
+	if m != nil {
+		return m.BLevelId
+	}
+	return ""
+}
+
+func (m *LastAdrBdlRequest) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+type LastAdrBdlResponse struct {
+	Err                  string   `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	AccountId            uint32   `protobuf:"varint,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LastAdrBdlResponse) Reset()         { *m = LastAdrBdlResponse{} }
+func (m *LastAdrBdlResponse) String() string { return proto.CompactTextString(m) }
+func (*LastAdrBdlResponse) ProtoMessage()    {}
+func (*LastAdrBdlResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51baa40a1cc6b48b, []int{47}
+}
+
+func (m *LastAdrBdlResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LastAdrBdlResponse.Unmarshal(m, b)
+}
+func (m *LastAdrBdlResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LastAdrBdlResponse.Marshal(b, m, deterministic)
+}
+func (m *LastAdrBdlResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastAdrBdlResponse.Merge(m, src)
+}
+func (m *LastAdrBdlResponse) XXX_Size() int {
+	return xxx_messageInfo_LastAdrBdlResponse.Size(m)
+}
+func (m *LastAdrBdlResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastAdrBdlResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastAdrBdlResponse proto.InternalMessageInfo
+
+func (m *LastAdrBdlResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *LastAdrBdlResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LastAdrBdlResponse) GetAccountId() uint32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "pb.Empty")
+	proto.RegisterType((*AddressBundle)(nil), "pb.AddressBundle")
+	proto.RegisterType((*PublicKey)(nil), "pb.PublicKey")
+	proto.RegisterType((*ActionVariant)(nil), "pb.ActionVariant")
+	proto.RegisterType((*Denomination)(nil), "pb.Denomination")
+	proto.RegisterType((*Reward)(nil), "pb.Reward")
+	proto.RegisterType((*BonusLevel)(nil), "pb.BonusLevel")
+	proto.RegisterMapType((map[string]int32)(nil), "pb.BonusLevel.ExchangeRatesEntry")
+	proto.RegisterType((*FareClass)(nil), "pb.FareClass")
+	proto.RegisterType((*Flight)(nil), "pb.Flight")
+	proto.RegisterType((*SystemInfoResponse)(nil), "pb.SystemInfoResponse")
+	proto.RegisterType((*SendBookingRequest)(nil), "pb.SendBookingRequest")
+	proto.RegisterType((*EarnedToken)(nil), "pb.EarnedToken")
+	proto.RegisterType((*SendBookingResponse)(nil), "pb.SendBookingResponse")
+	proto.RegisterType((*BlindSignatureRequest)(nil), "pb.BlindSignatureRequest")
+	proto.RegisterType((*BlindSignatureResponse)(nil), "pb.BlindSignatureResponse")
+	proto.RegisterType((*BookingCodeRequest)(nil), "pb.BookingCodeRequest")
+	proto.RegisterType((*BookingCodeResponse)(nil), "pb.BookingCodeResponse")
+	proto.RegisterType((*AccessBonusSystemRequest)(nil), "pb.AccessBonusSystemRequest")
+	proto.RegisterType((*AccessBonusSystemResponse)(nil), "pb.AccessBonusSystemResponse")
+	proto.RegisterMapType((map[string]string)(nil), "pb.AccessBonusSystemResponse.RecoveryTokensEntry")
+	proto.RegisterMapType((map[string]string)(nil), "pb.AccessBonusSystemResponse.TokensEntry")
+	proto.RegisterType((*CoinDenomination)(nil), "pb.CoinDenomination")
+	proto.RegisterType((*CoinDenominationsResponse)(nil), "pb.CoinDenominationsResponse")
+	proto.RegisterType((*BlindCoin)(nil), "pb.BlindCoin")
+	proto.RegisterType((*Coin)(nil), "pb.Coin")
+	proto.RegisterType((*WithdrawCoinsRequest)(nil), "pb.WithdrawCoinsRequest")
+	proto.RegisterType((*WithdrawCoinsResponse)(nil), "pb.WithdrawCoinsResponse")
+	proto.RegisterType((*TransferCodeRequest)(nil), "pb.TransferCodeRequest")
+	proto.RegisterType((*TransferCodeResponse)(nil), "pb.TransferCodeResponse")
+	proto.RegisterType((*ExchangeCodesRequest)(nil), "pb.ExchangeCodesRequest")
+	proto.RegisterType((*ExchangeCodesResponse)(nil), "pb.ExchangeCodesResponse")
+	proto.RegisterType((*SpendCoinsRequest)(nil), "pb.SpendCoinsRequest")
+	proto.RegisterType((*SpendCoinsResponse)(nil), "pb.SpendCoinsResponse")
+	proto.RegisterType((*SetAddressRequest)(nil), "pb.SetAddressRequest")
+	proto.RegisterType((*SetAddressResponse)(nil), "pb.SetAddressResponse")
+	proto.RegisterType((*ParticipateRequest)(nil), "pb.ParticipateRequest")
+	proto.RegisterType((*ParticipateResponse)(nil), "pb.ParticipateResponse")
+	proto.RegisterType((*RecoveryStatusRequest)(nil), "pb.RecoveryStatusRequest")
+	proto.RegisterType((*RecoveryStatusResponse)(nil), "pb.RecoveryStatusResponse")
+	proto.RegisterType((*RecoveryTestRequest)(nil), "pb.RecoveryTestRequest")
+	proto.RegisterType((*RecoveryTestResponse)(nil), "pb.RecoveryTestResponse")
+	proto.RegisterType((*CustomerRequest)(nil), "pb.CustomerRequest")
+	proto.RegisterType((*CustomerResponse)(nil), "pb.CustomerResponse")
+	proto.RegisterType((*BookingStatus)(nil), "pb.BookingStatus")
+	proto.RegisterType((*BookingsResponse)(nil), "pb.BookingsResponse")
+	proto.RegisterType((*RegisterResponse)(nil), "pb.RegisterResponse")
+	proto.RegisterType((*ResetResponse)(nil), "pb.ResetResponse")
+	proto.RegisterType((*DebugInfoResponse)(nil), "pb.DebugInfoResponse")
+	proto.RegisterType((*LastAdrBdlRequest)(nil), "pb.LastAdrBdlRequest")
+	proto.RegisterType((*LastAdrBdlResponse)(nil), "pb.LastAdrBdlResponse")
+}
+
+func init() { proto.RegisterFile("connection.proto", fileDescriptor_51baa40a1cc6b48b) }
+
+var fileDescriptor_51baa40a1cc6b48b = []byte{
+	// 1870 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x18, 0xdb, 0x6e, 0x23, 0x49,
+	0x55, 0xed, 0xb6, 0x1d, 0xf7, 0x71, 0xec, 0x38, 0x15, 0x4f, 0xe4, 0xc9, 0xec, 0x30, 0xd9, 0x12,
+	0xab, 0x99, 0x91, 0x96, 0x68, 0x76, 0x91, 0x56, 0x30, 0xbc, 0xe0, 0x5c, 0x46, 0x18, 0x16, 0x69,
+	0xa8, 0x44, 0x83, 0xe0, 0xa5, 0x55, 0xee, 0xae, 0x38, 0x4d, 0xec, 0x6a, 0x6f, 0x55, 0x3b, 0x19,
+	0xc3, 0x0b, 0x12, 0x20, 0x1e, 0xf9, 0x03, 0x84, 0xc4, 0x3f, 0xf0, 0xc0, 0x27, 0xec, 0x17, 0xf0,
+	0x19, 0x7c, 0x02, 0xaa, 0x4b, 0xb7, 0xbb, 0x3b, 0xb6, 0x13, 0x33, 0xda, 0x79, 0xea, 0x3e, 0xa7,
+	0xaa, 0xce, 0xfd, 0x56, 0x05, 0x9d, 0x20, 0xe6, 0x9c, 0x05, 0x49, 0x14, 0xf3, 0xa3, 0xa9, 0x88,
+	0x93, 0x18, 0x55, 0xa6, 0x43, 0xbc, 0x05, 0xb5, 0xb3, 0xc9, 0x34, 0x99, 0xe3, 0x3f, 0x40, 0xab,
+	0x1f, 0x86, 0x82, 0x49, 0x79, 0x3c, 0xe3, 0xe1, 0x98, 0x21, 0x04, 0x55, 0xc9, 0x58, 0xd8, 0x73,
+	0x0e, 0x9d, 0x17, 0xdb, 0x44, 0xff, 0xa3, 0xa7, 0x00, 0x34, 0x08, 0xe2, 0x19, 0x4f, 0xfc, 0x28,
+	0xec, 0x55, 0x0e, 0x9d, 0x17, 0x2d, 0xe2, 0x59, 0xcc, 0xc0, 0x2c, 0x1b, 0x1a, 0x6a, 0xd9, 0xb5,
+	0xcb, 0x06, 0x33, 0x08, 0x51, 0x0f, 0xb6, 0x2c, 0xd0, 0xab, 0x1e, 0x3a, 0x2f, 0x3c, 0x92, 0x82,
+	0xf8, 0x39, 0x78, 0x6f, 0x67, 0xc3, 0x71, 0x14, 0xfc, 0x82, 0xcd, 0xd1, 0x36, 0x38, 0xdc, 0x72,
+	0x75, 0xb8, 0x82, 0x98, 0xe6, 0xe4, 0x12, 0x87, 0xe1, 0x29, 0xb4, 0xfa, 0x5a, 0x85, 0x77, 0x54,
+	0x44, 0x94, 0x27, 0x8a, 0xe5, 0x8d, 0xf9, 0x55, 0x2c, 0xd5, 0xa9, 0x1a, 0xf1, 0x2c, 0x66, 0x10,
+	0xa2, 0xcf, 0x01, 0xa6, 0x9a, 0xb0, 0x7f, 0xcd, 0xe6, 0x9a, 0x4c, 0xf3, 0xcb, 0xd6, 0xd1, 0x74,
+	0x78, 0x94, 0xb1, 0x23, 0xde, 0x34, 0xe3, 0x8c, 0xa0, 0xca, 0xe9, 0x84, 0x69, 0xc9, 0x3d, 0xa2,
+	0xff, 0xf1, 0x05, 0x6c, 0x9f, 0x32, 0x1e, 0x4f, 0x22, 0x4e, 0x15, 0x5f, 0xb4, 0x0f, 0xf5, 0x69,
+	0x1c, 0xf1, 0x44, 0x5a, 0x66, 0x16, 0xda, 0x8c, 0x13, 0xfe, 0xa7, 0x03, 0x75, 0xc2, 0x6e, 0xa9,
+	0x08, 0x51, 0x1b, 0x2a, 0x56, 0x72, 0x8f, 0x54, 0xa2, 0x10, 0x1d, 0x42, 0x33, 0x64, 0x32, 0x10,
+	0xd1, 0x54, 0xf1, 0xd3, 0x94, 0x3c, 0x92, 0x47, 0xa1, 0x2e, 0xd4, 0x6e, 0xe8, 0x78, 0x66, 0xe4,
+	0xac, 0x11, 0x03, 0xa0, 0x03, 0x68, 0x04, 0x33, 0x21, 0x18, 0x0f, 0xe6, 0xd6, 0xbc, 0x19, 0x6c,
+	0xac, 0x34, 0x8e, 0x42, 0x3f, 0xa4, 0x73, 0xd9, 0xab, 0xa5, 0x56, 0x1a, 0x47, 0xe1, 0x29, 0x9d,
+	0xcb, 0x9c, 0x4e, 0xf5, 0xbc, 0x4e, 0xf8, 0x3f, 0x55, 0x80, 0xe3, 0x98, 0xcf, 0xe4, 0xd7, 0xec,
+	0x86, 0x8d, 0xd1, 0x63, 0x68, 0x0c, 0x15, 0xe4, 0x67, 0xf2, 0x6e, 0x69, 0x78, 0x10, 0xa2, 0xcf,
+	0xa0, 0x6d, 0x19, 0xcc, 0x04, 0xcd, 0xe4, 0xae, 0x91, 0x96, 0x61, 0x62, 0x91, 0x4a, 0x8e, 0x49,
+	0xc4, 0x7d, 0xcb, 0xcc, 0x88, 0xef, 0x4d, 0x22, 0xfe, 0xd6, 0xd8, 0xf0, 0x35, 0xec, 0x50, 0xed,
+	0x5d, 0xdf, 0x7a, 0x50, 0x05, 0x8a, 0xfb, 0xa2, 0xf9, 0xe5, 0xae, 0x32, 0x64, 0xc1, 0xf1, 0xa4,
+	0x4d, 0xf3, 0xa0, 0x44, 0x5f, 0xc0, 0xf6, 0x38, 0xbe, 0x65, 0xc2, 0x1f, 0x2b, 0x59, 0x95, 0x92,
+	0xea, 0x60, 0x5b, 0x1d, 0x5c, 0xa8, 0x40, 0x9a, 0x7a, 0x8f, 0xfe, 0x97, 0xe8, 0x2b, 0x68, 0x85,
+	0x39, 0xd7, 0x2a, 0xed, 0xd5, 0x99, 0x8e, 0x3a, 0x93, 0xf7, 0x39, 0x29, 0x6e, 0x43, 0xdf, 0x87,
+	0x2d, 0xa1, 0x7d, 0x27, 0x7b, 0x5b, 0xfa, 0x04, 0xa8, 0x13, 0xc6, 0x9d, 0x24, 0x5d, 0x42, 0x9f,
+	0x03, 0xba, 0x89, 0x67, 0xc1, 0x15, 0x13, 0x7e, 0x2e, 0x30, 0x1a, 0x3a, 0xae, 0x3b, 0x76, 0x65,
+	0x11, 0xf4, 0x3f, 0x83, 0x36, 0x7b, 0x1f, 0x5c, 0x51, 0x3e, 0x62, 0xbe, 0xa0, 0x09, 0x93, 0x3d,
+	0x4f, 0x93, 0xfe, 0xb4, 0xa8, 0xc0, 0xd1, 0x99, 0xdd, 0x44, 0xd4, 0x9e, 0x33, 0x9e, 0x88, 0x39,
+	0x69, 0xb1, 0x3c, 0x0e, 0xbd, 0x82, 0xae, 0xb2, 0xb1, 0x32, 0xcf, 0x8d, 0x16, 0xd8, 0x0f, 0xd9,
+	0x98, 0xce, 0x7b, 0xa0, 0x73, 0x08, 0x4d, 0x22, 0xde, 0xcf, 0x96, 0x4e, 0xd5, 0x8a, 0x3e, 0x41,
+	0xdf, 0xdf, 0x3d, 0xd1, 0xb4, 0x27, 0xe8, 0xfb, 0xd2, 0x89, 0x83, 0x9f, 0x02, 0xba, 0x2b, 0x08,
+	0xea, 0x80, 0xab, 0x54, 0x34, 0xa1, 0xa1, 0x7e, 0x17, 0x91, 0x5a, 0xc9, 0x45, 0xea, 0xeb, 0xca,
+	0x8f, 0x1c, 0xfc, 0x4b, 0xf0, 0xde, 0x50, 0xc1, 0x4e, 0xc6, 0x54, 0xca, 0x2c, 0xef, 0x9c, 0x45,
+	0xde, 0xa9, 0xa3, 0x92, 0xd1, 0x44, 0xda, 0xdc, 0x37, 0x80, 0x8a, 0xd4, 0x61, 0x1c, 0x5f, 0x33,
+	0x53, 0x5d, 0x5c, 0x62, 0x21, 0xfc, 0x5f, 0x07, 0xea, 0x6f, 0xc6, 0xd1, 0xe8, 0x2a, 0xc9, 0xe5,
+	0x93, 0xab, 0xf3, 0x69, 0x1f, 0xea, 0x7c, 0x36, 0x19, 0x32, 0x61, 0x53, 0xc9, 0x42, 0xba, 0x1a,
+	0x45, 0x62, 0x1c, 0xf1, 0x34, 0xdf, 0x53, 0x50, 0x9d, 0x88, 0x45, 0x34, 0x8a, 0xb8, 0xcd, 0x23,
+	0x0b, 0xd9, 0xcc, 0x4c, 0x6c, 0x1c, 0xf4, 0x6a, 0x59, 0x66, 0xa6, 0x28, 0xf4, 0x09, 0x78, 0x21,
+	0x9b, 0x52, 0x91, 0xcc, 0x04, 0xd3, 0xb9, 0xe4, 0x92, 0x05, 0x42, 0x65, 0x68, 0x18, 0xc9, 0x84,
+	0xf2, 0x80, 0xf5, 0xb6, 0xf4, 0x62, 0x06, 0xa3, 0x57, 0xb0, 0x7d, 0x49, 0x05, 0xf3, 0x03, 0x65,
+	0x10, 0x26, 0x7b, 0x8d, 0x43, 0x37, 0x2d, 0x20, 0x99, 0x9d, 0x48, 0xf3, 0x32, 0xfd, 0x65, 0x12,
+	0xdf, 0x02, 0x3a, 0x9f, 0xcb, 0x84, 0x4d, 0x06, 0xfc, 0x32, 0x26, 0x4c, 0x4e, 0x63, 0x2e, 0x99,
+	0xf2, 0x01, 0x13, 0x22, 0xf5, 0x01, 0x13, 0x42, 0x45, 0xeb, 0xa5, 0xb6, 0x8c, 0x32, 0x65, 0x16,
+	0xad, 0xc6, 0x58, 0x24, 0x5d, 0x42, 0x2f, 0xa1, 0x31, 0x4c, 0x53, 0xc7, 0x5d, 0x9a, 0x3a, 0x5b,
+	0x43, 0xfd, 0x95, 0xf8, 0x2d, 0xa0, 0x73, 0xc6, 0xc3, 0xe3, 0x38, 0xbe, 0x8e, 0xf8, 0x88, 0xb0,
+	0x6f, 0x66, 0x4c, 0x26, 0xe8, 0x09, 0x78, 0x86, 0x56, 0xda, 0x19, 0x5c, 0xd2, 0x30, 0x08, 0xd3,
+	0x18, 0x16, 0xda, 0x59, 0xab, 0x7a, 0x99, 0x32, 0xf8, 0x37, 0xd0, 0x3c, 0xa3, 0x82, 0xb3, 0xf0,
+	0x22, 0xbe, 0x66, 0xba, 0xbe, 0x25, 0xea, 0xc7, 0x6a, 0x61, 0x00, 0xf4, 0x09, 0x80, 0x95, 0x30,
+	0xe5, 0xe0, 0x91, 0x86, 0x91, 0x69, 0x10, 0xe6, 0x4a, 0x98, 0x5b, 0x28, 0x61, 0x7f, 0x71, 0x60,
+	0xaf, 0x20, 0xed, 0x4a, 0x3b, 0x65, 0x5c, 0x2b, 0x79, 0xae, 0x4f, 0x01, 0x86, 0xe6, 0x68, 0xda,
+	0xd2, 0x5c, 0xe2, 0x59, 0xcc, 0x20, 0x44, 0xcf, 0xa1, 0xae, 0xf7, 0xa5, 0x85, 0x6a, 0x47, 0x19,
+	0x2d, 0xa7, 0x0b, 0xb1, 0xcb, 0xf8, 0xcf, 0x0e, 0x3c, 0x3a, 0x1e, 0x47, 0x3c, 0x3c, 0x8f, 0x46,
+	0x9c, 0xaa, 0x70, 0x48, 0x0d, 0x57, 0xd4, 0xcb, 0x29, 0xe9, 0xb5, 0x5c, 0xaa, 0x67, 0xd0, 0x1c,
+	0x2a, 0x62, 0xbe, 0x59, 0x73, 0x75, 0x51, 0x01, 0x8d, 0x32, 0x26, 0xdc, 0x87, 0xba, 0xa9, 0x8f,
+	0xda, 0xd8, 0x35, 0x62, 0x21, 0x7c, 0x0e, 0xfb, 0x65, 0x29, 0x56, 0x1a, 0xe4, 0x39, 0xec, 0x18,
+	0x26, 0x32, 0xdd, 0xac, 0x85, 0xd8, 0x26, 0xed, 0x61, 0x81, 0x04, 0xfe, 0xab, 0x03, 0xc8, 0xda,
+	0xf7, 0x24, 0x0e, 0x1f, 0xa8, 0xd8, 0x53, 0x80, 0x2b, 0x2a, 0xaf, 0xfc, 0x45, 0x7d, 0xd8, 0x26,
+	0x9e, 0xc2, 0xbc, 0x53, 0x08, 0x95, 0x49, 0x0b, 0xb6, 0x46, 0xbf, 0x05, 0x22, 0xe7, 0xed, 0x6a,
+	0xc1, 0xdb, 0x3f, 0x81, 0xbd, 0x82, 0x20, 0x2b, 0x75, 0x43, 0x50, 0x0d, 0xe2, 0x90, 0x59, 0xab,
+	0xea, 0x7f, 0x3c, 0x84, 0x5e, 0x3f, 0x08, 0xd4, 0x00, 0xa4, 0x82, 0xde, 0xe4, 0x56, 0xaa, 0x4b,
+	0x17, 0x6a, 0x6a, 0x8f, 0x6a, 0xfa, 0xae, 0x72, 0x83, 0x06, 0xd0, 0x2b, 0x35, 0xef, 0x08, 0x7f,
+	0xa8, 0x07, 0x26, 0xdb, 0xf3, 0x4d, 0xab, 0xca, 0x4f, 0x52, 0x6a, 0x04, 0x12, 0xe6, 0x17, 0x7f,
+	0x5b, 0x81, 0xc7, 0x4b, 0x98, 0xac, 0x94, 0xb3, 0x9f, 0xc5, 0x97, 0xc9, 0xdd, 0x97, 0xa6, 0x11,
+	0xae, 0x20, 0x70, 0xa4, 0x9d, 0x6f, 0xdb, 0x82, 0x3d, 0x88, 0x7e, 0x0b, 0x3b, 0x82, 0x05, 0xf1,
+	0x0d, 0x13, 0x73, 0xdf, 0xd2, 0x32, 0x09, 0xfe, 0xc5, 0x7a, 0x5a, 0xc4, 0x1e, 0xca, 0xd3, 0x6c,
+	0x8b, 0x02, 0xf2, 0xe0, 0xc7, 0xd0, 0xcc, 0x2d, 0xdf, 0xd7, 0x00, 0xbc, 0x5c, 0x03, 0x38, 0xe8,
+	0xc3, 0xde, 0x12, 0x0e, 0x9b, 0x90, 0xc0, 0xef, 0xa0, 0x73, 0x12, 0x47, 0xbc, 0x30, 0x9e, 0x65,
+	0xbb, 0x9d, 0xfc, 0x6c, 0xb4, 0xd9, 0x70, 0x16, 0xc1, 0xe3, 0x32, 0x5d, 0xb9, 0xc6, 0x47, 0xaf,
+	0xcb, 0x63, 0x84, 0x71, 0x55, 0x57, 0xd1, 0x2f, 0xd3, 0x29, 0x8d, 0x12, 0xf8, 0x18, 0x3c, 0x9d,
+	0x8f, 0x6a, 0xdf, 0x0a, 0xd9, 0x4b, 0xb9, 0x5e, 0x29, 0xe7, 0x3a, 0x26, 0x50, 0x5d, 0x73, 0x7c,
+	0x1f, 0xea, 0x92, 0x89, 0x88, 0x8e, 0xd3, 0xf6, 0x67, 0xa0, 0xf5, 0x09, 0x86, 0x7f, 0x0f, 0xdd,
+	0x5f, 0x47, 0xc9, 0x55, 0x28, 0xe8, 0xad, 0xa2, 0x2d, 0x3f, 0xa4, 0x58, 0x1d, 0xa5, 0x0a, 0x04,
+	0x71, 0x94, 0x05, 0x9f, 0xb6, 0x7e, 0xa6, 0xba, 0xd5, 0x47, 0xb3, 0xc2, 0x17, 0xf0, 0xa8, 0xc4,
+	0x7b, 0xa5, 0xe9, 0x5f, 0x42, 0xa7, 0x54, 0xa2, 0x8c, 0xf5, 0x3d, 0xb2, 0x53, 0xac, 0x51, 0x12,
+	0xff, 0xd1, 0x81, 0xbd, 0x0b, 0x41, 0xb9, 0xbc, 0x64, 0x22, 0x5f, 0xa5, 0xd2, 0x4a, 0xe0, 0x2c,
+	0x2a, 0xc1, 0xff, 0xd7, 0x6a, 0xca, 0x8e, 0xaa, 0xde, 0x71, 0xd4, 0xaf, 0xa0, 0x5b, 0x94, 0xe0,
+	0xc3, 0x4b, 0xef, 0x37, 0xd0, 0x4d, 0x07, 0x31, 0x45, 0x52, 0xae, 0xaf, 0x57, 0x08, 0xaa, 0x97,
+	0x22, 0x9e, 0xa4, 0x55, 0x4f, 0xfd, 0xab, 0x71, 0x29, 0x89, 0xed, 0x04, 0x54, 0x49, 0xe2, 0xfb,
+	0xb5, 0x20, 0xf0, 0xa8, 0xc4, 0xf2, 0xc3, 0xd5, 0xf8, 0x87, 0x03, 0xbb, 0xe7, 0x53, 0x66, 0x23,
+	0x20, 0x55, 0xe2, 0x7b, 0x50, 0x33, 0x21, 0xe3, 0xe8, 0x90, 0x69, 0xa4, 0x09, 0x45, 0x0c, 0xfa,
+	0x1e, 0x37, 0x3d, 0x01, 0xcf, 0x8c, 0xe2, 0x69, 0xe3, 0xf6, 0x48, 0xc3, 0x20, 0x06, 0xa1, 0x1a,
+	0xb7, 0x6c, 0x4c, 0x6a, 0x45, 0x7a, 0xd5, 0x65, 0x41, 0x69, 0x0c, 0x71, 0xa2, 0x77, 0xe0, 0x29,
+	0xa0, 0xbc, 0x84, 0x2b, 0x75, 0xd6, 0x03, 0x83, 0xba, 0x24, 0x85, 0x34, 0xa1, 0x56, 0x28, 0x4f,
+	0x63, 0x4e, 0x69, 0x42, 0x97, 0x46, 0xac, 0xbb, 0x3c, 0x62, 0xbf, 0x55, 0x46, 0x61, 0x89, 0xed,
+	0x25, 0x1f, 0xa1, 0xab, 0x16, 0xdb, 0x59, 0xf5, 0xfe, 0x76, 0x96, 0x1b, 0x33, 0x6a, 0xf9, 0x31,
+	0x43, 0x99, 0x65, 0x7a, 0x2d, 0xf4, 0x04, 0xec, 0x11, 0xf5, 0x8b, 0x03, 0x40, 0x79, 0x5d, 0x36,
+	0x9c, 0xc2, 0x3e, 0x83, 0x76, 0xb1, 0x87, 0x59, 0x87, 0xb6, 0x0a, 0xfd, 0x08, 0xff, 0xcb, 0x01,
+	0xf4, 0x96, 0x8a, 0x24, 0x0a, 0xa2, 0x29, 0x4d, 0x3e, 0xc6, 0x20, 0x62, 0x15, 0xad, 0x66, 0x8a,
+	0xae, 0x34, 0x49, 0x21, 0x1c, 0xeb, 0xc5, 0x70, 0xc4, 0x7f, 0x72, 0x60, 0xaf, 0x20, 0xf8, 0x77,
+	0x62, 0x9f, 0x52, 0x6c, 0x56, 0x4b, 0xb1, 0x89, 0x47, 0xf0, 0x28, 0x6d, 0xc9, 0xe7, 0x09, 0x4d,
+	0x66, 0x0f, 0x8c, 0xb9, 0xcd, 0xa7, 0xa0, 0x08, 0xf6, 0xcb, 0x8c, 0xd6, 0xd5, 0x90, 0x4c, 0x35,
+	0xa9, 0x37, 0xdb, 0xcb, 0x64, 0x5b, 0x14, 0x48, 0x2c, 0x2c, 0xe3, 0xe6, 0x2c, 0x83, 0xff, 0xee,
+	0xe4, 0xe6, 0x0c, 0x26, 0x93, 0x87, 0xa9, 0x74, 0xd7, 0x9e, 0x95, 0x65, 0xf6, 0xb4, 0xde, 0x77,
+	0x17, 0xde, 0xdf, 0x38, 0x85, 0xf0, 0xdf, 0x1c, 0xe8, 0x16, 0x05, 0xdc, 0xd0, 0xf7, 0xaf, 0xa0,
+	0x7b, 0x19, 0xcf, 0x78, 0xe8, 0x2f, 0x8d, 0x00, 0xa4, 0xd7, 0xc8, 0x26, 0x61, 0xd0, 0x87, 0x9d,
+	0x93, 0x99, 0x4c, 0xe2, 0x09, 0x13, 0xb9, 0x26, 0x79, 0xe7, 0x82, 0x7e, 0x00, 0x8d, 0x29, 0x95,
+	0xf2, 0x36, 0x16, 0x59, 0xed, 0x4d, 0x61, 0xec, 0x43, 0x67, 0x41, 0x62, 0xa5, 0x3e, 0xcf, 0xa0,
+	0x19, 0xd8, 0x5d, 0x8b, 0x4b, 0x23, 0xa4, 0x28, 0xf3, 0x60, 0x28, 0x99, 0x94, 0x51, 0x9c, 0x6a,
+	0x93, 0x82, 0xf8, 0xdf, 0x0e, 0xb4, 0xec, 0xa4, 0x6f, 0xdd, 0x5f, 0xbc, 0xa8, 0x39, 0xe5, 0x8b,
+	0xda, 0x06, 0xd7, 0x53, 0xb7, 0x74, 0x3d, 0xd5, 0x23, 0x94, 0x89, 0x3c, 0xfb, 0x1e, 0x60, 0x20,
+	0xf4, 0x15, 0xb4, 0x55, 0x47, 0x50, 0x2c, 0xed, 0x60, 0x5d, 0x5b, 0x7e, 0x09, 0x6c, 0xd9, 0x6d,
+	0x17, 0xe6, 0x2e, 0x78, 0x0e, 0x1d, 0x2b, 0xfb, 0xba, 0xc0, 0xff, 0x81, 0x7a, 0x6d, 0x33, 0xbb,
+	0xec, 0x44, 0xb9, 0x6b, 0x6e, 0xe4, 0x39, 0xad, 0x49, 0xb6, 0x05, 0xf7, 0xa1, 0x43, 0xd8, 0x28,
+	0x92, 0xc9, 0x5a, 0x93, 0x3f, 0x01, 0x2f, 0x18, 0x47, 0x8c, 0xe7, 0xcd, 0x60, 0x10, 0x83, 0x10,
+	0x7f, 0x0a, 0x2d, 0xc2, 0x24, 0x5b, 0x13, 0x82, 0xf8, 0x0d, 0xec, 0x9e, 0xb2, 0xe1, 0x6c, 0x74,
+	0xcf, 0x9b, 0xc3, 0x33, 0x68, 0x4a, 0x26, 0x6e, 0x98, 0xf0, 0x7f, 0x27, 0xe3, 0x6c, 0x66, 0x35,
+	0xa8, 0x9f, 0xcb, 0x98, 0xe3, 0x33, 0xd8, 0xfd, 0x9a, 0xca, 0xa4, 0x1f, 0x8a, 0xe3, 0x70, 0xfc,
+	0xb0, 0x9c, 0x4c, 0xdf, 0xa3, 0x2b, 0x8b, 0xf7, 0x68, 0xec, 0x03, 0xca, 0x93, 0x59, 0x29, 0x4f,
+	0xee, 0xe5, 0xb9, 0x52, 0x78, 0x79, 0x2e, 0xbd, 0x68, 0xbb, 0xa5, 0x17, 0xed, 0x61, 0x5d, 0xbf,
+	0x94, 0xff, 0xf0, 0x7f, 0x03, 0x00, 0x2a, 0x8c, 0xe4, 0xbc, 0x3d, 0x17, 0x00, 0x00,
+}
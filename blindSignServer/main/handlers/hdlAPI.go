@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// the OpenAPI document is generated from the message types once
+var openAPIDocument = model.NewOpenAPIDocument(model.APIVersion1)
+
+// Lists the names of all api versions served by the server
+func GetAPIVersions(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	var versions []string
+
+	for version := model.APIVersion(model.APIVersionLegacy); version <= model.LatestAPIVersion; version++ {
+		versions = append(versions, version.String())
+	}
+	data := map[string]interface{}{"versions": versions}
+	render(c, gin.H{"payload": &data}, &status, &err)
+}
+
+// Serves the OpenAPI document of the v1 routes
+func GetOpenAPIDocument(c *gin.Context) {
+	c.JSON(http.StatusOK, openAPIDocument)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetAPIVersions(t *testing.T) {
+	var msgVersions model.MsgResponseAPIVersions
+
+	response := callURL("GET", model.RouteAPIVersions, http.StatusOK, nil, t)
+	if err := json.Unmarshal([]byte(response.String()), &msgVersions); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if msgVersions.Err != "" {
+		t.Error(msgVersions.Err)
+		t.Fail()
+	}
+	if len(msgVersions.Data.Versions) != 2 || msgVersions.Data.Versions[1] != "v1" {
+		t.Errorf("wrong versions: %v", msgVersions.Data.Versions)
+		t.Fail()
+	}
+}
+
+func TestGetOpenAPIDocument(t *testing.T) {
+	var doc model.OpenAPIDocument
+
+	response := callURL("GET", model.APIVersion(model.APIVersion1).Prefix()+model.RouteOpenAPI, http.StatusOK, nil, t)
+	if err := json.Unmarshal([]byte(response.String()), &doc); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if doc.OpenAPI == "" || len(doc.Servers) != 1 || doc.Servers[0].URL != "/v1" {
+		t.Error("wrong document header")
+		t.Fail()
+	}
+
+	// every documented operation has to be routed in the v1 group
+	routes := map[string]bool{}
+	for _, route := range r.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	for _, op := range model.APIOperations {
+		if doc.Paths[op.Path.String()][strings.ToLower(op.Method)] == nil {
+			t.Error("operation not documented: " + op.Path.String())
+			t.Fail()
+		}
+		if !routes[op.Method+" "+op.Path.Versioned(model.APIVersion1)] {
+			t.Error("operation not routed: " + op.Method + " " + op.Path.Versioned(model.APIVersion1))
+			t.Fail()
+		}
+	}
+
+	// every routed operation of the v1 group has to be documented, except the document itself
+	prefix := model.APIVersion(model.APIVersion1).Prefix()
+	for _, route := range r.Routes() {
+		if !strings.HasPrefix(route.Path, prefix+"/") || route.Path == prefix+model.RouteOpenAPI {
+			continue
+		}
+		if doc.Paths[strings.TrimPrefix(route.Path, prefix)][strings.ToLower(route.Method)] == nil {
+			t.Error("route not documented: " + route.Method + " " + route.Path)
+			t.Fail()
+		}
+	}
+}
+
+func TestV1SendBooking(t *testing.T) {
+	setup(t)
+	var msgBooking *model.MsgResponseSendBooking
+	session := loginCustomer("customer", t)
+
+	values := model.MsgRequestSendBooking{FlightID: 2, FareClass: model.FarePremium}
+	jsonValue, _ := json.Marshal(values)
+	response := callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).Versioned(model.APIVersion1), session, http.StatusAccepted, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal([]byte(response.String()), &msgBooking); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if msgBooking.Err != "" || msgBooking.Data.Token == "" {
+		t.Error("no token received: " + msgBooking.Err)
+		t.Fail()
+	}
+}
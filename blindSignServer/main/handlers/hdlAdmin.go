@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/model"
+	"crypto/subtle"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+var errNoAdmin = errors.New("admin key is missing or not valid")
+
+// Rejects requests without the configured admin key of the operators. The admin
+// routes are closed if no key is configured.
+func requireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// compare in constant time, so the time does not leak the key
+		adminKey := config.GetConfigAdminKey()
+		key := c.GetHeader(model.HeaderAdminKey)
+		if adminKey != "" && subtle.ConstantTimeCompare([]byte(adminKey), []byte(key)) == 1 {
+			return
+		}
+		var status = http.StatusUnauthorized
+		var err = errNoAdmin
+		render(c, gin.H{}, &status, &err)
+		c.Abort()
+	}
+}
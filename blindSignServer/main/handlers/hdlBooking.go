@@ -1,61 +1,103 @@
 package handlers
 
 import (
+	"blindSignAccount/main/model"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
 
+// Books a flight for the customer of the session
 func SendBooking(c *gin.Context) {
 	var status = http.StatusBadRequest
 	var err error
-	var token = make(map[string]string, 0)
-	var customerID, flightID int
-	var bLevelID string
+	var token = make(map[string]interface{}, 0)
+	var customerID, flightID, bookingID int
+	var fareClass string
+	var tokens []*model.EarnedToken
 
-	Server.CntReqSendBooking++
+	Server.CntReqSendBooking.Inc()
 
 	err = errors.New("unknown error")
-	elements := map[string]interface{}{"customerID": customerID, "flightID": flightID, "bLevelID": bLevelID}
+	elements := map[string]interface{}{"flightID": flightID}
+	optional := map[string]interface{}{"fareClass": fareClass}
 
 	defer render(c, gin.H{"payload": &token}, &status, &err)
 
-	if err = parseBody(c, &elements); err != nil {
+	if customerID, err = authenticateCustomer(c, &status); err != nil {
+		return
+	}
+	if err = parseBodyWithOptional(c, &elements, &optional); err != nil {
 		return
 	}
-	customerID = elements["customerID"].(int)
 	flightID = elements["flightID"].(int)
-	bLevelID = elements["bLevelID"].(string)
+	fareClass = optional["fareClass"].(string)
 
-	if token["token"], err = Server.Booking(flightID, customerID, bLevelID); err != nil {
+	if tokens, bookingID, err = Server.BookFlight(flightID, customerID, fareClass); err != nil {
 		return
 	}
+	token["bookingID"] = bookingID
+	token["tokens"] = tokens
+	token["token"] = ""
+	if len(tokens) > 0 {
+		token["token"] = tokens[0].Token
+	}
 
 	status = http.StatusAccepted
 }
 
+// Cancels the booking of the body element 'bookingID' of the customer of the session
+func CancelBooking(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID, bookingID int
+	var data = make(map[string]interface{}, 0)
+
+	Server.CntReqCancelBooking.Inc()
+
+	err = errors.New("unknown error")
+	elements := map[string]interface{}{"bookingID": bookingID}
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if customerID, err = authenticateCustomer(c, &status); err != nil {
+		return
+	}
+	if err = parseBody(c, &elements); err != nil {
+		return
+	}
+	bookingID = elements["bookingID"].(int)
+
+	if data["codeMayExist"], err = Server.CancelBooking(customerID, bookingID); err != nil {
+		return
+	}
+	status = http.StatusOK
+}
+
 func HdlGetBookingCode(c *gin.Context) {
 	var status = http.StatusBadRequest
 	var err error
 	var data = make(map[string]string, 0)
 	var hashValue, signature []byte
 	var bLevelID string
+	var points = 1
 
-	Server.CntReqGetBookingCode++
+	Server.CntReqGetBookingCode.Inc()
 
 	err = errors.New("unknown error")
 	elements := map[string]interface{}{"hashValue": hashValue, "signature": signature, "bLevelID": bLevelID}
+	optional := map[string]interface{}{"points": points}
 	defer render(c, gin.H{"payload": &data}, &status, &err)
 
-	if err = parseBody(c, &elements); err != nil {
+	if err = parseBodyWithOptional(c, &elements, &optional); err != nil {
 		return
 	}
 
 	bLevelID = elements["bLevelID"].(string)
 	hashValue = elements["hashValue"].([]byte)
 	signature = elements["signature"].([]byte)
+	points = optional["points"].(int)
 
-	if data["code"], err = Server.GetBookingCode(bLevelID, hashValue, signature); err != nil {
+	if data["code"], err = Server.GetBookingCode(bLevelID, points, hashValue, signature); err != nil {
 		return
 	}
 	status = http.StatusAccepted
@@ -5,19 +5,27 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSendBooking(t *testing.T) {
 	setup(t)
 	var msgBooking *model.MsgResponseSendBooking
 
+	// a booking needs a logged in customer
+	jsonValue, _ := json.Marshal(model.MsgRequestSendBooking{FlightID: 2})
+	callURL("POST", model.RoutePath(model.PathSendBooking).String(), http.StatusUnauthorized, bytes.NewBuffer(jsonValue), t)
+	callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), "unknown", http.StatusUnauthorized, bytes.NewBuffer(jsonValue), t)
+	session := loginCustomer("customer", t)
+
 	// try to fail
 	msgBooking = nil
 	values := map[string]interface{}{}
-	jsonValue, _ := json.Marshal(values)
-	response := callURL("POST", model.RoutePath(model.PathSendBooking).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	jsonValue, _ = json.Marshal(values)
+	response := callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), session, http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
 	if err := json.Unmarshal([]byte(response.String()), &msgBooking); err != nil {
 		t.Error(err)
 		t.Fail()
@@ -26,8 +34,8 @@ func TestSendBooking(t *testing.T) {
 		t.Error("no error msg received")
 		t.Fail()
 	}
-	if !strings.Contains(msgBooking.Err, "missing") || !strings.Contains(msgBooking.Err, "bLevelID") ||
-		!strings.Contains(msgBooking.Err, "customerID") || !strings.Contains(msgBooking.Err, "flightID") {
+	if !strings.Contains(msgBooking.Err, "missing") || strings.Contains(msgBooking.Err, "fareClass") ||
+		!strings.Contains(msgBooking.Err, "flightID") {
 		t.Error(msgBooking.Err)
 		t.Fail()
 	}
@@ -38,9 +46,9 @@ func TestSendBooking(t *testing.T) {
 
 	// must not fail
 	msgBooking = nil
-	values = map[string]interface{}{"customerID": 1, "flightID": 2, "bLevelID": "middle"}
+	values = map[string]interface{}{"flightID": 2, "fareClass": model.FarePremium}
 	jsonValue, _ = json.Marshal(values)
-	response = callURL("POST", model.RoutePath(model.PathSendBooking).String(), http.StatusAccepted, bytes.NewBuffer(jsonValue), t)
+	response = callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), session, http.StatusAccepted, bytes.NewBuffer(jsonValue), t)
 	if err := json.Unmarshal([]byte(response.String()), &msgBooking); err != nil {
 		t.Error(err)
 		t.Fail()
@@ -53,8 +61,12 @@ func TestSendBooking(t *testing.T) {
 		t.Error("no token received")
 		t.Fail()
 	}
+	// the server decides the bonus level of the token
+	if len(msgBooking.Data.Tokens) != 1 || msgBooking.Data.Tokens[0].BLevelID != "middle" {
+		t.Errorf("wrong earned tokens: %s", response.String())
+	}
 
-	if Server.CntReqSendBooking != 2 {
+	if Server.CntReqSendBooking != 4 {
 		t.Error("wrong count for request")
 		t.Fail()
 	}
@@ -93,10 +105,11 @@ func TestBookingBlindSignature(t *testing.T) {
 	setup(t)
 	var msgBooking *model.MsgResponseSendBooking
 	var msgBlindSign *model.MsgResponseBlindSignature
+	session := loginCustomer("customer", t)
 
-	values := map[string]interface{}{"customerID": 1, "flightID": 2, "bLevelID": "middle"}
+	values := map[string]interface{}{"flightID": 2, "fareClass": model.FarePremium}
 	jsonValue, _ := json.Marshal(values)
-	response := callURL("POST", model.RoutePath(model.PathSendBooking).String(), http.StatusAccepted, bytes.NewBuffer(jsonValue), t)
+	response := callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), session, http.StatusAccepted, bytes.NewBuffer(jsonValue), t)
 	if err := json.Unmarshal([]byte(response.String()), &msgBooking); err != nil {
 		t.Error(err)
 		t.Fail()
@@ -124,3 +137,64 @@ func TestBookingBlindSignature(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestBlindSignatureBusy(t *testing.T) {
+	setup(t)
+	Server.SetSignPool(model.NewSignPool(1, 0, 2*time.Second))
+	tokens, _ := Server.Booking(1, 1, model.FarePremium)
+	token := tokens[0].Token
+
+	// occupy the only worker
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go Server.SignPool.Do(func() error {
+		close(started)
+		<-done
+		return nil
+	})
+	<-started
+	defer close(done)
+
+	values := map[string]interface{}{"bLevelID": "middle", "token": token, "action": model.ActionBooking,
+		"blindToken": "123455BlindToken"}
+	jsonValue, _ := json.Marshal(values)
+	req, _ := http.NewRequest("POST", model.RoutePath(model.PathBlindSignature).String(), bytes.NewBuffer(jsonValue))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "2" {
+		t.Errorf("wrong status %d or Retry-After '%s'", w.Code, w.Header().Get("Retry-After"))
+		t.Fail()
+	}
+}
+
+func TestCancelBooking(t *testing.T) {
+	setup(t)
+	var msgBooking model.MsgResponseSendBooking
+	var msgCancel model.MsgResponseCancelBooking
+	session := loginCustomer("customer", t)
+
+	jsonValue, _ := json.Marshal(model.MsgRequestSendBooking{FlightID: 2, FareClass: model.FarePremium})
+	response := callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), session, http.StatusAccepted, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msgBooking); err != nil || msgBooking.Data.BookingID == 0 {
+		t.Fatalf("no booking id received: %s", response.String())
+	}
+
+	// only the customer of the booking can cancel it
+	jsonValue, _ = json.Marshal(model.MsgRequestCancelBooking{BookingID: msgBooking.Data.BookingID})
+	callURLAsCustomer("POST", model.RoutePath(model.PathCancelBooking).String(), loginCustomer("other", t), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+
+	jsonValue, _ = json.Marshal(model.MsgRequestCancelBooking{BookingID: msgBooking.Data.BookingID})
+	response = callURLAsCustomer("POST", model.APIVersion(model.APIVersion1).Prefix()+model.RoutePath(model.PathCancelBooking).String(), session, http.StatusOK, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msgCancel); err != nil || msgCancel.Err != "" || msgCancel.Data.CodeMayExist {
+		t.Errorf("wrong cancellation response: %s", response.String())
+	}
+
+	// a cancelled booking is unknown
+	jsonValue, _ = json.Marshal(model.MsgRequestCancelBooking{BookingID: msgBooking.Data.BookingID})
+	callURLAsCustomer("POST", model.RoutePath(model.PathCancelBooking).String(), session, http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	callURLAsCustomer("POST", model.RoutePath(model.PathCancelBooking).String(), session, http.StatusBadRequest, bytes.NewBufferString("{}"), t)
+	callURL("POST", model.RoutePath(model.PathCancelBooking).String(), http.StatusUnauthorized, bytes.NewBuffer(jsonValue), t)
+	if Server.CntReqCancelBooking != 5 {
+		t.Error("wrong count for request")
+	}
+}
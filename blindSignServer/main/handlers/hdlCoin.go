@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+)
+
+// Publishes the values and public keys of the coin denominations
+func GetCoinDenominations(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	var data = Server.CoinDenominations()
+
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+}
+
+// Withdraws coins for a booking Token, the body contains the blind coins
+func HdlWithdrawCoins(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req model.MsgRequestWithdrawCoins
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = decodeBody(c, &req); err != nil {
+		return
+	}
+
+	var blindSigs []string
+	if blindSigs, err = Server.WithdrawCoins(req.BLevelID, req.Token, req.BlindCoins); err != nil {
+		return
+	}
+
+	data["blindSignatures"] = blindSigs
+	status = http.StatusAccepted
+}
+
+// Spends coins for a reward, the body contains the coins and the blind coins of the change
+func HdlSpendCoins(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req model.MsgRequestSpendCoins
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = decodeBody(c, &req); err != nil {
+		return
+	}
+
+	var bonusData string
+	var blindSigs []string
+	if bonusData, blindSigs, err = Server.SpendCoins(req.Coins, req.BLevelID, req.RewardID, req.BlindChange); err != nil {
+		return
+	}
+
+	data["bonusData"] = bonusData
+	data["blindSignatures"] = blindSigs
+	status = http.StatusAccepted
+}
+
+func ProtoCoinDenominations(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	var resp pb.CoinDenominationsResponse
+
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	resp.Denominations = model.CoinDenominationsToProto(Server.CoinDenominations())
+}
+
+func ProtoWithdrawCoins(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.WithdrawCoinsRequest
+	var resp pb.WithdrawCoinsResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if resp.BlindSignatures, err = Server.WithdrawCoins(req.BLevelId, req.Token, model.BlindCoinsFromProto(req.BlindCoins)); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoSpendCoins(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.SpendCoinsRequest
+	var resp pb.SpendCoinsResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if resp.BonusData, resp.BlindSignatures, err = Server.SpendCoins(model.CoinsFromProto(req.Coins), req.BLevelId, req.RewardId,
+		model.BlindCoinsFromProto(req.BlindChange)); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
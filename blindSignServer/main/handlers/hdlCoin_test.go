@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetCoinDenominations(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseCoinDenominations
+
+	response := callURL("GET", model.RoutePath(model.PathCoinDenominations).String(), http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || len(msg.Data) != len(Server.CoinDenominations()) {
+		t.Fatalf("wrong denominations: %s", response.String())
+	}
+	for _, denomination := range msg.Data {
+		if denomination.SkKey != nil || denomination.PublicKey.N == nil {
+			t.Errorf("wrong keys of denomination %d", denomination.Value)
+		}
+	}
+}
+
+func TestHdlWithdrawCoins(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseWithdrawCoins
+
+	values := model.MsgRequestWithdrawCoins{BLevelID: "low", Token: "unknown",
+		BlindCoins: []*model.BlindCoin{{Value: 1, BlindToken: model.HexBytes{1, 2}}}}
+	jsonValue, _ := json.Marshal(values)
+	response := callURL("POST", model.RoutePath(model.PathWithdrawCoins).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Err != "Token is not valid" {
+		t.Errorf("wrong response: %s", response.String())
+	}
+
+	session := loginCustomer("customer", t)
+	callURLAsCustomer("POST", model.RoutePath(model.PathWithdrawCoins).String(), session, http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+}
+
+func TestHdlSpendCoins(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseSpendCoins
+
+	values := model.MsgRequestSpendCoins{BLevelID: "low", RewardID: "baggage",
+		Coins: []*model.Coin{{Value: 50, Serial: "serial", Signature: model.HexBytes{1, 2}}}}
+	jsonValue, _ := json.Marshal(values)
+	response := callURL("POST", model.RoutePath(model.PathSpendCoins).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Err != "coin has an invalid signature" {
+		t.Errorf("wrong response: %s", response.String())
+	}
+}
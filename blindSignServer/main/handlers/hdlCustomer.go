@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+	"strings"
+)
+
+var errNotLoggedIn = errors.New("customer is not logged in")
+var errCustomerSession = errors.New("customer sessions are not accepted by anonymous routes")
+
+// Returns the id of the customer who is authenticated by the session header.
+// The status is set to unauthorized if the session is missing or not valid.
+func authenticateCustomer(c *gin.Context, status *int) (int, error) {
+	header := c.GetHeader(model.HeaderAuthorization)
+	if !strings.HasPrefix(header, model.BearerPrefix) {
+		*status = http.StatusUnauthorized
+		return 0, errNotLoggedIn
+	}
+	customerID, err := Server.Authenticate(strings.TrimPrefix(header, model.BearerPrefix))
+	if err != nil {
+		*status = http.StatusUnauthorized
+	}
+	return customerID, err
+}
+
+// Rejects requests of anonymous routes which carry a customer session, so a booking
+// identity is never linked to the access or the participation of the bonus system
+func rejectCustomerSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(model.HeaderAuthorization) == "" {
+			return
+		}
+		var status = http.StatusBadRequest
+		var err = errCustomerSession
+		render(c, gin.H{}, &status, &err)
+		c.Abort()
+	}
+}
+
+// Creates a customer account, the body contains the elements 'name' and 'password'
+func PostCustomerRegister(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var name, password string
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	elements := map[string]interface{}{"name": name, "password": password}
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = parseBody(c, &elements); err != nil {
+		return
+	}
+	name = elements["name"].(string)
+	password = elements["password"].(string)
+
+	if data["customerID"], err = Server.RegisterCustomer(name, password); err != nil {
+		return
+	}
+	status = http.StatusOK
+}
+
+// Logs a customer in, the body contains the elements 'name' and 'password'
+func PostCustomerLogin(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var name, password, session string
+	var customerID int
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	elements := map[string]interface{}{"name": name, "password": password}
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = parseBody(c, &elements); err != nil {
+		return
+	}
+	name = elements["name"].(string)
+	password = elements["password"].(string)
+
+	if session, customerID, err = Server.Login(name, password); err != nil {
+		status = http.StatusUnauthorized
+		return
+	}
+	data["customerID"] = customerID
+	data["session"] = session
+	status = http.StatusOK
+}
+
+// Ends the session of the session header
+func PostCustomerLogout(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if data["customerID"], err = authenticateCustomer(c, &status); err != nil {
+		return
+	}
+	Server.Logout(strings.TrimPrefix(c.GetHeader(model.HeaderAuthorization), model.BearerPrefix))
+	status = http.StatusOK
+}
+
+func ProtoCustomerRegister(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID int
+	var req pb.CustomerRequest
+	var resp pb.CustomerResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+	if customerID, err = Server.RegisterCustomer(req.Name, req.Password); err != nil {
+		return
+	}
+	resp.CustomerId = int64(customerID)
+	status = http.StatusOK
+}
+
+func ProtoCustomerLogin(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID int
+	var req pb.CustomerRequest
+	var resp pb.CustomerResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+	if resp.Session, customerID, err = Server.Login(req.Name, req.Password); err != nil {
+		status = http.StatusUnauthorized
+		return
+	}
+	resp.CustomerId = int64(customerID)
+	status = http.StatusOK
+}
+
+// Lists the bookings of the customer of the session and the status of their codes
+func GetCustomerBookings(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID int
+	var data []*model.BookingStatus
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if customerID, err = authenticateCustomer(c, &status); err != nil {
+		return
+	}
+	data = Server.GetBookings(customerID)
+	status = http.StatusOK
+}
+
+func ProtoCustomerBookings(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID int
+	var resp pb.BookingsResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if customerID, err = authenticateCustomer(c, &status); err != nil {
+		return
+	}
+	for _, booking := range Server.GetBookings(customerID) {
+		resp.Bookings = append(resp.Bookings, model.BookingStatusToProto(booking))
+	}
+	status = http.StatusOK
+}
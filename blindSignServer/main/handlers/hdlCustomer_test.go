@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCustomerLogin(t *testing.T) {
+	setup(t)
+	var msgCustomer model.MsgResponseCustomer
+
+	jsonValue, _ := json.Marshal(model.MsgRequestCustomer{Name: "customer", Password: "password"})
+	response := callURL("POST", model.RoutePath(model.PathCustomerRegister).String(), http.StatusOK, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msgCustomer); err != nil || msgCustomer.Data.CustomerID != 1 {
+		t.Errorf("wrong registration response: %s", response.String())
+	}
+	// names are unique
+	callURL("POST", model.RoutePath(model.PathCustomerRegister).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+
+	response = callURL("POST", model.RoutePath(model.PathCustomerLogin).String(), http.StatusOK, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msgCustomer); err != nil || msgCustomer.Data.Session == "" {
+		t.Fatalf("no session received: %s", response.String())
+	}
+	session := msgCustomer.Data.Session
+
+	// the session is rejected by anonymous routes
+	callURLAsCustomer("POST", model.RoutePath(model.PathBlindSignature).String(), session, http.StatusBadRequest,
+		bytes.NewBufferString("{}"), t)
+
+	callURLAsCustomer("POST", model.RoutePath(model.PathCustomerLogout).String(), session, http.StatusOK, nil, t)
+	callURLAsCustomer("POST", model.RoutePath(model.PathCustomerLogout).String(), session, http.StatusUnauthorized, nil, t)
+
+	jsonValue, _ = json.Marshal(model.MsgRequestCustomer{Name: "customer", Password: "wrong password"})
+	callURL("POST", model.RoutePath(model.PathCustomerLogin).String(), http.StatusUnauthorized, bytes.NewBuffer(jsonValue), t)
+}
+
+func TestProtoCustomerLogin(t *testing.T) {
+	setup(t)
+	var msg pb.CustomerResponse
+
+	values := &pb.CustomerRequest{Name: "customer", Password: "password"}
+	if w, err := callURLWithProto("POST", model.RoutePath(model.PathCustomerRegister).String(), "", values, &msg); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("registration failed with status %d: %v", w.Code, err)
+	}
+	if w, err := callURLWithProto("POST", model.RoutePath(model.PathCustomerLogin).String(), "", values, &msg); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("login failed with status %d: %v", w.Code, err)
+	}
+	if msg.Session == "" || msg.CustomerId != 1 {
+		t.Errorf("wrong login response: %v", &msg)
+	}
+}
+
+func TestGetCustomerBookings(t *testing.T) {
+	setup(t)
+	var msgBookings model.MsgResponseBookings
+
+	callURL("GET", model.RoutePath(model.PathCustomerBookings).String(), http.StatusUnauthorized, nil, t)
+
+	session := loginCustomer("customer", t)
+	if _, _, err := Server.BookFlight(2, 1, model.FarePremium); err != nil {
+		t.Fatal(err)
+	}
+	response := callURLAsCustomer("GET", model.RoutePath(model.PathCustomerBookings).String(), session, http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msgBookings); err != nil || len(msgBookings.Data) != 1 ||
+		msgBookings.Data[0].Status != model.BookingCodePending || len(msgBookings.Data[0].PendingTokens) != 1 {
+		t.Errorf("wrong bookings: %s", response.String())
+	}
+
+	// bookings of other customers are not listed
+	response = callURLAsCustomer("GET", model.RoutePath(model.PathCustomerBookings).String(), loginCustomer("other", t), http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msgBookings); err != nil || len(msgBookings.Data) != 0 {
+		t.Errorf("bookings of another customer listed: %s", response.String())
+	}
+}
@@ -1,16 +1,18 @@
 package handlers
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/crypt"
 	"blindSignAccount/main/model"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"io/ioutil"
+	"net/http"
 	"reflect"
 	"strconv"
+	"time"
 )
 
 var Server *model.Server
@@ -31,6 +33,35 @@ func init() {
 	//}
 }
 
+// Replaces the server by a server of the read configuration. The server of init is created
+// before main reads the configuration, so it has the defaults of the model only.
+func InitServer() error {
+	server := model.NewServer()
+
+	// configured earning rules replace the default rules
+	if rules := config.GetConfigEarningRules(); len(rules) > 0 {
+		if err := server.SetEarningRules(rules); err != nil {
+			return err
+		}
+	}
+
+	// partners redeem vouchers by their configured keys
+	if partners := config.GetConfigPartners(); len(partners) > 0 {
+		if err := server.SetPartners(partners); err != nil {
+			return err
+		}
+	}
+
+	// the flight catalogue replaces the default flights
+	if flightFile := config.GetConfigFlightFile(); flightFile != "" {
+		if err := server.LoadFlights(flightFile); err != nil {
+			return err
+		}
+	}
+	Server = server
+	return nil
+}
+
 func New(data interface{}, err error) Msg {
 	var msg msg
 	msg.Data = data
@@ -41,6 +72,7 @@ func New(data interface{}, err error) Msg {
 }
 
 func render(c *gin.Context, data gin.H, statusCode *int, err *error) {
+	errorStatus(c, statusCode, *err)
 	msg := New(data["payload"], *err)
 
 	switch c.Request.Header.Get("Accept") {
@@ -48,18 +80,58 @@ func render(c *gin.Context, data gin.H, statusCode *int, err *error) {
 		// Respond with XML
 		c.XML(*statusCode, msg)
 	default: //"application/json"
-		// Respond with JSON
-		c.JSON(*statusCode, msg)
+		enc := responseEncoding(c)
+		if !enc.IsBinary() {
+			// Respond with JSON
+			c.JSON(*statusCode, msg)
+			return
+		}
+		// Respond with MessagePack or CBOR
+		body, encErr := enc.Marshal(msg)
+		if encErr != nil {
+			c.JSON(http.StatusInternalServerError, New(nil, encErr))
+			return
+		}
+		c.Data(*statusCode, enc.ContentType(), body)
+	}
+}
+
+// Sets the status of errors which are not caused by the request parameters.
+// Requests rejected by the signing pool get a 503 and a Retry-After header.
+func errorStatus(c *gin.Context, statusCode *int, err error) {
+	if err == errBodyTooLarge {
+		*statusCode = http.StatusRequestEntityTooLarge
+		return
+	}
+	if err != model.ErrSignPoolBusy {
+		return
+	}
+	*statusCode = http.StatusServiceUnavailable
+	retryAfter := int(Server.SignPool.RetryAfter / time.Second)
+	if retryAfter < 1 {
+		retryAfter = 1
 	}
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+}
+
+// Returns the encoding requested by the Accept header
+func responseEncoding(c *gin.Context) model.Encoding {
+	enc, _ := model.EncodingFromContentType(c.Request.Header.Get("Accept"))
+	return enc
 }
 
+// Reads the body with the encoding given by the Content-Type header
 func readBody(c *gin.Context) (map[string]interface{}, error) {
 	if c.Request.Body == nil {
 		return make(map[string]interface{}, 0), nil
 	}
-	bodyBytes, _ := ioutil.ReadAll(c.Request.Body)
+	bodyBytes, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
 	var values = make(map[string]interface{})
-	err := json.Unmarshal(bodyBytes, &values)
+	enc, _ := model.EncodingFromContentType(c.ContentType())
+	err = enc.Unmarshal(bodyBytes, &values)
 	return values, err
 }
 
@@ -94,6 +166,10 @@ func parseElements(elements *map[string]interface{}, body *map[string]interface{
 				(*elements)[elemName] = int(parameter.(float64))
 			case int:
 				(*elements)[elemName] = parameter.(int)
+			case int64:
+				(*elements)[elemName] = int(parameter.(int64))
+			case uint64:
+				(*elements)[elemName] = int(parameter.(uint64))
 			case string:
 				(*elements)[elemName], err = strconv.Atoi(parameter.(string))
 			default:
@@ -118,6 +194,9 @@ func parseElements(elements *map[string]interface{}, body *map[string]interface{
 			case string:
 				paramToString, _ := hex.DecodeString(parameter.(string))
 				(*elements)[elemName] = paramToString
+			case []byte:
+				// binary encodings send bytes raw
+				(*elements)[elemName] = parameter
 			default:
 				misTypes += elemName + " "
 			}
@@ -164,27 +243,42 @@ func parseElements(elements *map[string]interface{}, body *map[string]interface{
 
 func parseAdrBundle(paramMap map[string]interface{}) (*crypt.AddressBundle, error) {
 	var adrBdl = &crypt.AddressBundle{}
+	var found bool
 	// check if the map contains all elements
-	if _, found := paramMap["Seed"]; !found || paramMap["Seed"] == nil || reflect.TypeOf(paramMap["Seed"]).String() != "string" {
+	switch seed := paramMap["Seed"].(type) {
+	case string:
+		adrBdl.Seed, _ = hex.DecodeString(seed)
+	case []byte:
+		adrBdl.Seed = seed
+	default:
 		return nil, errors.New("missing seed")
 	}
-	if _, found := paramMap["AccountID"]; !found || reflect.TypeOf(paramMap["AccountID"]).String() != "float64" {
+	if adrBdl.AccountID, found = parseUint32(paramMap["AccountID"]); !found {
 		return nil, errors.New("missing AccountID")
 	}
-	if _, found := paramMap["AddressID"]; !found || reflect.TypeOf(paramMap["AddressID"]).String() != "float64" {
+	if adrBdl.AddressID, found = parseUint32(paramMap["AddressID"]); !found {
 		return nil, errors.New("missing AddressID")
 	}
-	if _, found := paramMap["Address"]; !found || reflect.TypeOf(paramMap["Address"]).String() != "string" {
+	if adrBdl.Address, found = paramMap["Address"].(string); !found {
 		return nil, errors.New("missing AddressID")
 	}
-	decodedSeed, _ := hex.DecodeString(paramMap["Seed"].(string))
-	adrBdl.Seed = decodedSeed
-	adrBdl.AccountID = uint32(paramMap["AccountID"].(float64))
-	adrBdl.AddressID = uint32(paramMap["AddressID"].(float64))
-	adrBdl.Address = paramMap["Address"].(string)
 	return adrBdl, nil
 }
 
+// Parses a number of a decoded body. JSON numbers are float64 values, numbers of binary
+// encodings are integers.
+func parseUint32(param interface{}) (uint32, bool) {
+	switch value := param.(type) {
+	case float64:
+		return uint32(value), true
+	case int64:
+		return uint32(value), true
+	case uint64:
+		return uint32(value), true
+	}
+	return 0, false
+}
+
 func parseToStringSlice(elements []interface{}) ([]string, error) {
 	var out = make([]string, len(elements))
 
@@ -198,6 +292,11 @@ func parseToStringSlice(elements []interface{}) ([]string, error) {
 }
 
 func parseBody(c *gin.Context, elements *map[string]interface{}) error {
+	return parseBodyWithOptional(c, elements, &map[string]interface{}{})
+}
+
+// Like parseBody, but the optional elements may be missing: missing elements keep their values
+func parseBodyWithOptional(c *gin.Context, elements, optional *map[string]interface{}) error {
 	var err error
 	var body map[string]interface{}
 
@@ -213,5 +312,18 @@ func parseBody(c *gin.Context, elements *map[string]interface{}) error {
 		return err
 	}
 
+	present := map[string]interface{}{}
+	for elemName, elem := range *optional {
+		if body[elemName] != nil {
+			present[elemName] = elem
+		}
+	}
+	if err = parseElements(&present, &body); err != nil {
+		return err
+	}
+	for elemName, elem := range present {
+		(*optional)[elemName] = elem
+	}
+
 	return nil
 }
@@ -1,23 +1,35 @@
 package handlers
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/model"
 	"bytes"
+	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 )
 
+// the configuration which is read by the model for the tests
+const utConfigFile = "../config/configTEST.json"
+
 var r *gin.Engine
 
 func callURL(method, url string, expStatus int, body io.Reader, t *testing.T) *bytes.Buffer {
-	// request for all flights
+	return callURLAsCustomer(method, url, "", expStatus, body, t)
+}
+
+// Calls the url with the session of a logged in customer
+func callURLAsCustomer(method, url, session string, expStatus int, body io.Reader, t *testing.T) *bytes.Buffer {
 	req, _ := http.NewRequest(method, url, body)
 	req.Header.Add("Accept", "application/json")
+	setSession(req, session)
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -36,6 +48,25 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// Sets the bearer header of the session, if any
+func setSession(req *http.Request, session string) {
+	if session != "" {
+		req.Header.Set(model.HeaderAuthorization, model.BearerPrefix+session)
+	}
+}
+
+// Registers and logs in a customer and returns the session
+func loginCustomer(name string, t *testing.T) string {
+	if _, err := Server.RegisterCustomer(name, "password"+name); err != nil {
+		t.Fatal(err)
+	}
+	session, _, err := Server.Login(name, "password"+name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
 func setup(t *testing.T) {
 	Server = model.NewServer()
 	if Server == nil {
@@ -43,3 +74,54 @@ func setup(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// Reads the test configuration with the values replaced, the test configuration is read again by the cleanup
+func readConfig(t *testing.T, values map[string]interface{}) {
+	raw, err := ioutil.ReadFile(utConfigFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configuration := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &configuration); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range values {
+		configuration[key] = value
+	}
+	raw, _ = json.Marshal(configuration)
+	fileName := filepath.Join(t.TempDir(), "config.json")
+	if err = ioutil.WriteFile(fileName, raw, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = config.ReadConfigFile(fileName); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = config.ReadConfigFile(utConfigFile) })
+}
+
+func TestInitServer(t *testing.T) {
+	readConfig(t, map[string]interface{}{
+		"actionTypes": []map[string]string{{"name": "InitAction", "bonusData": "init:"}}})
+	if err := InitServer(); err != nil {
+		t.Fatal(err)
+	}
+	defer setup(t)
+	var msgSystemInfo model.MsgResponseSystemInfo
+
+	// the action types of a configuration read after init are offered at once
+	response := callURL("GET", model.RoutePath(model.PathGetSystemInformation).String(), http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msgSystemInfo); err != nil {
+		t.Fatal(err)
+	}
+	for _, bLevel := range msgSystemInfo.Data.BLevels {
+		if bLevel.ActionVariantByName("InitAction") == nil {
+			t.Errorf("bonus level %s misses the configured action", bLevel.BonusID)
+		}
+	}
+
+	// invalid configurations are reported
+	readConfig(t, map[string]interface{}{"flightFile": "unknown.json"})
+	if err := InitServer(); err == nil {
+		t.Error("unknown flight file not reported")
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/model"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Sends a request with the given encoding and decodes the response
+func callURLWithEncoding(method, url, session string, enc model.Encoding, values, msg interface{}) (*httptest.ResponseRecorder, error) {
+	var body []byte
+	var err error
+	if values != nil {
+		if body, err = enc.Marshal(values); err != nil {
+			return nil, err
+		}
+	}
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", enc.ContentType())
+	req.Header.Set("Accept", enc.ContentType())
+
+	setSession(req, session)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, enc.Unmarshal(w.Body.Bytes(), msg)
+}
+
+func TestBinaryEncodings(t *testing.T) {
+	for _, enc := range []model.Encoding{model.EncodingMsgpack, model.EncodingCBOR} {
+		setup(t)
+		var msgBooking model.MsgResponseSendBooking
+		var msgBlindSign model.MsgResponseBlindSignature
+		session := loginCustomer("customer", t)
+
+		values := model.MsgRequestSendBooking{FlightID: 2, FareClass: model.FarePremium}
+		w, err := callURLWithEncoding("POST", model.RoutePath(model.PathSendBooking).String(), session, enc, values, &msgBooking)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if w.Code != http.StatusAccepted || w.Header().Get("Content-Type") != enc.ContentType() {
+			t.Errorf("%s: wrong status %d or content type %s", enc, w.Code, w.Header().Get("Content-Type"))
+			t.Fail()
+		}
+		if msgBooking.Data.Token == "" {
+			t.Error("no token received: " + msgBooking.Err)
+			t.FailNow()
+		}
+
+		// the blind token is sent raw
+		blindBundle, _ := crypt.CreateBlindBundle(Server.BonusList["middle"].ActionVariants[model.ActionBooking].PublicKey)
+		signValues := model.MsgRequestBlindSignature{BLevelID: "middle", Token: msgBooking.Data.Token,
+			BlindToken: blindBundle.BlindToken, Action: model.ActionBooking}
+		if _, err = callURLWithEncoding("POST", model.RoutePath(model.PathBlindSignature).String(), "", enc, signValues, &msgBlindSign); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if msgBlindSign.Err != "" {
+			t.Error(msgBlindSign.Err)
+			t.Fail()
+		}
+		// and the signature is received raw
+		if len(msgBlindSign.Data.BlindSignature) != crypt.KeyLength/8 {
+			t.Errorf("%s: signature not received raw (%d bytes)", enc, len(msgBlindSign.Data.BlindSignature))
+			t.Fail()
+		}
+	}
+}
+
+// Measures size and latency of the system information request per encoding
+func benchmarkSystemInformation(b *testing.B, enc model.Encoding) {
+	Server = model.NewServer()
+	var size int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var msg model.MsgResponseSystemInfo
+		w, err := callURLWithEncoding("GET", model.RoutePath(model.PathGetSystemInformation).String(), "", enc, nil, &msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		size = w.Body.Len()
+	}
+	b.SetBytes(int64(size))
+	b.Logf("%s: %d bytes", enc, size)
+}
+
+func BenchmarkSystemInformation_JSON(b *testing.B) {
+	benchmarkSystemInformation(b, model.EncodingJSON)
+}
+
+func BenchmarkSystemInformation_Msgpack(b *testing.B) {
+	benchmarkSystemInformation(b, model.EncodingMsgpack)
+}
+
+func BenchmarkSystemInformation_CBOR(b *testing.B) {
+	benchmarkSystemInformation(b, model.EncodingCBOR)
+}
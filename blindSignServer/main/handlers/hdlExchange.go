@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"encoding/base64"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+)
+
+// Spends codes of a bonus level for the blind signature of a code of a higher level, the body
+// contains the codes, both bonus levels and the blind Token
+func HdlExchangeCodes(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req model.MsgRequestExchangeCodes
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = decodeBody(c, &req); err != nil {
+		return
+	}
+
+	var blindSignature string
+	if blindSignature, err = Server.ExchangeCodes(req.Codes, req.From, req.To, req.BlindToken); err != nil {
+		return
+	}
+
+	data["blindSignature"] = blindSignature
+	status = http.StatusAccepted
+}
+
+func ProtoExchangeCodes(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var blindSignature string
+	var req pb.ExchangeCodesRequest
+	var resp pb.ExchangeCodesResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if blindSignature, err = Server.ExchangeCodes(req.Codes, req.From, req.To, req.BlindToken); err != nil {
+		return
+	}
+	// the signature is sent raw
+	if resp.BlindSignature, err = base64.URLEncoding.DecodeString(blindSignature); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
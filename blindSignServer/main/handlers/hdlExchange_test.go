@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHdlExchangeCodes(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseExchangeCodes
+
+	values := model.MsgRequestExchangeCodes{Codes: []string{"unknown"}, From: "low", To: "middle", BlindToken: model.HexBytes{1, 2}}
+	jsonValue, _ := json.Marshal(values)
+	response := callURL("POST", model.RoutePath(model.PathExchangeCodes).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Err != "code is not valid" {
+		t.Errorf("wrong response: %s", response.String())
+	}
+
+	values.From = "high"
+	jsonValue, _ = json.Marshal(values)
+	response = callURL("POST", model.RoutePath(model.PathExchangeCodes).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Err != "no exchange from 'high' to 'middle'" {
+		t.Errorf("wrong response: %s", response.String())
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Searches the flight catalogue. The query parameters 'origin', 'destination' and
+// 'date' (YYYY-MM-DD) filter the flights, 'page' and 'pageSize' select a page.
+func GetFlightSearch(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var data *model.FlightPage
+	var query model.FlightQuery
+
+	Server.CntReqSearchFlights.Inc()
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if query, err = parseFlightQuery(c); err != nil {
+		return
+	}
+	data = Server.SearchFlights(query)
+	status = http.StatusOK
+}
+
+func parseFlightQuery(c *gin.Context) (query model.FlightQuery, err error) {
+	query.Origin = c.Query("origin")
+	query.Destination = c.Query("destination")
+	if date := c.Query("date"); date != "" {
+		if query.Date, err = time.Parse(model.FlightDateFormat, date); err != nil {
+			return query, errors.New("invalid date: " + date)
+		}
+	}
+	for name, value := range map[string]*int{"page": &query.Page, "pageSize": &query.PageSize} {
+		if param := c.Query(name); param != "" {
+			if *value, err = strconv.Atoi(param); err != nil || *value < 1 {
+				return query, errors.New("invalid " + name + ": " + param)
+			}
+		}
+	}
+	return query, nil
+}
+
+// Lists the whole flight catalogue with the booked seats
+func GetAdminFlights(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	data := Server.Flights()
+	render(c, gin.H{"payload": &data}, &status, &err)
+}
+
+// Adds a flight or replaces the schedule of a known flight. The body is a flight.
+func PostAdminFlight(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var flight model.Flight
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = decodeBody(c, &flight); err != nil {
+		return
+	}
+	if err = Server.AddFlight(&flight); err != nil {
+		return
+	}
+	data["flightID"] = flight.ID
+	status = http.StatusOK
+}
+
+// Removes the flight of the query parameter 'id' if it has no bookings
+func DeleteAdminFlight(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var flightID int
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if flightID, err = strconv.Atoi(c.Query("id")); err != nil {
+		err = errors.New("invalid flight id: " + c.Query("id"))
+		return
+	}
+	if err = Server.RemoveFlight(flightID); err != nil {
+		return
+	}
+	data["flightID"] = flightID
+	status = http.StatusOK
+}
+
+// Sets the tier of a customer, the body contains the elements 'customerID' and 'tier'
+func PostAdminCustomerTier(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID int
+	var tier string
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	elements := map[string]interface{}{"customerID": customerID, "tier": tier}
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = parseBody(c, &elements); err != nil {
+		return
+	}
+	customerID = elements["customerID"].(int)
+	tier = elements["tier"].(string)
+
+	Server.SetCustomerTier(customerID, tier)
+	data["tier"] = Server.CustomerTier(customerID)
+	status = http.StatusOK
+}
+
+// Decodes the body into a value with the encoding given by the Content-Type header
+func decodeBody(c *gin.Context, value interface{}) error {
+	if c.Request.Body == nil {
+		return errors.New("missing body")
+	}
+	bodyBytes, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	enc, _ := model.EncodingFromContentType(c.ContentType())
+	return enc.Unmarshal(bodyBytes, value)
+}
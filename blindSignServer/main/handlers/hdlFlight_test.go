@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const utAdminKey = "admin-key"
+
+// Configures the admin key for the test
+func setupAdminKey(t *testing.T) {
+	config.SetConfigAdminKey(utAdminKey)
+	t.Cleanup(func() { config.SetConfigAdminKey("") })
+}
+
+// Calls an admin route with the admin key header, if any
+func callURLAsAdmin(method, url, key string, expStatus int, body *bytes.Buffer, t *testing.T) *bytes.Buffer {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+	req, _ := http.NewRequest(method, url, body)
+	req.Header.Set("Accept", "application/json")
+	if key != "" {
+		req.Header.Set(model.HeaderAdminKey, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != expStatus {
+		t.Errorf("bad status %d: %s", w.Code, w.Body.String())
+	}
+	return w.Body
+}
+
+func TestGetFlightSearch(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseFlightSearch
+
+	response := callURL("GET", model.RoutePath(model.PathFlightSearch).String()+"?page=2&pageSize=30", http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Err != "" {
+		t.Fatalf("wrong search response: %s", response.String())
+	}
+	if msg.Data.Total != 100 || msg.Data.Page != 2 || len(msg.Data.Flights) != 30 {
+		t.Errorf("wrong page: total %d, page %d, %d flights", msg.Data.Total, msg.Data.Page, len(msg.Data.Flights))
+	}
+
+	// filter by the route and date of a found flight
+	flight := msg.Data.Flights[0]
+	response = callURL("GET", model.APIVersion(model.APIVersion1).Prefix()+model.RoutePath(model.PathFlightSearch).String()+"?origin="+flight.Origin+
+		"&destination="+flight.Destination+"&date="+flight.Departure.Format(model.FlightDateFormat), http.StatusOK, nil, t)
+	msg = model.MsgResponseFlightSearch{}
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Data.Total == 0 {
+		t.Fatalf("flight not found: %s", response.String())
+	}
+	for _, found := range msg.Data.Flights {
+		if found.Origin != flight.Origin || found.Destination != flight.Destination {
+			t.Error("flight of another route found")
+		}
+	}
+
+	for _, query := range []string{"?date=tomorrow", "?page=0", "?pageSize=x"} {
+		callURL("GET", model.RoutePath(model.PathFlightSearch).String()+query, http.StatusBadRequest, nil, t)
+	}
+}
+
+func TestAdminFlights(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	var msg struct {
+		Data []*model.Flight `json:"data"`
+		Err  string          `json:"err"`
+	}
+
+	body := `{"ID": 500, "Number": "BS500", "Origin": "FRA", "Destination": "LHR",
+		"Departure": "2030-01-02T10:00:00Z", "FareClasses": [{"Name": "economy", "Seats": 1}]}`
+	callURLAsAdmin("POST", model.RouteAdminFlights, utAdminKey, http.StatusOK, bytes.NewBufferString(body), t)
+	callURLAsAdmin("POST", model.RouteAdminFlights, utAdminKey, http.StatusBadRequest, bytes.NewBufferString(`{"ID": 501}`), t)
+
+	response := callURLAsAdmin("GET", model.RouteAdminFlights, utAdminKey, http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || len(msg.Data) != 101 {
+		t.Fatalf("wrong flight list: %d flights", len(msg.Data))
+	}
+	if last := msg.Data[len(msg.Data)-1]; last.ID != 500 || last.FareClasses[0].Seats != 1 {
+		t.Error("added flight not listed")
+	}
+
+	// the added flight takes one booking
+	if _, err := Server.Booking(500, 1, ""); err != nil {
+		t.Error(err)
+	}
+	if _, err := Server.Booking(500, 2, ""); err == nil {
+		t.Error("fully booked flight accepted a booking")
+	}
+
+	callURLAsAdmin("DELETE", model.RouteAdminFlights+"?id=500", utAdminKey, http.StatusBadRequest, nil, t)
+	callURLAsAdmin("DELETE", model.RouteAdminFlights+"?id=x", utAdminKey, http.StatusBadRequest, nil, t)
+	callURLAsAdmin("DELETE", model.RouteAdminFlights+"?id=0", utAdminKey, http.StatusOK, nil, t)
+	if page := Server.SearchFlights(model.FlightQuery{}); page.Total != 100 {
+		t.Error("flight not removed")
+	}
+}
+
+func TestAdminFlights_Unauthorized(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+
+	body := `{"ID": 500, "Number": "BS500", "Origin": "FRA", "Destination": "LHR",
+		"Departure": "2030-01-02T10:00:00Z", "FareClasses": [{"Name": "economy", "Seats": 1}]}`
+	callURL("GET", model.RouteAdminFlights, http.StatusUnauthorized, nil, t)
+	callURL("POST", model.RouteAdminFlights, http.StatusUnauthorized, bytes.NewBufferString(body), t)
+	callURL("DELETE", model.RouteAdminFlights+"?id=7", http.StatusUnauthorized, nil, t)
+	callURLAsAdmin("DELETE", model.RouteAdminFlights+"?id=7", "wrong-key", http.StatusUnauthorized, nil, t)
+	if page := Server.SearchFlights(model.FlightQuery{}); page.Total != 100 {
+		t.Error("flight catalogue changed without admin key")
+	}
+}
+
+func TestPostAdminCustomerTier(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	body := `{"customerID": 7, "tier": "gold"}`
+	callURLAsAdmin("POST", model.RouteAdminCustomerTier, utAdminKey, http.StatusOK, bytes.NewBufferString(body), t)
+	callURLAsAdmin("POST", model.RouteAdminCustomerTier, utAdminKey, http.StatusBadRequest, bytes.NewBufferString(`{"tier": "gold"}`), t)
+	if Server.CustomerTier(7) != "gold" || Server.CustomerTier(8) != model.DefaultCustomerTier {
+		t.Error("customer tier not set")
+	}
+}
+
+func TestPostAdminCustomerTier_Unauthorized(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	body := `{"customerID": 7, "tier": "gold"}`
+	callURL("POST", model.RouteAdminCustomerTier, http.StatusUnauthorized, bytes.NewBufferString(body), t)
+	callURLAsAdmin("POST", model.RouteAdminCustomerTier, "wrong-key", http.StatusUnauthorized, bytes.NewBufferString(body), t)
+
+	// the admin routes are closed without a configured key
+	config.SetConfigAdminKey("")
+	callURLAsAdmin("POST", model.RouteAdminCustomerTier, "", http.StatusUnauthorized, bytes.NewBufferString(body), t)
+	if Server.CustomerTier(7) != model.DefaultCustomerTier {
+		t.Error("customer tier set without admin key")
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"blindSignAccount/main/metrics"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// label of requests which do not match a route, keeps the number of label values small
+const unmatchedRoute = "unmatched"
+
+// Counts the requests and records their latencies per route
+func metricsMiddleware(r *gin.Engine) gin.HandlerFunc {
+	var once sync.Once
+	var routes map[string]bool
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// the routes are known after all of them were registered
+		once.Do(func() {
+			routes = map[string]bool{}
+			for _, route := range r.Routes() {
+				routes[route.Method+" "+route.Path] = true
+			}
+		})
+		route := c.Request.URL.Path
+		if !routes[c.Request.Method+" "+route] {
+			route = unmatchedRoute
+		}
+		Server.HTTPRequests.With(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).Inc()
+		Server.HTTPDuration.Observe(time.Since(start).Seconds(), route, c.Request.Method)
+	}
+}
+
+// Serves all metrics in the prometheus text format
+func GetMetrics(c *gin.Context) {
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", metrics.ContentType)
+	if err := Server.Metrics.WriteText(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetMetrics(t *testing.T) {
+	setup(t)
+
+	session := loginCustomer("customer", t)
+
+	callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), session, http.StatusAccepted,
+		strings.NewReader(`{"flightID": 2}`), t)
+	callURL("GET", "/unknown/route", http.StatusNotFound, nil, t)
+	body := callURL("GET", model.RouteMetrics, http.StatusOK, nil, t).String()
+
+	for _, expected := range []string{
+		`bss_requests_total{operation="SendBooking"} 1`,
+		`bss_http_requests_total{route="/booking/send",method="POST",status="202"} 1`,
+		`bss_http_requests_total{route="unmatched",method="GET",status="404"} 1`,
+		`bss_http_request_duration_seconds_count{route="/booking/send",method="POST"} 1`,
+		`bss_valid_tokens{bonus_level="low",variant="ActionBooking"} 1`,
+		`bss_map_size{bonus_level="low",variant="ActionBooking",map="ValidTokens"} 1`,
+		`bss_outstanding_codes 0`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Error("missing metric: " + expected)
+			t.Fail()
+		}
+	}
+
+	// the statistic summary is derived from the same counters
+	if summary := Server.GetStatisticSummary(); summary.CntReqSendBooking != 1 {
+		t.Errorf("wrong booking counter of the summary: %d", summary.CntReqSendBooking)
+		t.Fail()
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/model"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -15,7 +16,7 @@ func HdlAccessBonusSystem(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqAccessBonusSystem++
+	Server.CntReqAccessBonusSystem.Inc()
 
 	elements := map[string]interface{}{"codes": codes, "adrBundle": adrBundle}
 	err = errors.New("unknown error")
@@ -40,18 +41,20 @@ func HdlAccessBonusSystem(c *gin.Context) {
 
 func HdlParticipate(c *gin.Context) {
 	var status = http.StatusBadRequest
-	var bLevelID, pkr, token, recoveryToken, bonusData string
+	var bLevelID, pkr, token, recoveryToken, bonusData, rewardID string
 	var hashValue, signature []byte
+	var action = model.ActionParticipate
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqParticipate++
+	Server.CntReqParticipate.Inc()
 
 	elements := map[string]interface{}{"bLevelID": bLevelID, "hashValue": hashValue, "signature": signature, "pkr": pkr}
+	optional := map[string]interface{}{"action": action, "rewardID": rewardID}
 	err = errors.New("unknown error")
 	defer render(c, gin.H{"payload": &data}, &status, &err)
 
-	if err = parseBody(c, &elements); err != nil {
+	if err = parseBodyWithOptional(c, &elements, &optional); err != nil {
 		return
 	}
 
@@ -59,8 +62,10 @@ func HdlParticipate(c *gin.Context) {
 	hashValue = elements["hashValue"].([]byte)
 	signature = elements["signature"].([]byte)
 	pkr = elements["pkr"].(string)
+	action = optional["action"].(int)
+	rewardID = optional["rewardID"].(string)
 
-	if token, recoveryToken, bonusData, err = Server.Participate(bLevelID, hashValue, signature, pkr); err != nil {
+	if token, recoveryToken, bonusData, err = Server.PerformAction(bLevelID, action, rewardID, hashValue, signature, pkr); err != nil {
 		return
 	}
 
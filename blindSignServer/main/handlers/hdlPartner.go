@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+var errNoPartner = errors.New("partner key is missing")
+
+// Returns the name of the partner who is authenticated by the partner key header.
+// The status is set to unauthorized if the key is missing or not valid.
+func authenticatePartner(c *gin.Context, status *int) (string, error) {
+	key := c.GetHeader(model.HeaderPartnerKey)
+	if key == "" {
+		*status = http.StatusUnauthorized
+		return "", errNoPartner
+	}
+	partner, err := Server.AuthenticatePartner(key)
+	if err != nil {
+		*status = http.StatusUnauthorized
+	}
+	return partner, err
+}
+
+// Publishes the hex encoded ed25519 voucher keys by bonus level ids
+func GetPartnerKeys(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	var data = make(map[string]interface{}, 0)
+
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	for bLevelID, key := range Server.VoucherKeys() {
+		data[bLevelID] = hex.EncodeToString(key)
+	}
+}
+
+// Redeems a voucher for the authenticated partner, the body contains the element 'voucher'
+// with the bonus data of the participation
+func PostPartnerRedeem(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var partner, bonusData string
+	var voucher *model.Voucher
+	var redemption *model.Redemption
+
+	err = errors.New("unknown error")
+	elements := map[string]interface{}{"voucher": bonusData}
+	defer render(c, gin.H{"payload": &redemption}, &status, &err)
+
+	if partner, err = authenticatePartner(c, &status); err != nil {
+		return
+	}
+	if err = parseBody(c, &elements); err != nil {
+		return
+	}
+	if voucher, err = model.ParseVoucher(elements["voucher"].(string)); err != nil {
+		return
+	}
+	if redemption, err = Server.RedeemVoucher(partner, voucher); err != nil {
+		return
+	}
+	status = http.StatusOK
+}
+
+// Returns the signed revocation filters of the epochs after the query parameter 'since'
+// and the key of their signatures
+func GetPartnerRevocations(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var sinceEpoch int
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if since := c.Query("since"); since != "" {
+		if sinceEpoch, err = strconv.Atoi(since); err != nil || sinceEpoch < 0 {
+			err = errors.New("invalid since: " + since)
+			return
+		}
+	}
+	data["publicKey"] = hex.EncodeToString(Server.RevocationPublicKey())
+	data["filters"] = Server.GetRevocations(sinceEpoch)
+	err = nil
+	status = http.StatusOK
+}
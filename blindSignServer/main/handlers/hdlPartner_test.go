@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/crypt"
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Calls a partner route with the partner key header, if any
+func callURLAsPartner(method, url, key string, expStatus int, body *bytes.Buffer, t *testing.T) *bytes.Buffer {
+	if body == nil {
+		body = &bytes.Buffer{}
+	}
+	req, _ := http.NewRequest(method, url, body)
+	if key != "" {
+		req.Header.Set(model.HeaderPartnerKey, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != expStatus {
+		t.Errorf("bad status %d: %s", w.Code, w.Body.String())
+	}
+	return w.Body
+}
+
+func TestGetPartnerKeys(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponsePartnerKeys
+
+	response := callURL("GET", model.RoutePath(model.PathPartnerKeys).String(), http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || len(msg.Data) != len(Server.BonusList) {
+		t.Fatalf("wrong keys: %s", response.String())
+	}
+	for bLevelID, bLevel := range Server.BonusList {
+		if msg.Data[bLevelID] != hex.EncodeToString(bLevel.VoucherPublicKey) {
+			t.Errorf("wrong key of bonus level %s", bLevelID)
+		}
+	}
+}
+
+func TestPostPartnerRedeem(t *testing.T) {
+	config.SetConfigPartners([]config.Partner{{Name: "lounge", Key: "lounge-key"}})
+	defer config.SetConfigPartners(nil)
+	setup(t)
+
+	body := func(voucher string) *bytes.Buffer {
+		jsonValue, _ := json.Marshal(map[string]string{"voucher": voucher})
+		return bytes.NewBuffer(jsonValue)
+	}
+
+	// partners authenticate by their keys only
+	callURLAsPartner("POST", model.RoutePath(model.PathPartnerRedeem).String(), "", http.StatusUnauthorized, body("{}"), t)
+	callURLAsPartner("POST", model.RoutePath(model.PathPartnerRedeem).String(), "wrong-key", http.StatusUnauthorized, body("{}"), t)
+	session := loginCustomer("customer", t)
+	callURLAsCustomer("POST", model.RoutePath(model.PathPartnerRedeem).String(), session, http.StatusBadRequest, body("{}"), t)
+
+	callURLAsPartner("POST", model.RoutePath(model.PathPartnerRedeem).String(), "lounge-key", http.StatusBadRequest, body("no voucher"), t)
+	callURLAsPartner("POST", model.RoutePath(model.PathPartnerRedeem).String(), "lounge-key", http.StatusBadRequest,
+		body(`{"ID":"1","BLevelID":"high","RewardID":"lounge"}`), t)
+}
+
+func TestGetPartnerRevocations(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseRevocations
+
+	for i := 0; i < 2; i++ {
+		if _, err := Server.SealRevocations(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	response := callURL("GET", model.RoutePath(model.PathPartnerRevocations).String()+"?since=1", http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || len(msg.Data.Filters) != 1 {
+		t.Fatalf("wrong revocations: %s", response.String())
+	}
+	list := model.NewRevocationList(Server.RevocationPublicKey())
+	if err := list.Add(msg.Data.Filters...); err == nil {
+		t.Error("filter added without its previous epoch")
+	}
+	response = callURL("GET", model.RoutePath(model.PathPartnerRevocations).String(), http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || list.Add(msg.Data.Filters...) != nil || list.Epoch() != 2 {
+		t.Errorf("revocations not verified: %s", response.String())
+	}
+	if !bytes.Equal(msg.Data.PublicKey, Server.RevocationPublicKey()) {
+		t.Error("wrong revocation key")
+	}
+	callURL("GET", model.RoutePath(model.PathPartnerRevocations).String()+"?since=-1", http.StatusBadRequest, nil, t)
+}
+
+func TestPostPartnerRedeem_ConfiguredPartner(t *testing.T) {
+	// the partner of the test configuration, which is read before the server is built as by main
+	readConfig(t, nil)
+	if err := InitServer(); err != nil {
+		t.Fatal(err)
+	}
+	defer setup(t)
+
+	redeem := func() {
+		bLevel := Server.BonusList[Server.Hierarchy[0].BonusID]
+		_, _, hashValue, sig, err := crypt.GetBlindSignatureTestData(crypt.GenerateToken(), bLevel.ActionVariants[model.ActionParticipate].SkKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, bonusData, err := Server.Participate(bLevel.BonusID, hashValue, sig, "pkr")
+		if err != nil {
+			t.Fatal(err)
+		}
+		jsonValue, _ := json.Marshal(map[string]string{"voucher": bonusData})
+		callURLAsPartner("POST", model.RoutePath(model.PathPartnerRedeem).String(), "test-lounge-key", http.StatusOK, bytes.NewBuffer(jsonValue), t)
+	}
+	redeem()
+	// the partners are kept by a reset
+	callURL("GET", model.RoutePath(model.PathReset).String(), http.StatusOK, nil, t)
+	redeem()
+}
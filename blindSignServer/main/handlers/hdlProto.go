@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golang/protobuf/proto"
+	"net/http"
+	"strings"
+)
+
+// Dispatches requests which send or accept protocol buffers to the protobuf handler
+func negotiateProto(handler, protoHandler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.ContentType() == model.MIMEProtobuf || strings.Contains(c.GetHeader("Accept"), model.MIMEProtobuf) {
+			protoHandler(c)
+			return
+		}
+		handler(c)
+	}
+}
+
+func renderProto(c *gin.Context, msg proto.Message, msgErr *string, statusCode *int, err *error) {
+	errorStatus(c, statusCode, *err)
+	if *err != nil {
+		*msgErr = (*err).Error()
+	}
+	c.ProtoBuf(*statusCode, msg)
+}
+
+func ProtoGetReset(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var resp pb.ResetResponse
+
+	Server.CntReqReset.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	Server.Reset()
+	status = http.StatusOK
+	err = nil
+}
+
+func ProtoGetSystemInformation(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var resp pb.SystemInfoResponse
+	var flights []*model.Flight
+	var bLevels []*model.BonusLevel
+
+	Server.CntReqGetSystemInformation.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if flights, bLevels, err = Server.GetSystemInformation(); err != nil {
+		return
+	}
+	for _, flight := range flights {
+		resp.Flights = append(resp.Flights, model.FlightToProto(flight))
+	}
+	for _, bLevel := range bLevels {
+		resp.BLevels = append(resp.BLevels, model.BonusLevelToProto(bLevel))
+	}
+
+	status = http.StatusOK
+}
+
+func ProtoGetDebugInformation(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	var resp pb.DebugInfoResponse
+	defer Server.Mux.Unlock()
+	Server.Mux.Lock()
+	if resp.ServerJson, err = json.Marshal(Server); err != nil {
+		status = http.StatusInternalServerError
+	}
+	renderProto(c, &resp, &resp.Err, &status, &err)
+}
+
+func ProtoGetSystemRegister(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var clientID int
+	var resp pb.RegisterResponse
+
+	Server.CntReqRegister.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if clientID, err = Server.Register(); err != nil {
+		return
+	}
+
+	resp.ClientId = int64(clientID)
+	status = http.StatusOK
+}
+
+func ProtoSendBooking(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var customerID int
+	var req pb.SendBookingRequest
+	var resp pb.SendBookingResponse
+
+	Server.CntReqSendBooking.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if customerID, err = authenticateCustomer(c, &status); err != nil {
+		return
+	}
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	tokens, bookingID, err := Server.BookFlight(int(req.FlightId), customerID, req.FareClass)
+	if err != nil {
+		return
+	}
+	resp.BookingId = int64(bookingID)
+	for _, token := range tokens {
+		resp.Tokens = append(resp.Tokens, &pb.EarnedToken{Token: token.Token, BLevelId: token.BLevelID, Points: int32(token.Points)})
+	}
+	if len(tokens) > 0 {
+		resp.Token = tokens[0].Token
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoGetBookingCode(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.BookingCodeRequest
+	var resp pb.BookingCodeResponse
+
+	Server.CntReqGetBookingCode.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if resp.Code, err = Server.GetBookingCode(req.BLevelId, int(req.Points), req.HashValue, req.Signature); err != nil {
+		return
+	}
+	status = http.StatusAccepted
+}
+
+func ProtoBlindSignature(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var blindSignature string
+	var req pb.BlindSignatureRequest
+	var resp pb.BlindSignatureResponse
+
+	Server.CntReqBlindSignature.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	action := int(req.Action)
+	if !model.IsKnownAction(action) {
+		err = errors.New("unknown action")
+		return
+	}
+
+	if blindSignature, err = Server.GetBlindSignature(req.BLevelId, req.Token, req.BlindToken, action); err != nil {
+		return
+	}
+	// the signature is sent raw
+	if resp.BlindSignature, err = base64.URLEncoding.DecodeString(blindSignature); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoSetAddress(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.SetAddressRequest
+	var resp pb.SetAddressResponse
+
+	Server.CntReqSetAddress.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	action := int(req.Action)
+	if !model.IsKnownAction(action) {
+		err = errors.New("unknown action")
+		return
+	}
+	if resp.Token, resp.RecoveryToken, err = Server.SetAddress(req.BLevelId, req.HashValue, req.Signature,
+		model.AdrBundleFromProto(req.AdrBundle), action, req.Pkr); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoAccessBonusSystem(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.AccessBonusSystemRequest
+	var resp pb.AccessBonusSystemResponse
+
+	Server.CntReqAccessBonusSystem.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if resp.Tokens, resp.RecoveryTokens, err = Server.AccessBonusSystem(req.Codes, model.AdrBundleFromProto(req.AdrBundle)); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoParticipate(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.ParticipateRequest
+	var resp pb.ParticipateResponse
+
+	Server.CntReqParticipate.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	// the zero value of the action selects the participation
+	action := int(req.Action)
+	if action == 0 {
+		action = model.ActionParticipate
+	}
+	if resp.Token, resp.RecoveryToken, resp.BonusData, err = Server.PerformAction(req.BLevelId, action, req.RewardId, req.HashValue, req.Signature, req.Pkr); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoCanBeUsedForRecovery(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var recoveryStatus model.RecoveryStatus
+	var req pb.RecoveryStatusRequest
+	var resp pb.RecoveryStatusResponse
+
+	Server.CntReqCanBesUsedForRecovery.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	recoveryStatus, resp.Token, err = Server.CanBeUsedForRecovery(req.BLevelId, model.AdrBundleFromProto(req.AdrBundle))
+	resp.RecoveryStatus = int32(recoveryStatus)
+
+	status = http.StatusAccepted
+}
+
+func ProtoRecoveryTest(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.RecoveryTestRequest
+	var resp pb.RecoveryTestResponse
+
+	Server.CntReqRecoveryTest.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if resp.Token, resp.FoundRecoveryToken, resp.BonusData, err = Server.RecoveryTest(req.BLevelId, req.RecoveryToken,
+		req.Pkr, model.AdrBundleFromProto(req.AdrBundle)); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
+
+func ProtoGetLastAdrBundle(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req pb.LastAdrBdlRequest
+	var resp pb.LastAdrBdlResponse
+
+	Server.CntReqGetLastAdrBundle.Inc()
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	resp.Address, resp.AccountId, err = Server.GetLastAdrBundle(req.Seed, req.BLevelId)
+
+	if err == nil {
+		status = http.StatusAccepted
+	} else {
+		status = http.StatusNotFound
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"net/http/httptest"
+	"testing"
+)
+
+const utMnemonic = "coil early bronze maze battle any core sweet burger busy cotton impact evoke oven jeans glance clock final eight crowd tool okay mushroom shrimp"
+
+// Serves the routes by a local server which the clients of the test connect to
+func startTestServer(t *testing.T) {
+	setup(t)
+	ts := httptest.NewServer(r)
+	address := model.ServerAddress
+	model.ServerAddress = ts.URL
+	t.Cleanup(func() {
+		model.ServerAddress = address
+		ts.Close()
+	})
+}
+
+// Creates a client which is connected by protobuf
+func newProtoClient(t *testing.T, accountID uint32) *model.Client {
+	client := model.NewClientWithAccountID(1, utMnemonic, 2, accountID)
+	client.SetConnection(model.NewProtoConnection())
+	if err := client.GetSystemInformation(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// Like newProtoClient, the client is logged in as a new customer
+func setupProtoClient(t *testing.T, name string, accountID uint32) *model.Client {
+	client := newProtoClient(t, accountID)
+	if _, err := client.RegisterCustomer(name, "password"+name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Login(name, "password"+name); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestProtoConnection_Register(t *testing.T) {
+	startTestServer(t)
+	con := model.NewProtoConnection()
+
+	clientID, err := con.Register()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clientID2nd, err := con.Register(); err != nil || clientID2nd != clientID+1 {
+		t.Errorf("wrong client ids: %d, %d (%v)", clientID, clientID2nd, err)
+	}
+}
+
+func TestProtoConnection_ClientBooking(t *testing.T) {
+	startTestServer(t)
+	client := setupProtoClient(t, "customer", 1)
+
+	if err := client.Booking(1, model.FareEconomy); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.BonusCodes) != 1 || client.BonusCodes[0].ValidFor != client.BonusLevels["low"] {
+		t.Errorf("wrong bonus codes: %d", len(client.BonusCodes))
+	}
+	// unknown fare classes are reported by the err field
+	if err := client.Booking(1, "unknown"); err == nil {
+		t.Error("booking of unknown fare class accepted")
+	}
+}
+
+func TestProtoConnection_ClientAccess(t *testing.T) {
+	startTestServer(t)
+	client := setupProtoClient(t, "customer", 1)
+
+	for i := 0; i < 3; i++ {
+		if err := client.Booking(1, model.FarePremium); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.AccessBonusSystem(); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.BLevelToTokens) != 2 || len(client.BLevelToRecovery) != 2 {
+		t.Errorf("wrong number of accessed bonus levels: %d", len(client.BLevelToTokens))
+	}
+}
+
+func TestProtoConnection_ClientRecovery(t *testing.T) {
+	startTestServer(t)
+	client := setupProtoClient(t, "customer", 2)
+
+	for i := 0; i < 3; i++ {
+		if err := client.Booking(1, model.FarePremium); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.AccessBonusSystem(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Participate("low"); err != nil {
+		t.Fatal(err)
+	}
+	bData, err := client.Participate("low")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a client which knows the mnemonic only
+	restored := newProtoClient(t, 2)
+	recovered, err := restored.RestoreFromMnemonic(1, utMnemonic, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered["low"] != bData {
+		t.Errorf("recovered bonus data differs. Exp: %s\t\tact: %s", bData, recovered["low"])
+	}
+}
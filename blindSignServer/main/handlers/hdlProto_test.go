@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"bytes"
+	"github.com/golang/protobuf/proto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Sends a protobuf request and decodes the protobuf response
+func callURLWithProto(method, url, session string, values, msg proto.Message) (*httptest.ResponseRecorder, error) {
+	var body []byte
+	var err error
+	if values != nil {
+		if body, err = proto.Marshal(values); err != nil {
+			return nil, err
+		}
+	}
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", model.MIMEProtobuf)
+	req.Header.Set("Accept", model.MIMEProtobuf)
+
+	setSession(req, session)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, proto.Unmarshal(w.Body.Bytes(), msg)
+}
+
+func TestProtoSendBooking(t *testing.T) {
+	setup(t)
+	var msg pb.SendBookingResponse
+	session := loginCustomer("customer", t)
+
+	values := &pb.SendBookingRequest{FlightId: 2, FareClass: model.FarePremium}
+	w, err := callURLWithProto("POST", model.RoutePath(model.PathSendBooking).Versioned(model.APIVersion1), session, values, &msg)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if w.Code != http.StatusAccepted || w.Header().Get("Content-Type") != model.MIMEProtobuf {
+		t.Errorf("wrong status %d or content type %s", w.Code, w.Header().Get("Content-Type"))
+		t.Fail()
+	}
+	if msg.Token == "" || msg.Err != "" || len(msg.Tokens) != 1 || msg.Tokens[0].BLevelId != "middle" || msg.BookingId == 0 {
+		t.Error("no token received: " + msg.Err)
+		t.Fail()
+	}
+
+	// errors are sent in the err field
+	values.FareClass = "unknown"
+	if w, err = callURLWithProto("POST", model.RoutePath(model.PathSendBooking).Versioned(model.APIVersion1), session, values, &msg); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if w.Code != http.StatusBadRequest || msg.Err == "" {
+		t.Errorf("missing error for unknown fare class (status %d)", w.Code)
+		t.Fail()
+	}
+}
+
+func TestProtoGetSystemInformation(t *testing.T) {
+	setup(t)
+	var msg pb.SystemInfoResponse
+
+	w, err := callURLWithProto("GET", model.RoutePath(model.PathGetSystemInformation).String(), "", nil, &msg)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if w.Code != http.StatusOK || len(msg.Flights) == 0 || len(msg.BLevels) != len(Server.BonusList) {
+		t.Errorf("wrong system information (status %d, %d flights, %d bonus levels)", w.Code, len(msg.Flights), len(msg.BLevels))
+		t.Fail()
+	}
+	// the flights are sent with their schedules
+	for _, flight := range msg.Flights {
+		if flight.Origin == "" || flight.Departure == 0 || len(flight.FareClasses) == 0 || flight.FareClasses[0].Seats == 0 {
+			t.Errorf("flight %d without schedule", flight.Id)
+		}
+	}
+}
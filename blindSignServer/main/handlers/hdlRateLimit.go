@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/model"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Classes of rate limits: endpoints which issue tokens and endpoints with
+// expensive cryptographic operations
+const (
+	limitDefault = "default"
+	limitCrypto  = "crypto"
+)
+
+var errRateLimited = errors.New("too many requests")
+
+// The rate limiters of the limit classes, a class without limiter is not limited
+type rateLimiters map[string]*model.RateLimiter
+
+// Creates the rate limiters of the server configuration
+func newRateLimiters() rateLimiters {
+	limiters := rateLimiters{}
+	for class, limit := range map[string]config.RateLimit{limitDefault: config.GetConfigRateLimit(),
+		limitCrypto: config.GetConfigCryptoRateLimit()} {
+		if limit.Enabled() {
+			limiters[class] = model.NewRateLimiter(limit.Rate, limit.Burst)
+		}
+	}
+	return limiters
+}
+
+// Limits the requests of a route per IP. Routes which identify the client are limited per
+// registered client also, anonymous routes ignore the client id. Requests of the same route
+// under different api versions share their limit.
+func (limiters rateLimiters) limit(class string, path model.RoutePath) gin.HandlerFunc {
+	return limiters.limitRequests(class, path.String(), path.IdentifiesClient())
+}
+
+// Limits the requests of a route which is not a protocol path per IP
+func (limiters rateLimiters) limitRoute(class, route string) gin.HandlerFunc {
+	return limiters.limitRequests(class, route, false)
+}
+
+// The address and the registered client have buckets of their own, a request has to pass both
+func (limiters rateLimiters) limitRequests(class, route string, perClient bool) gin.HandlerFunc {
+	limiter := limiters[class]
+	proxies := parseTrustedProxies(config.GetConfigTrustedProxies())
+	return func(c *gin.Context) {
+		if limiter == nil {
+			return
+		}
+		allowed, retryAfter := limiter.Allow("ip|" + clientAddress(c, proxies) + "|" + route)
+		if allowed && perClient {
+			if clientID := registeredClientID(c); clientID != "" {
+				allowed, retryAfter = limiter.Allow("client|" + clientID + "|" + route)
+			}
+		}
+		if allowed {
+			return
+		}
+
+		Server.RateLimited.With(route, class).Inc()
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		var status = http.StatusTooManyRequests
+		var err = errRateLimited
+		render(c, gin.H{}, &status, &err)
+		c.Abort()
+	}
+}
+
+// Returns the address of the caller. The X-Forwarded-For header is only used if the
+// request comes from a trusted proxy: the last address which is not a trusted proxy is taken.
+func clientAddress(c *gin.Context, proxies []*net.IPNet) string {
+	address := c.Request.RemoteAddr
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	if !isTrustedProxy(address, proxies) {
+		return address
+	}
+	forwarded := strings.Split(c.GetHeader("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwarded[i])
+		if hop == "" {
+			break
+		}
+		address = hop
+		if !isTrustedProxy(hop, proxies) {
+			break
+		}
+	}
+	return address
+}
+
+// Parses addresses and CIDR ranges of proxies, invalid entries are ignored
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func isTrustedProxy(address string, proxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the client id of the request header if the client is registered.
+// Unknown ids are ignored, so they cannot be used to get new limits.
+func registeredClientID(c *gin.Context) string {
+	clientID, err := strconv.Atoi(c.GetHeader(model.HeaderClientID))
+	if err != nil || !Server.IsRegistered(clientID) {
+		return ""
+	}
+	return strconv.Itoa(clientID)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func callRegister(engine *gin.Engine, clientID string) *httptest.ResponseRecorder {
+	return callRegisterFrom(engine, "192.0.2.1:1234", "", clientID)
+}
+
+// Calls the register route from the remote address with a X-Forwarded-For header, if any
+func callRegisterFrom(engine *gin.Engine, remoteAddr, forwardedFor, clientID string) *httptest.ResponseRecorder {
+	return callPathFrom(engine, model.PathRegister, remoteAddr, forwardedFor, clientID)
+}
+
+// Like callRegisterFrom, but calls the get route of the path
+func callPathFrom(engine *gin.Engine, path model.RoutePath, remoteAddr, forwardedFor, clientID string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path.String(), nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("Accept", "application/json")
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	if clientID != "" {
+		req.Header.Set(model.HeaderClientID, clientID)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit(t *testing.T) {
+	setup(t)
+	engine := gin.New()
+	initProtocolRoutes(engine, rateLimiters{limitDefault: model.NewRateLimiter(0.5, 2)})
+
+	for i := 0; i < 2; i++ {
+		if w := callRegister(engine, ""); w.Code != http.StatusOK {
+			t.Errorf("request %d rejected with status %d", i, w.Code)
+			t.Fail()
+		}
+	}
+	w := callRegister(engine, "")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "2" {
+		t.Errorf("wrong status %d or Retry-After '%s'", w.Code, w.Header().Get("Retry-After"))
+		t.Fail()
+	}
+
+	// a client id gives no new limit to the address
+	if w = callRegister(engine, "1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("registered client got status %d", w.Code)
+		t.Fail()
+	}
+	if w = callRegister(engine, "99"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("unknown client got status %d", w.Code)
+		t.Fail()
+	}
+
+	if Server.GetStatisticSummary().RateLimited != 3 {
+		t.Errorf("wrong number of limited requests %d", Server.GetStatisticSummary().RateLimited)
+		t.Fail()
+	}
+}
+
+func TestRateLimit_PerClient(t *testing.T) {
+	setup(t)
+	engine := gin.New()
+	initProtocolRoutes(engine, rateLimiters{limitDefault: model.NewRateLimiter(0.5, 2)})
+	clientID, _ := Server.Register()
+
+	// a registered client is limited from all addresses on the routes of customers,
+	// the limiter runs before the session is checked
+	for i := 0; i < 2; i++ {
+		if w := callPathFrom(engine, model.PathCustomerBookings, "192.0.2."+strconv.Itoa(i)+":1234", "", strconv.Itoa(clientID)); w.Code == http.StatusTooManyRequests {
+			t.Errorf("request %d rejected", i)
+		}
+	}
+	if w := callPathFrom(engine, model.PathCustomerBookings, "192.0.2.9:1234", "", strconv.Itoa(clientID)); w.Code != http.StatusTooManyRequests {
+		t.Errorf("client got status %d from a new address", w.Code)
+	}
+	if w := callPathFrom(engine, model.PathCustomerBookings, "192.0.2.9:1234", "", ""); w.Code == http.StatusTooManyRequests {
+		t.Error("address rejected")
+	}
+}
+
+func TestRateLimit_AnonymousIgnoresClient(t *testing.T) {
+	setup(t)
+	engine := gin.New()
+	initProtocolRoutes(engine, rateLimiters{limitDefault: model.NewRateLimiter(0.5, 2)})
+	clientID, _ := Server.Register()
+
+	// anonymous routes are limited per address only, the client id is not linked to them
+	for i := 0; i < 5; i++ {
+		if w := callRegisterFrom(engine, "192.0.2."+strconv.Itoa(i)+":1234", "", strconv.Itoa(clientID)); w.Code != http.StatusOK {
+			t.Errorf("request %d rejected with status %d", i, w.Code)
+		}
+	}
+}
+
+func TestRateLimit_SpoofedForwardedFor(t *testing.T) {
+	setup(t)
+	engine := gin.New()
+	initProtocolRoutes(engine, rateLimiters{limitDefault: model.NewRateLimiter(0.5, 2)})
+
+	// the header of an untrusted caller is ignored
+	limited := 0
+	for i := 0; i < 10; i++ {
+		if w := callRegisterFrom(engine, "192.0.2.1:1234", "198.51.100."+strconv.Itoa(i), ""); w.Code == http.StatusTooManyRequests {
+			limited++
+		}
+	}
+	if limited != 8 {
+		t.Errorf("%d of 10 requests with spoofed headers limited", limited)
+	}
+}
+
+func TestRateLimit_TrustedProxy(t *testing.T) {
+	setup(t)
+	config.SetConfigTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	defer config.SetConfigTrustedProxies(nil)
+	engine := gin.New()
+	initProtocolRoutes(engine, rateLimiters{limitDefault: model.NewRateLimiter(0.5, 2)})
+
+	// the proxies forward the addresses of different callers
+	for i := 0; i < 5; i++ {
+		if w := callRegisterFrom(engine, "192.0.2.1:1234", "198.51.100."+strconv.Itoa(i)+", 10.0.0.1", ""); w.Code != http.StatusOK {
+			t.Errorf("forwarded request %d rejected with status %d", i, w.Code)
+		}
+	}
+	// addresses left of the first untrusted hop are not trusted
+	limited := 0
+	for i := 0; i < 5; i++ {
+		if w := callRegisterFrom(engine, "192.0.2.1:1234", "203.0.113."+strconv.Itoa(i)+", 198.51.100.9", ""); w.Code == http.StatusTooManyRequests {
+			limited++
+		}
+	}
+	if limited != 3 {
+		t.Errorf("%d of 5 requests of the same caller limited", limited)
+	}
+}
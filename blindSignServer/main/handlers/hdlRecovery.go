@@ -16,7 +16,7 @@ func HdlCanBeUsedForRecovery(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqCanBesUsedForRecovery++
+	Server.CntReqCanBesUsedForRecovery.Inc()
 
 	elements := map[string]interface{}{"bLevelID": bLevelID, "adrBundle": adrBundle}
 	err = errors.New("unknown error")
@@ -44,7 +44,7 @@ func HdlRecoveryTest(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqRecoveryTest++
+	Server.CntReqRecoveryTest.Inc()
 
 	elements := map[string]interface{}{"bLevelID": bLevelID, "recoveryToken": recoveryToken, "pkr": pkr, "adrBundle": adrBundle}
 	err = errors.New("unknown error")
@@ -77,7 +77,7 @@ func HdlGetLastAdrBundle(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqGetLastAdrBundle++
+	Server.CntReqGetLastAdrBundle.Inc()
 
 	elements := map[string]interface{}{"bLevelID": bLevelID, "seed": seed}
 	err = errors.New("unknown error")
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"blindSignAccount/main/config"
+	"blindSignAccount/main/model"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+var errInternal = errors.New("internal server error")
+
+// Renders panics of the handlers as an error message. The stack is written to
+// the error log only.
+func recoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				fmt.Fprintf(gin.DefaultErrorWriter, "[Recovery] panic: %v\n%s\n", recovered, debug.Stack())
+				c.Abort()
+				if c.Writer.Written() {
+					return
+				}
+				var status = http.StatusInternalServerError
+				var err = errInternal
+				render(c, gin.H{}, &status, &err)
+			}
+		}()
+		c.Next()
+	}
+}
+
+// Sets headers which keep browsers from sniffing, framing or caching responses
+func securityHeadersMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "no-referrer")
+		header.Set("Content-Security-Policy", "default-src 'none'; frame-ancestors 'none'")
+		header.Set("Cache-Control", "no-store")
+		if c.Request.TLS != nil {
+			header.Set("Strict-Transport-Security", "max-age=31536000")
+		}
+		c.Next()
+	}
+}
+
+// Allows cross-origin requests of the given origins and answers preflight requests.
+// Requests of other origins get no CORS headers, so browsers block them.
+func corsMiddleware(origins []string) gin.HandlerFunc {
+	allowed := map[string]bool{}
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || (!allowed["*"] && !allowed[origin]) {
+			return
+		}
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Vary", "Origin")
+		c.Header("Access-Control-Expose-Headers", "Retry-After")
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", strings.Join([]string{"Content-Type", "Accept", model.HeaderClientID,
+				model.HeaderAuthorization, model.HeaderPartnerKey, model.HeaderAdminKey}, ", "))
+			c.Header("Access-Control-Max-Age", "600")
+			c.AbortWithStatus(http.StatusNoContent)
+		}
+	}
+}
+
+// Limits the size of request bodies. The limit of a route is the same for all api versions.
+func bodyLimitMiddleware() gin.HandlerFunc {
+	versionPrefix := model.APIVersion(model.APIVersion1).Prefix()
+	return func(c *gin.Context) {
+		if c.Request.Body == nil {
+			return
+		}
+		limit := config.GetConfigMaxBodySize(strings.TrimPrefix(c.Request.URL.Path, versionPrefix))
+		if c.Request.ContentLength > limit {
+			var status = http.StatusRequestEntityTooLarge
+			var err = errBodyTooLarge
+			render(c, gin.H{}, &status, &err)
+			c.Abort()
+			return
+		}
+		// the length of chunked bodies is unknown in advance
+		c.Request.Body = &limitedBody{ReadCloser: c.Request.Body, remaining: limit}
+	}
+}
+
+// A body which returns errBodyTooLarge if more than the remaining bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (body *limitedBody) Read(p []byte) (n int, err error) {
+	if body.remaining < 0 {
+		return 0, errBodyTooLarge
+	}
+	// read one byte more than allowed to detect larger bodies
+	if int64(len(p)) > body.remaining+1 {
+		p = p[:body.remaining+1]
+	}
+	n, err = body.ReadCloser.Read(p)
+	body.remaining -= int64(n)
+	if body.remaining < 0 {
+		return n + int(body.remaining), errBodyTooLarge
+	}
+	return n, err
+}
+
+type responseControllerKey struct{}
+
+// Passes the response controller of the connection to the handlers, so a long running
+// response like the statistic stream can lift the write timeout of the server.
+// The router is wrapped by it before gin wraps the response writer.
+func WithResponseController(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := context.WithValue(req.Context(), responseControllerKey{}, http.NewResponseController(w))
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// Removes the write deadline of the request's connection. Fails if the request was not
+// passed by WithResponseController or the connection has no deadlines.
+func liftWriteDeadline(c *gin.Context) error {
+	controller, ok := c.Request.Context().Value(responseControllerKey{}).(*http.ResponseController)
+	if !ok {
+		return errors.New("no response controller")
+	}
+	return controller.SetWriteDeadline(time.Time{})
+}
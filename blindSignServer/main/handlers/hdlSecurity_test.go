@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	errorWriter := gin.DefaultErrorWriter
+	gin.DefaultErrorWriter = ioutil.Discard
+	defer func() { gin.DefaultErrorWriter = errorWriter }()
+
+	engine := gin.New()
+	engine.Use(recoveryMiddleware())
+	engine.GET("/panic", func(c *gin.Context) { panic("test") })
+
+	req, _ := http.NewRequest("GET", "/panic", nil)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	// the panic is rendered as error message
+	var msg msg
+	if err := json.Unmarshal(w.Body.Bytes(), &msg); err != nil || w.Code != http.StatusInternalServerError || msg.Err != errInternal.Error() {
+		t.Errorf("wrong status %d or body %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	setup(t)
+	req, _ := http.NewRequest("GET", model.RoutePath(model.PathGetSystemInformation).String(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Header().Get("X-Content-Type-Options") != "nosniff" || w.Header().Get("X-Frame-Options") != "DENY" {
+		t.Errorf("security headers missing: %v", w.Header())
+		t.Fail()
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	engine := gin.New()
+	engine.Use(corsMiddleware([]string{"https://app.example"}))
+	engine.POST("/cors", func(c *gin.Context) { c.Status(http.StatusAccepted) })
+
+	// preflight requests of allowed origins are answered
+	req, _ := http.NewRequest("OPTIONS", "/cors", nil)
+	req.Header.Set("Origin", "https://app.example")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example" ||
+		w.Header().Get("Access-Control-Allow-Methods") == "" {
+		t.Errorf("wrong preflight response %d: %v", w.Code, w.Header())
+		t.Fail()
+	}
+	// the partner and admin routes are called by browsers also
+	for _, header := range []string{model.HeaderPartnerKey, model.HeaderAdminKey} {
+		if !strings.Contains(w.Header().Get("Access-Control-Allow-Headers"), header) {
+			t.Errorf("header %s is not allowed", header)
+		}
+	}
+	if !strings.Contains(w.Header().Get("Access-Control-Allow-Methods"), http.MethodDelete) {
+		t.Error("DELETE is not allowed")
+	}
+
+	// other origins get no CORS headers
+	req, _ = http.NewRequest("POST", "/cors", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("wrong response %d for unknown origin: %v", w.Code, w.Header())
+		t.Fail()
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	setup(t)
+	body := bytes.Repeat([]byte("a"), 128<<10)
+
+	req, _ := http.NewRequest("POST", model.RoutePath(model.PathSendBooking).String(), bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("wrong status %d", w.Code)
+		t.Fail()
+	}
+
+	// bodies of unknown length are limited while reading
+	req, _ = http.NewRequest("POST", model.RoutePath(model.PathSendBooking).Versioned(model.APIVersion1),
+		ioutil.NopCloser(bytes.NewReader(body)))
+	req.ContentLength = -1
+	setSession(req, loginCustomer("customer", t))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("wrong status %d for body of unknown length", w.Code)
+		t.Fail()
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Opens the statistic stream of the test server with the admin key
+func getStatisticStream(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url+model.RouteStatisticStream+"?interval=50ms", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(model.HeaderAdminKey, utAdminKey)
+	return http.DefaultClient.Do(req)
+}
+
+func TestGetStatisticStream(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	session := loginCustomer("customer", t)
+
+	// streaming needs a real connection
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, err := getStatisticStream(ts.URL)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.Errorf("wrong status %d or content type %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+		t.FailNow()
+	}
+
+	// a booking between the first and the second event
+	reader := bufio.NewReader(resp.Body)
+	events := make([]*model.StatisticEvent, 0, 2)
+	for len(events) < 2 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if strings.HasPrefix(line, "event:") && strings.TrimSpace(line[6:]) != "statistic" {
+			t.Error("unexpected event: " + line)
+			t.Fail()
+		}
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		var event model.StatisticEvent
+		if err = json.Unmarshal([]byte(line[5:]), &event); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		events = append(events, &event)
+		if len(events) == 1 {
+			callURLAsCustomer("POST", model.RoutePath(model.PathSendBooking).String(), session, http.StatusAccepted,
+				strings.NewReader(`{"flightID": 2}`), t)
+		}
+	}
+
+	if events[1].Delta.CntReqSendBooking != 1 || events[1].Summary.CntReqSendBooking != 1 {
+		t.Errorf("wrong booking counter: delta %d, total %d", events[1].Delta.CntReqSendBooking,
+			events[1].Summary.CntReqSendBooking)
+		t.Fail()
+	}
+	if events[1].Interval <= 0 || len(events[1].Rates) != len(Server.BonusList) {
+		t.Errorf("wrong interval %f or rates", events[1].Interval)
+		t.Fail()
+	}
+}
+
+func TestGetStatisticStream_Unauthorized(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	callURLAsAdmin("GET", model.RouteStatisticStream, "", http.StatusUnauthorized, nil, t)
+	callURLAsAdmin("GET", model.RouteStatisticStream, "wrong-key", http.StatusUnauthorized, nil, t)
+}
+
+func TestGetStatisticStream_InvalidInterval(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	callURLAsAdmin("GET", model.RouteStatisticStream+"?interval=never", utAdminKey, http.StatusBadRequest, nil, t)
+}
+
+func TestGetStatisticStream_WriteTimeout(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+
+	// the stream outlasts the write timeout of the server
+	ts := httptest.NewUnstartedServer(WithResponseController(r))
+	ts.Config.WriteTimeout = 200 * time.Millisecond
+	ts.Start()
+	defer ts.Close()
+
+	resp, err := getStatisticStream(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	start := time.Now()
+	reader := bufio.NewReader(resp.Body)
+	for events := 0; events < 10; {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("stream ended after %s: %v", time.Since(start), err)
+		}
+		if strings.HasPrefix(line, "data:") {
+			events++
+		}
+	}
+}
+
+func TestGetStatisticStream_IntervalExceedsWriteTimeout(t *testing.T) {
+	setup(t)
+	setupAdminKey(t)
+	// without a response controller the write timeout of 30s applies
+	callURLAsAdmin("GET", model.RouteStatisticStream+"?interval=40s", utAdminKey, http.StatusBadRequest, nil, t)
+}
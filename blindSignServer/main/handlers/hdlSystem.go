@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/crypt"
 	"blindSignAccount/main/model"
+	"encoding/base64"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 	"syscall"
 	"time"
@@ -15,7 +18,7 @@ func GetReset(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqReset++
+	Server.CntReqReset.Inc()
 
 	err = errors.New("unknown error")
 	defer render(c, gin.H{"payload": &data}, &status, &err)
@@ -32,7 +35,7 @@ func GetSystemInformation(c *gin.Context) {
 	var flights []*model.Flight
 	var bLevels []*model.BonusLevel
 
-	Server.CntReqGetSystemInformation++
+	Server.CntReqGetSystemInformation.Inc()
 
 	err = errors.New("unknown error")
 	defer render(c, gin.H{"payload": &data}, &status, &err)
@@ -60,7 +63,7 @@ func GetSystemStatistic(c *gin.Context) {
 	var err error
 	var data *model.StatisticSummary
 
-	Server.CntReqStatistic++
+	Server.CntReqStatistic.Inc()
 
 	err = errors.New("unknown error")
 	defer render(c, gin.H{"payload": &data}, &status, &err)
@@ -70,13 +73,63 @@ func GetSystemStatistic(c *gin.Context) {
 	status = http.StatusOK
 }
 
+// Streams the statistic summary as server-sent events. The interval is taken from the
+// configuration and can be overridden by the query parameter 'interval' (e.g. 500ms).
+// The write timeout of the server does not apply to the stream, it runs until the client
+// disconnects. Without a response controller the stream ends before the write timeout.
+func GetStatisticStream(c *gin.Context) {
+	var err error
+	var status = http.StatusBadRequest
+	interval := config.GetConfigStatisticInterval()
+
+	Server.CntReqStatistic.Inc()
+
+	if param := c.Query("interval"); param != "" {
+		if interval, err = time.ParseDuration(param); err != nil || interval <= 0 {
+			err = errors.New("invalid interval: " + param)
+			render(c, gin.H{"payload": nil}, &status, &err)
+			return
+		}
+	}
+
+	var end time.Time
+	if liftWriteDeadline(c) != nil {
+		end = time.Now().Add(config.GetConfigWriteTimeout() - interval)
+		if !time.Now().Before(end) {
+			err = errors.New("interval " + interval.String() + " exceeds the write timeout")
+			render(c, gin.H{"payload": nil}, &status, &err)
+			return
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last, lastTime := Server.GetStatisticSummary(), time.Now()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case now := <-ticker.C:
+			if !end.IsZero() && now.After(end) {
+				return false
+			}
+			current := Server.GetStatisticSummary()
+			c.SSEvent("statistic", model.NewStatisticEvent(current, last, now.Sub(lastTime)))
+			last, lastTime = current, now
+			return true
+		}
+	})
+}
+
 func PostSystemExit(c *gin.Context) {
 	var data string
 	var status = http.StatusBadRequest
 	var err error
 	var exitStatus int
 
-	Server.CntReqExit++
+	Server.CntReqExit.Inc()
 
 	elements := map[string]interface{}{"exitStatus": exitStatus}
 	err = errors.New("unknown error")
@@ -103,7 +156,7 @@ func GetSystemRegister(c *gin.Context) {
 	var clientID int
 	var data = make(map[string]interface{}, 1)
 
-	Server.CntReqRegister++
+	Server.CntReqRegister.Inc()
 
 	err = errors.New("unknown error")
 	defer render(c, gin.H{"payload": &data}, &status, &err)
@@ -124,7 +177,7 @@ func PostBlindSignature(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqBlindSignature++
+	Server.CntReqBlindSignature.Inc()
 
 	elements := map[string]interface{}{"bLevelID": bLevelID, "token": token, "blindToken": blindToken, "action": action}
 	err = errors.New("unknown error")
@@ -138,7 +191,7 @@ func PostBlindSignature(c *gin.Context) {
 	token = elements["token"].(string)
 	blindToken = elements["blindToken"].([]byte)
 	action = elements["action"].(int)
-	if action != model.ActionBooking && action != model.ActionParticipate {
+	if !model.IsKnownAction(action) {
 		err = errors.New("unknown action")
 		return
 	}
@@ -148,6 +201,10 @@ func PostBlindSignature(c *gin.Context) {
 	}
 
 	data["blindSignature"] = blindSignature
+	if responseEncoding(c).IsBinary() {
+		// binary encodings carry the signature raw
+		data["blindSignature"], _ = base64.URLEncoding.DecodeString(blindSignature)
+	}
 
 	status = http.StatusAccepted
 }
@@ -161,7 +218,7 @@ func HdlSetAddress(c *gin.Context) {
 	var err error
 	var data = make(map[string]interface{}, 0)
 
-	Server.CntReqSetAddress++
+	Server.CntReqSetAddress.Inc()
 
 	elements := map[string]interface{}{"bLevelID": bLevelID, "hashValue": hashValue, "signature": signature,
 		"adrBundle": adrBundle, "action": action, "pkr": pkr}
@@ -178,7 +235,7 @@ func HdlSetAddress(c *gin.Context) {
 	adrBundle = elements["adrBundle"].(*crypt.AddressBundle)
 	action = elements["action"].(int)
 	pkr = elements["pkr"].(string)
-	if action != model.ActionBooking && action != model.ActionParticipate {
+	if !model.IsKnownAction(action) {
 		err = errors.New("unknown action")
 		return
 	}
@@ -188,3 +245,24 @@ func HdlSetAddress(c *gin.Context) {
 
 	status = http.StatusAccepted
 }
+
+// Liveness probe: the server answers requests
+func GetHealth(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	data := model.MsgDataHealth{Status: "ok"}
+	render(c, gin.H{"payload": &data}, &status, &err)
+}
+
+// Readiness probe: the server can serve protocol requests
+func GetReadiness(c *gin.Context) {
+	var status = http.StatusOK
+	var err error
+	var data model.MsgDataReadiness
+
+	data.Ready, data.Checks = Server.Readiness()
+	if !data.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	render(c, gin.H{"payload": &data}, &status, &err)
+}
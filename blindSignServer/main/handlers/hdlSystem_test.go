@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetSystemInformation(t *testing.T) {
@@ -188,7 +189,7 @@ func TestStatistic(t *testing.T) {
 	var actionBooking = 0
 	var actionParticipate = 1
 
-	_, _ = Server.Booking(1, 1, "low")
+	_, _ = Server.Booking(1, 1, model.FareEconomy)
 	response := callURL("GET", model.RoutePath(model.PathStatistic).String(), http.StatusOK, nil, t)
 
 	if err := json.Unmarshal([]byte(response.String()), &msgStatistic); err != nil {
@@ -261,3 +262,38 @@ func TestGetDebugInformation(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGetHealth(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseHealth
+	response := callURL("GET", model.RouteHealth, http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Data.Status != "ok" {
+		t.Errorf("wrong health response: %s", response.String())
+		t.Fail()
+	}
+}
+
+func TestGetReadiness(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseReadiness
+	response := callURL("GET", model.RouteReady, http.StatusOK, nil, t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || !msg.Data.Ready || len(msg.Data.Checks) != 3 {
+		t.Errorf("wrong readiness response: %s", response.String())
+		t.Fail()
+	}
+
+	// a full signing queue makes the server unready
+	Server.SetSignPool(model.NewSignPool(1, 1, time.Second))
+	done := make(chan struct{})
+	defer close(done)
+	for i := 0; i < 2; i++ {
+		go Server.SignPool.Do(func() error {
+			<-done
+			return nil
+		})
+	}
+	for Server.SignPool.Depth() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	callURL("GET", model.RouteReady, http.StatusServiceUnavailable, nil, t)
+}
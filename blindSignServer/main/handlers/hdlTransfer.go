@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"blindSignAccount/main/pb"
+	"encoding/base64"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+)
+
+// Spends a code for the blind signature of the Token of its receiver, the body contains the
+// code, its bonus level, its points and the blind Token
+func HdlTransferCode(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var req model.MsgRequestTransferCode
+	var data = make(map[string]interface{}, 0)
+
+	err = errors.New("unknown error")
+	defer render(c, gin.H{"payload": &data}, &status, &err)
+
+	if err = decodeBody(c, &req); err != nil {
+		return
+	}
+
+	var blindSignature string
+	if blindSignature, err = Server.TransferCode(req.Code, req.BLevelID, req.Points, req.BlindToken); err != nil {
+		return
+	}
+
+	data["blindSignature"] = blindSignature
+	status = http.StatusAccepted
+}
+
+func ProtoTransferCode(c *gin.Context) {
+	var status = http.StatusBadRequest
+	var err error
+	var blindSignature string
+	var req pb.TransferCodeRequest
+	var resp pb.TransferCodeResponse
+
+	err = errors.New("unknown error")
+	defer renderProto(c, &resp, &resp.Err, &status, &err)
+
+	if err = c.ShouldBindWith(&req, binding.ProtoBuf); err != nil {
+		return
+	}
+
+	if blindSignature, err = Server.TransferCode(req.Code, req.BLevelId, int(req.Points), req.BlindToken); err != nil {
+		return
+	}
+	// the signature is sent raw
+	if resp.BlindSignature, err = base64.URLEncoding.DecodeString(blindSignature); err != nil {
+		return
+	}
+
+	status = http.StatusAccepted
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"blindSignAccount/main/model"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHdlTransferCode(t *testing.T) {
+	setup(t)
+	var msg model.MsgResponseTransferCode
+
+	values := model.MsgRequestTransferCode{Code: "unknown", BLevelID: "low", Points: 1, BlindToken: model.HexBytes{1, 2}}
+	jsonValue, _ := json.Marshal(values)
+	response := callURL("POST", model.RoutePath(model.PathTransferCode).String(), http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+	if err := json.Unmarshal(response.Bytes(), &msg); err != nil || msg.Err != "code is not valid" {
+		t.Errorf("wrong response: %s", response.String())
+	}
+
+	session := loginCustomer("customer", t)
+	callURLAsCustomer("POST", model.RoutePath(model.PathTransferCode).String(), session, http.StatusBadRequest, bytes.NewBuffer(jsonValue), t)
+}
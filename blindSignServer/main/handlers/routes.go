@@ -1,24 +1,66 @@
 package handlers
 
 import (
+	"blindSignAccount/main/config"
 	"blindSignAccount/main/model"
 	"github.com/gin-gonic/gin"
 )
 
 func InitRoutes(r *gin.Engine) {
-	r.GET(model.RoutePath(model.PathGetSystemInformation).String(), GetSystemInformation)
-	r.GET(model.RoutePath(model.PathReset).String(), GetReset)
-	r.POST(model.RoutePath(model.PathSendBooking).String(), SendBooking)
-	r.POST(model.RoutePath(model.PathGetBookingCode).String(), HdlGetBookingCode)
-	r.POST(model.RoutePath(model.PathBlindSignature).String(), PostBlindSignature)
-	r.POST(model.RoutePath(model.PathSetAddress).String(), HdlSetAddress)
-	r.POST(model.RoutePath(model.PathAccessBonusSystem).String(), HdlAccessBonusSystem)
-	r.POST(model.RoutePath(model.PathParticipate).String(), HdlParticipate)
-	r.POST(model.RoutePath(model.PathCanBesUsedForRecovery).String(), HdlCanBeUsedForRecovery)
-	r.POST(model.RoutePath(model.PathRecoveryTest).String(), HdlRecoveryTest)
-	r.GET(model.RoutePath(model.PathRegister).String(), GetSystemRegister)
+	r.Use(metricsMiddleware(r), recoveryMiddleware(), securityHeadersMiddleware(),
+		corsMiddleware(config.GetConfigCORSOrigins()), bodyLimitMiddleware())
+
+	// the legacy routes are kept for clients without version support
+	limiters := newRateLimiters()
+	initProtocolRoutes(r, limiters)
+
+	v1 := r.Group(model.APIVersion(model.APIVersion1).Prefix())
+	initProtocolRoutes(v1, limiters)
+	v1.GET(model.RouteOpenAPI, GetOpenAPIDocument)
+
+	r.GET(model.RouteAPIVersions, GetAPIVersions)
+	r.GET(model.RouteStatisticStream, limiters.limitRoute(limitDefault, model.RouteStatisticStream), requireAdmin(), GetStatisticStream)
+	r.GET(model.RouteMetrics, GetMetrics)
+	r.GET(model.RouteHealth, GetHealth)
+	r.GET(model.RouteReady, GetReadiness)
+	adminFlights := limiters.limitRoute(limitDefault, model.RouteAdminFlights)
+	r.GET(model.RouteAdminFlights, adminFlights, requireAdmin(), GetAdminFlights)
+	r.POST(model.RouteAdminFlights, adminFlights, requireAdmin(), PostAdminFlight)
+	r.DELETE(model.RouteAdminFlights, adminFlights, requireAdmin(), DeleteAdminFlight)
+	r.POST(model.RouteAdminCustomerTier, limiters.limitRoute(limitDefault, model.RouteAdminCustomerTier), requireAdmin(), PostAdminCustomerTier)
+}
+
+// Registers all routes of the protocol for one route group. Requests with protocol
+// buffer bodies are served by the protobuf handlers. The routes of the anonymous
+// protocol steps reject customer sessions.
+func initProtocolRoutes(r gin.IRoutes, limiters rateLimiters) {
+	r.GET(model.RoutePath(model.PathGetSystemInformation).String(), limiters.limit(limitDefault, model.PathGetSystemInformation), negotiateProto(GetSystemInformation, ProtoGetSystemInformation))
+	r.GET(model.RoutePath(model.PathReset).String(), negotiateProto(GetReset, ProtoGetReset))
+	r.POST(model.RoutePath(model.PathSendBooking).String(), limiters.limit(limitDefault, model.PathSendBooking), negotiateProto(SendBooking, ProtoSendBooking))
+	r.POST(model.RoutePath(model.PathGetBookingCode).String(), limiters.limit(limitCrypto, model.PathGetBookingCode), rejectCustomerSession(), negotiateProto(HdlGetBookingCode, ProtoGetBookingCode))
+	r.POST(model.RoutePath(model.PathBlindSignature).String(), limiters.limit(limitCrypto, model.PathBlindSignature), rejectCustomerSession(), negotiateProto(PostBlindSignature, ProtoBlindSignature))
+	r.POST(model.RoutePath(model.PathSetAddress).String(), limiters.limit(limitCrypto, model.PathSetAddress), rejectCustomerSession(), negotiateProto(HdlSetAddress, ProtoSetAddress))
+	r.POST(model.RoutePath(model.PathAccessBonusSystem).String(), limiters.limit(limitCrypto, model.PathAccessBonusSystem), rejectCustomerSession(), negotiateProto(HdlAccessBonusSystem, ProtoAccessBonusSystem))
+	r.POST(model.RoutePath(model.PathParticipate).String(), limiters.limit(limitCrypto, model.PathParticipate), rejectCustomerSession(), negotiateProto(HdlParticipate, ProtoParticipate))
+	r.GET(model.RoutePath(model.PathCoinDenominations).String(), limiters.limit(limitDefault, model.PathCoinDenominations), negotiateProto(GetCoinDenominations, ProtoCoinDenominations))
+	r.POST(model.RoutePath(model.PathWithdrawCoins).String(), limiters.limit(limitCrypto, model.PathWithdrawCoins), rejectCustomerSession(), negotiateProto(HdlWithdrawCoins, ProtoWithdrawCoins))
+	r.POST(model.RoutePath(model.PathSpendCoins).String(), limiters.limit(limitCrypto, model.PathSpendCoins), rejectCustomerSession(), negotiateProto(HdlSpendCoins, ProtoSpendCoins))
+	r.POST(model.RoutePath(model.PathTransferCode).String(), limiters.limit(limitCrypto, model.PathTransferCode), rejectCustomerSession(), negotiateProto(HdlTransferCode, ProtoTransferCode))
+	r.POST(model.RoutePath(model.PathExchangeCodes).String(), limiters.limit(limitCrypto, model.PathExchangeCodes), rejectCustomerSession(), negotiateProto(HdlExchangeCodes, ProtoExchangeCodes))
+	r.POST(model.RoutePath(model.PathCanBesUsedForRecovery).String(), limiters.limit(limitDefault, model.PathCanBesUsedForRecovery), rejectCustomerSession(), negotiateProto(HdlCanBeUsedForRecovery, ProtoCanBeUsedForRecovery))
+	r.POST(model.RoutePath(model.PathRecoveryTest).String(), limiters.limit(limitDefault, model.PathRecoveryTest), rejectCustomerSession(), negotiateProto(HdlRecoveryTest, ProtoRecoveryTest))
+	r.GET(model.RoutePath(model.PathRegister).String(), limiters.limit(limitDefault, model.PathRegister), negotiateProto(GetSystemRegister, ProtoGetSystemRegister))
 	r.POST(model.RoutePath(model.PathExit).String(), PostSystemExit)
 	r.GET(model.RoutePath(model.PathStatistic).String(), GetSystemStatistic)
-	r.GET(model.RoutePath(model.PathDebugInfos).String(), GetDebugInformation)
-	r.POST(model.RoutePath(model.PathLastAdrBdl).String(), HdlGetLastAdrBundle)
+	r.GET(model.RoutePath(model.PathDebugInfos).String(), negotiateProto(GetDebugInformation, ProtoGetDebugInformation))
+	r.POST(model.RoutePath(model.PathCancelBooking).String(), limiters.limit(limitDefault, model.PathCancelBooking), CancelBooking)
+	r.POST(model.RoutePath(model.PathCustomerRegister).String(), limiters.limit(limitCrypto, model.PathCustomerRegister), negotiateProto(PostCustomerRegister, ProtoCustomerRegister))
+	r.POST(model.RoutePath(model.PathCustomerLogin).String(), limiters.limit(limitCrypto, model.PathCustomerLogin), negotiateProto(PostCustomerLogin, ProtoCustomerLogin))
+	r.POST(model.RoutePath(model.PathCustomerLogout).String(), limiters.limit(limitDefault, model.PathCustomerLogout), PostCustomerLogout)
+	r.GET(model.RoutePath(model.PathCustomerBookings).String(), limiters.limit(limitDefault, model.PathCustomerBookings), negotiateProto(GetCustomerBookings, ProtoCustomerBookings))
+	r.GET(model.RoutePath(model.PathPartnerKeys).String(), limiters.limit(limitDefault, model.PathPartnerKeys), GetPartnerKeys)
+	r.GET(model.RoutePath(model.PathPartnerRevocations).String(), limiters.limit(limitDefault, model.PathPartnerRevocations), GetPartnerRevocations)
+	r.POST(model.RoutePath(model.PathPartnerRedeem).String(), limiters.limit(limitDefault, model.PathPartnerRedeem), rejectCustomerSession(), PostPartnerRedeem)
+	r.GET(model.RoutePath(model.PathFlightSearch).String(), limiters.limit(limitDefault, model.PathFlightSearch), GetFlightSearch)
+	r.POST(model.RoutePath(model.PathLastAdrBdl).String(), limiters.limit(limitDefault, model.PathLastAdrBdl), rejectCustomerSession(), negotiateProto(HdlGetLastAdrBundle, ProtoGetLastAdrBundle))
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 )
 
@@ -43,12 +44,32 @@ func main() {
 		gin.DefaultErrorWriter = errorLogFile
 	}
 
-	// Initialize routes
-	router = gin.Default()
+	// the server is built from the configuration
+	if err := handlers.InitServer(); err != nil {
+		panic(err)
+	}
+	if flightFile := config.GetConfigFlightFile(); flightFile != "" {
+		log.Println("flights loaded from '" + flightFile + "'")
+	}
+
+	// revocation filters are published per epoch
+	go handlers.Server.SealRevocationsEvery(config.GetConfigRevocationInterval(), make(chan struct{}))
+
+	// Initialize routes, panics are recovered by the handlers' middleware
+	router = gin.New()
+	router.Use(gin.Logger())
 	handlers.InitRoutes(router)
 
-	// Run
-	if err := router.Run(config.GetConfigAddress()); err != nil {
+	// Run, the statistic stream lifts the write timeout by the response controller
+	server := &http.Server{
+		Addr:              config.GetConfigAddress(),
+		Handler:           handlers.WithResponseController(router),
+		ReadTimeout:       config.GetConfigReadTimeout(),
+		ReadHeaderTimeout: config.GetConfigReadHeaderTimeout(),
+		WriteTimeout:      config.GetConfigWriteTimeout(),
+		IdleTimeout:       config.GetConfigIdleTimeout(),
+	}
+	if err := server.ListenAndServe(); err != nil {
 		fmt.Print(err)
 	}
 }